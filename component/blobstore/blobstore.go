@@ -0,0 +1,119 @@
+// Package blobstore provides access to containers and objects exposed through
+// [wasi:blobstore], streaming object contents as [io.Reader] and [io.Writer].
+//
+// [wasi:blobstore]: https://github.com/WebAssembly/wasi-blobstore
+package blobstore
+
+import (
+	"errors"
+	"time"
+
+	"go.wasmcloud.dev/component/gen/wasi/blobstore/blobstore"
+	"go.wasmcloud.dev/component/gen/wasi/blobstore/types"
+)
+
+var (
+	// ErrClosed is returned when using a container after [Container.Close].
+	ErrClosed = errors.New("blobstore: container closed")
+	// ErrInvalidRange is returned for reads starting at a negative offset.
+	ErrInvalidRange = errors.New("blobstore: invalid range")
+)
+
+// Error is an error reported by the host. wasi:blobstore only describes
+// errors as strings, so Message is passed through as is.
+type Error struct {
+	Op      string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return "blobstore: " + e.Op + ": " + e.Message
+}
+
+// ObjectID identifies an object across containers.
+type ObjectID struct {
+	Container string
+	Object    string
+}
+
+// ContainerInfo describes a container.
+type ContainerInfo struct {
+	Name      string
+	CreatedAt time.Time
+}
+
+// ObjectInfo describes an object.
+type ObjectInfo struct {
+	Name      string
+	Container string
+	CreatedAt time.Time
+	Size      int64
+}
+
+// CreateContainer creates an empty container and returns it open.
+func CreateContainer(name string) (*Container, error) {
+	res := blobstore.CreateContainer(types.ContainerName(name))
+	if err := resultError("create container", res.Err()); err != nil {
+		return nil, err
+	}
+	return newContainer(name, *res.OK()), nil
+}
+
+// OpenContainer opens an existing container.
+func OpenContainer(name string) (*Container, error) {
+	res := blobstore.GetContainer(types.ContainerName(name))
+	if err := resultError("open container", res.Err()); err != nil {
+		return nil, err
+	}
+	return newContainer(name, *res.OK()), nil
+}
+
+// DeleteContainer deletes a container and all objects within it.
+func DeleteContainer(name string) error {
+	res := blobstore.DeleteContainer(types.ContainerName(name))
+	return resultError("delete container", res.Err())
+}
+
+// ContainerExists reports whether a container exists.
+func ContainerExists(name string) (bool, error) {
+	res := blobstore.ContainerExists(types.ContainerName(name))
+	if err := resultError("container exists", res.Err()); err != nil {
+		return false, err
+	}
+	return *res.OK(), nil
+}
+
+// CopyObject copies src to dest, which may be in another container. An
+// existing dest object is overwritten.
+func CopyObject(src, dest ObjectID) error {
+	res := blobstore.CopyObject(src.wasi(), dest.wasi())
+	return resultError("copy object", res.Err())
+}
+
+// MoveObject moves or renames src to dest, which may be in another
+// container. An existing dest object is overwritten.
+func MoveObject(src, dest ObjectID) error {
+	res := blobstore.MoveObject(src.wasi(), dest.wasi())
+	return resultError("move object", res.Err())
+}
+
+func (id ObjectID) wasi() types.ObjectID {
+	return types.ObjectID{
+		Container: types.ContainerName(id.Container),
+		Object:    types.ObjectName(id.Object),
+	}
+}
+
+// timestamp converts wasi:blobstore timestamps, which wasmCloud reports in
+// seconds since the Unix epoch.
+func timestamp(t types.Timestamp) time.Time {
+	return time.Unix(int64(t), 0)
+}
+
+// resultError converts the error case of a result, nil when the result is OK.
+func resultError(op string, e *types.Error) error {
+	if e == nil {
+		return nil
+	}
+	return &Error{Op: op, Message: string(*e)}
+}
@@ -0,0 +1,270 @@
+package blobstore
+
+import (
+	"io"
+	"iter"
+	"math"
+	"runtime"
+	"strings"
+	"sync"
+
+	"go.bytecodealliance.org/cm"
+	"go.wasmcloud.dev/component/gen/wasi/blobstore/container"
+	"go.wasmcloud.dev/component/gen/wasi/blobstore/types"
+	"go.wasmcloud.dev/component/internal/wasiio"
+)
+
+// listBatchSize is the number of object names fetched per host call.
+const listBatchSize = 256
+
+// Container is an open blobstore container.
+//
+// The underlying resource is released by [Container.Close]. Containers that
+// are never closed are released once garbage collected, on runtimes that
+// support finalizers. Readers and writers stay usable after the container
+// is closed.
+type Container struct {
+	mu     sync.RWMutex
+	name   string
+	handle container.Container
+	closed bool
+}
+
+func newContainer(name string, handle container.Container) *Container {
+	c := &Container{name: name, handle: handle}
+	runtime.SetFinalizer(c, (*Container).Close)
+	return c
+}
+
+// Name returns the name the container was opened with.
+func (c *Container) Name() string {
+	return c.name
+}
+
+// Close releases the container. It is safe to call more than once.
+func (c *Container) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	c.handle.ResourceDrop()
+	runtime.SetFinalizer(c, nil)
+	return nil
+}
+
+// use runs fn with the container handle, holding it open until fn returns.
+func (c *Container) use(fn func(container.Container) error) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return ErrClosed
+	}
+	return fn(c.handle)
+}
+
+// Info returns the container metadata.
+func (c *Container) Info() (*ContainerInfo, error) {
+	var info *ContainerInfo
+	err := c.use(func(h container.Container) error {
+		res := h.Info()
+		if err := resultError("container info", res.Err()); err != nil {
+			return err
+		}
+		meta := res.OK()
+		info = &ContainerInfo{
+			Name:      string(meta.Name),
+			CreatedAt: timestamp(meta.CreatedAt),
+		}
+		return nil
+	})
+	return info, err
+}
+
+// ObjectInfo returns the metadata of the named object.
+func (c *Container) ObjectInfo(name string) (*ObjectInfo, error) {
+	var info *ObjectInfo
+	err := c.use(func(h container.Container) error {
+		res := h.ObjectInfo(types.ObjectName(name))
+		if err := resultError("object info", res.Err()); err != nil {
+			return err
+		}
+		meta := res.OK()
+		info = &ObjectInfo{
+			Name:      string(meta.Name),
+			Container: string(meta.Container),
+			CreatedAt: timestamp(meta.CreatedAt),
+			Size:      int64(meta.Size),
+		}
+		return nil
+	})
+	return info, err
+}
+
+// HasObject reports whether the named object exists.
+func (c *Container) HasObject(name string) (bool, error) {
+	var exists bool
+	err := c.use(func(h container.Container) error {
+		res := h.HasObject(types.ObjectName(name))
+		if err := resultError("has object", res.Err()); err != nil {
+			return err
+		}
+		exists = *res.OK()
+		return nil
+	})
+	return exists, err
+}
+
+// DeleteObject deletes the named object. Deleting a missing object is not an
+// error.
+func (c *Container) DeleteObject(name string) error {
+	return c.use(func(h container.Container) error {
+		res := h.DeleteObject(types.ObjectName(name))
+		return resultError("delete object", res.Err())
+	})
+}
+
+// DeleteObjects deletes all of the named objects in a single host call.
+func (c *Container) DeleteObjects(names ...string) error {
+	objects := make([]types.ObjectName, len(names))
+	for i, name := range names {
+		objects[i] = types.ObjectName(name)
+	}
+	return c.use(func(h container.Container) error {
+		res := h.DeleteObjects(cm.ToList(objects))
+		return resultError("delete objects", res.Err())
+	})
+}
+
+// Clear deletes every object, leaving the container empty.
+func (c *Container) Clear() error {
+	return c.use(func(h container.Container) error {
+		res := h.Clear()
+		return resultError("clear", res.Err())
+	})
+}
+
+// Objects iterates over the names of all objects in the container, in no
+// particular order.
+//
+// Iteration stops at the first error, which is yielded with an empty name.
+func (c *Container) Objects() iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		var names container.StreamObjectNames
+		err := c.use(func(h container.Container) error {
+			res := h.ListObjects()
+			if err := resultError("list objects", res.Err()); err != nil {
+				return err
+			}
+			names = *res.OK()
+			return nil
+		})
+		if err != nil {
+			yield("", err)
+			return
+		}
+		defer names.ResourceDrop()
+
+		for {
+			res := names.ReadStreamObjectNames(listBatchSize)
+			if err := resultError("list objects", res.Err()); err != nil {
+				yield("", err)
+				return
+			}
+			page := res.OK()
+			for _, name := range page.F0.Slice() {
+				if !yield(string(name), nil) {
+					return
+				}
+			}
+			if page.F1 {
+				return
+			}
+		}
+	}
+}
+
+// NewReader returns a reader for the whole named object. The caller must
+// close it.
+func (c *Container) NewReader(name string) (io.ReadCloser, error) {
+	return c.NewRangeReader(name, 0, -1)
+}
+
+// NewRangeReader returns a reader for length bytes of the named object,
+// starting at offset. A negative length reads until the end of the object.
+// The caller must close it.
+func (c *Container) NewRangeReader(name string, offset, length int64) (io.ReadCloser, error) {
+	if offset < 0 {
+		return nil, ErrInvalidRange
+	}
+	if length == 0 {
+		return io.NopCloser(strings.NewReader("")), nil
+	}
+	// wasi:blobstore ranges are inclusive of end
+	end := uint64(math.MaxUint64)
+	if length > 0 {
+		end = uint64(offset) + uint64(length) - 1
+	}
+
+	var value types.IncomingValue
+	err := c.use(func(h container.Container) error {
+		res := h.GetData(types.ObjectName(name), uint64(offset), end)
+		if err := resultError("get data", res.Err()); err != nil {
+			return err
+		}
+		value = *res.OK()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := types.IncomingValueIncomingValueConsumeAsync(value)
+	if err := resultError("get data", res.Err()); err != nil {
+		return nil, err
+	}
+	return wasiio.NewReader(*res.OK()), nil
+}
+
+// NewWriter returns a writer creating or replacing the named object. The
+// object is complete once the writer is closed, and Close reports whether the
+// host stored it.
+func (c *Container) NewWriter(name string) (io.WriteCloser, error) {
+	value := types.OutgoingValueNewOutgoingValue()
+	body := value.OutgoingValueWriteBody()
+	if body.IsErr() {
+		value.ResourceDrop()
+		return nil, &Error{Op: "write data", Message: "failed to acquire output stream"}
+	}
+	stream := *body.OK()
+
+	err := c.use(func(h container.Container) error {
+		res := h.WriteData(types.ObjectName(name), value)
+		return resultError("write data", res.Err())
+	})
+	if err != nil {
+		stream.ResourceDrop()
+		value.ResourceDrop()
+		return nil, err
+	}
+	return &objectWriter{Writer: wasiio.NewWriter(stream), value: value}, nil
+}
+
+// objectWriter finishes the outgoing value once its body stream is closed.
+type objectWriter struct {
+	*wasiio.Writer
+	value    types.OutgoingValue
+	finished bool
+}
+
+func (w *objectWriter) Close() error {
+	if w.finished {
+		return nil
+	}
+	w.finished = true
+	// The stream is a child of the value and must be dropped first
+	w.Writer.Close()
+	res := types.OutgoingValueFinish(w.value)
+	return resultError("write data", res.Err())
+}
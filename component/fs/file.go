@@ -0,0 +1,317 @@
+package fs
+
+import (
+	"errors"
+	"io"
+	iofs "io/fs"
+	"path"
+	"runtime"
+	"sync"
+
+	"go.bytecodealliance.org/cm"
+	"go.wasmcloud.dev/component/gen/wasi/filesystem/types"
+	"go.wasmcloud.dev/component/internal/wasiio"
+)
+
+// readChunkSize bounds the size of a single read from the host.
+const readChunkSize = 64 * 1024
+
+// File is an open file or directory. Besides [iofs.File] it implements
+// [io.Seeker], [io.ReaderAt] and [io.WriterAt], as needed by
+// [net/http.FS] and [net/http.ServeContent].
+//
+// The underlying descriptor is released by [File.Close]. Files that are
+// never closed are released once garbage collected, on runtimes that
+// support finalizers.
+type File struct {
+	mu      sync.Mutex
+	fsys    *FS
+	fd      types.Descriptor
+	name    string
+	offset  int64
+	append  bool
+	entries *types.DirectoryEntryStream
+	appends *types.OutputStream
+	closed  bool
+}
+
+var (
+	_ iofs.ReadDirFile   = (*File)(nil)
+	_ io.ReadWriteSeeker = (*File)(nil)
+	_ io.ReaderAt        = (*File)(nil)
+	_ io.WriterAt        = (*File)(nil)
+)
+
+func newFile(fsys *FS, fd types.Descriptor, name string, append bool) *File {
+	f := &File{fsys: fsys, fd: fd, name: name, append: append}
+	runtime.SetFinalizer(f, (*File).Close)
+	return f
+}
+
+// Name returns the name the file was opened with.
+func (f *File) Name() string {
+	return f.name
+}
+
+// Close releases the file. It returns [iofs.ErrClosed] if called more than
+// once.
+func (f *File) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return f.pathError("close", iofs.ErrClosed)
+	}
+	f.closed = true
+	// Streams are children of the descriptor and must be dropped first
+	if f.entries != nil {
+		f.entries.ResourceDrop()
+	}
+	if f.appends != nil {
+		f.appends.ResourceDrop()
+	}
+	f.fd.ResourceDrop()
+	runtime.SetFinalizer(f, nil)
+	return nil
+}
+
+// use runs fn with the descriptor, holding it open until fn returns.
+func (f *File) use(op string, fn func(types.Descriptor) error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return f.pathError(op, iofs.ErrClosed)
+	}
+	if err := fn(f.fd); err != nil {
+		return f.pathError(op, err)
+	}
+	return nil
+}
+
+// Stat returns a [iofs.FileInfo] describing the file.
+func (f *File) Stat() (iofs.FileInfo, error) {
+	var info iofs.FileInfo
+	err := f.use("stat", func(fd types.Descriptor) error {
+		res := fd.Stat()
+		if err := res.Err(); err != nil {
+			return codeError(*err)
+		}
+		info = newFileInfo(basename(f.name), *res.OK())
+		return nil
+	})
+	return info, err
+}
+
+// Read reads up to len(p) bytes from the current offset.
+func (f *File) Read(p []byte) (int, error) {
+	var n int
+	err := f.use("read", func(fd types.Descriptor) (err error) {
+		n, err = readAt(fd, p, f.offset)
+		f.offset += int64(n)
+		return err
+	})
+	if errors.Is(err, io.EOF) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// ReadAt reads len(p) bytes starting at off, without moving the offset.
+func (f *File) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, f.pathError("readat", errors.New("negative offset"))
+	}
+	var n int
+	err := f.use("read", func(fd types.Descriptor) error {
+		for n < len(p) {
+			m, err := readAt(fd, p[n:], off+int64(n))
+			n += m
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, io.EOF) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// readAt performs a single read of at most len(p) bytes.
+func readAt(fd types.Descriptor, p []byte, off int64) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	res := fd.Read(types.FileSize(min(len(p), readChunkSize)), types.FileSize(off))
+	if err := res.Err(); err != nil {
+		return 0, codeError(*err)
+	}
+	data, eof := res.OK().F0, res.OK().F1
+	n := copy(p, data.Slice())
+	if n == 0 && eof {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+func (f *File) readAll() ([]byte, error) {
+	var size int64
+	if info, err := f.Stat(); err == nil {
+		size = info.Size()
+	}
+	buf := make([]byte, 0, size+512)
+	for {
+		if len(buf) == cap(buf) {
+			buf = append(buf, 0)[:len(buf)]
+		}
+		n, err := f.Read(buf[len(buf):cap(buf)])
+		buf = buf[:len(buf)+n]
+		if err == io.EOF {
+			return buf, nil
+		}
+		if err != nil {
+			return buf, err
+		}
+	}
+}
+
+// Write writes p at the current offset. Files opened with [os.O_APPEND]
+// write at the end of the file instead, through a host append stream, and
+// don't move the offset.
+func (f *File) Write(p []byte) (int, error) {
+	var n int
+	err := f.use("write", func(fd types.Descriptor) (err error) {
+		if f.append {
+			if f.appends == nil {
+				res := fd.AppendViaStream()
+				if err := res.Err(); err != nil {
+					return codeError(*err)
+				}
+				f.appends = res.OK()
+			}
+			n, err = wasiio.Write(*f.appends, p)
+			return err
+		}
+		n, err = writeAt(fd, p, f.offset)
+		f.offset += int64(n)
+		return err
+	})
+	return n, err
+}
+
+// WriteAt writes p starting at off, without moving the offset.
+func (f *File) WriteAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, f.pathError("writeat", errors.New("negative offset"))
+	}
+	var n int
+	err := f.use("write", func(fd types.Descriptor) (err error) {
+		n, err = writeAt(fd, p, off)
+		return err
+	})
+	return n, err
+}
+
+func writeAt(fd types.Descriptor, p []byte, off int64) (int, error) {
+	var written int
+	for written < len(p) {
+		res := fd.Write(cm.ToList(p[written:]), types.FileSize(off+int64(written)))
+		if err := res.Err(); err != nil {
+			return written, codeError(*err)
+		}
+		if *res.OK() == 0 {
+			return written, io.ErrShortWrite
+		}
+		written += int(*res.OK())
+	}
+	return written, nil
+}
+
+// Seek sets the offset for the next Read or Write, as described by
+// [io.Seeker].
+func (f *File) Seek(offset int64, whence int) (int64, error) {
+	var pos int64
+	err := f.use("seek", func(fd types.Descriptor) error {
+		switch whence {
+		case io.SeekStart:
+			pos = offset
+		case io.SeekCurrent:
+			pos = f.offset + offset
+		case io.SeekEnd:
+			res := fd.Stat()
+			if err := res.Err(); err != nil {
+				return codeError(*err)
+			}
+			pos = int64(res.OK().Size) + offset
+		default:
+			return iofs.ErrInvalid
+		}
+		if pos < 0 {
+			return iofs.ErrInvalid
+		}
+		f.offset = pos
+		return nil
+	})
+	return pos, err
+}
+
+// Truncate changes the size of the file. It does not change the offset.
+func (f *File) Truncate(size int64) error {
+	return f.use("truncate", func(fd types.Descriptor) error {
+		res := fd.SetSize(types.FileSize(size))
+		if err := res.Err(); err != nil {
+			return codeError(*err)
+		}
+		return nil
+	})
+}
+
+// Sync commits the file contents to stable storage.
+func (f *File) Sync() error {
+	return f.use("sync", func(fd types.Descriptor) error {
+		res := fd.Sync()
+		if err := res.Err(); err != nil {
+			return codeError(*err)
+		}
+		return nil
+	})
+}
+
+// ReadDir reads the directory entries, as described by
+// [iofs.ReadDirFile]. Entries are returned in the order reported by the
+// host.
+func (f *File) ReadDir(n int) ([]iofs.DirEntry, error) {
+	var entries []iofs.DirEntry
+	err := f.use("readdir", func(fd types.Descriptor) error {
+		if f.entries == nil {
+			res := fd.ReadDirectory()
+			if err := res.Err(); err != nil {
+				return codeError(*err)
+			}
+			f.entries = res.OK()
+		}
+		for n <= 0 || len(entries) < n {
+			res := f.entries.ReadDirectoryEntry()
+			if err := res.Err(); err != nil {
+				return codeError(*err)
+			}
+			entry := res.OK().Some()
+			if entry == nil {
+				break
+			}
+			entries = append(entries, &dirEntry{fsys: f.fsys, name: entry.Name, path: path.Join(f.name, entry.Name), typ: entry.Type})
+		}
+		return nil
+	})
+	if err != nil {
+		return entries, err
+	}
+	if n > 0 && len(entries) == 0 {
+		return nil, io.EOF
+	}
+	return entries, nil
+}
+
+func (f *File) pathError(op string, err error) error {
+	return &iofs.PathError{Op: op, Path: f.name, Err: err}
+}
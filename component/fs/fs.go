@@ -0,0 +1,155 @@
+// Package fs exposes directories preopened through [wasi:filesystem] as
+// [io/fs.FS], so code written against the standard library, such as
+// [html/template.ParseFS] or [net/http.FS], works on host-mounted
+// directories. [FS] also provides helpers for creating, writing, renaming
+// and removing files.
+//
+// wasi:filesystem has no notion of permission bits. Modes passed to write
+// helpers are ignored, and modes reported by Stat are synthesized from the
+// file type.
+//
+// [wasi:filesystem]: https://github.com/WebAssembly/wasi-filesystem/tree/v0.2.0
+package fs
+
+import (
+	iofs "io/fs"
+	"maps"
+	"os"
+	"sort"
+	"sync"
+	"syscall"
+
+	"go.wasmcloud.dev/component/gen/wasi/filesystem/preopens"
+	"go.wasmcloud.dev/component/gen/wasi/filesystem/types"
+)
+
+// Error is an error code reported by wasi:filesystem.
+type Error struct {
+	Code types.ErrorCode
+}
+
+func (e *Error) Error() string {
+	return e.Code.String()
+}
+
+// Unwrap maps the code to the matching [syscall.Errno], so callers can use
+// errors.Is(err, fs.ErrNotExist) as with the standard library.
+func (e *Error) Unwrap() error {
+	switch e.Code {
+	case types.ErrorCodeAccess:
+		return syscall.EACCES
+	case types.ErrorCodeNotPermitted:
+		return syscall.EPERM
+	case types.ErrorCodeNoEntry:
+		return syscall.ENOENT
+	case types.ErrorCodeExist:
+		return syscall.EEXIST
+	case types.ErrorCodeIsDirectory:
+		return syscall.EISDIR
+	case types.ErrorCodeNotDirectory:
+		return syscall.ENOTDIR
+	case types.ErrorCodeNotEmpty:
+		return syscall.ENOTEMPTY
+	case types.ErrorCodeInvalid:
+		return syscall.EINVAL
+	case types.ErrorCodeReadOnly:
+		return syscall.EROFS
+	case types.ErrorCodeCrossDevice:
+		return syscall.EXDEV
+	case types.ErrorCodeNameTooLong:
+		return syscall.ENAMETOOLONG
+	case types.ErrorCodeInsufficientSpace:
+		return syscall.ENOSPC
+	case types.ErrorCodeUnsupported:
+		return syscall.ENOTSUP
+	}
+	return nil
+}
+
+func codeError(code types.ErrorCode) error {
+	return &Error{Code: code}
+}
+
+// preopened holds the directories granted to the component. The handles
+// live for the lifetime of the instance and are never dropped.
+var preopened = sync.OnceValue(func() map[string]*FS {
+	dirs := make(map[string]*FS)
+	for _, dir := range preopens.GetDirectories().Slice() {
+		dirs[dir.F1] = &FS{fd: dir.F0, path: dir.F1}
+	}
+	return dirs
+})
+
+// Preopens returns the directories preopened by the host, keyed by the path
+// they are mounted at.
+func Preopens() map[string]*FS {
+	return maps.Clone(preopened())
+}
+
+// Dir returns the preopened directory mounted at path.
+func Dir(path string) (*FS, error) {
+	if fsys, ok := preopened()[path]; ok {
+		return fsys, nil
+	}
+	return nil, &iofs.PathError{Op: "open", Path: path, Err: iofs.ErrNotExist}
+}
+
+// FS is a preopened directory. Names passed to its methods are
+// slash-separated paths relative to the directory, as described by
+// [iofs.ValidPath], and cannot escape it.
+type FS struct {
+	fd   types.Descriptor
+	path string
+}
+
+var (
+	_ iofs.FS         = (*FS)(nil)
+	_ iofs.ReadDirFS  = (*FS)(nil)
+	_ iofs.ReadFileFS = (*FS)(nil)
+	_ iofs.StatFS     = (*FS)(nil)
+)
+
+// Path returns the path the directory is mounted at.
+func (fsys *FS) Path() string {
+	return fsys.path
+}
+
+// Open opens the named file or directory for reading.
+func (fsys *FS) Open(name string) (iofs.File, error) {
+	return fsys.OpenFile(name, os.O_RDONLY, 0)
+}
+
+// Stat returns a [iofs.FileInfo] describing the named file, following
+// symbolic links.
+func (fsys *FS) Stat(name string) (iofs.FileInfo, error) {
+	if !iofs.ValidPath(name) {
+		return nil, &iofs.PathError{Op: "stat", Path: name, Err: iofs.ErrInvalid}
+	}
+	info, err := statAt(fsys.fd, name)
+	if err != nil {
+		return nil, &iofs.PathError{Op: "stat", Path: name, Err: err}
+	}
+	return info, nil
+}
+
+// ReadDir reads the named directory, returning its entries sorted by name.
+func (fsys *FS) ReadDir(name string) ([]iofs.DirEntry, error) {
+	f, err := fsys.OpenFile(name, os.O_RDONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	entries, err := f.ReadDir(-1)
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, err
+}
+
+// ReadFile reads the named file and returns its contents.
+func (fsys *FS) ReadFile(name string) ([]byte, error) {
+	f, err := fsys.OpenFile(name, os.O_RDONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return f.readAll()
+}
@@ -0,0 +1,533 @@
+package fs
+
+import (
+	"errors"
+	"io"
+	iofs "io/fs"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"syscall"
+	"testing"
+	"testing/fstest"
+	"unsafe"
+
+	"go.bytecodealliance.org/cm"
+	"go.wasmcloud.dev/component/gen/wasi/filesystem/types"
+	"go.wasmcloud.dev/component/gen/wasi/io/streams"
+)
+
+func TestFileMode(t *testing.T) {
+	for _, tt := range []struct {
+		typ  types.DescriptorType
+		want string
+	}{
+		{types.DescriptorTypeDirectory, "drwxr-xr-x"},
+		{types.DescriptorTypeRegularFile, "-rw-r--r--"},
+		{types.DescriptorTypeSymbolicLink, "Lrwxrwxrwx"},
+		{types.DescriptorTypeBlockDevice, "Drw-r--r--"},
+		{types.DescriptorTypeCharacterDevice, "Dcrw-r--r--"},
+		{types.DescriptorTypeFIFO, "prw-r--r--"},
+		{types.DescriptorTypeSocket, "Srw-r--r--"},
+		{types.DescriptorTypeUnknown, "?---------"},
+	} {
+		if got := fileMode(tt.typ).String(); got != tt.want {
+			t.Errorf("fileMode(%v) = %s, want %s", tt.typ, got, tt.want)
+		}
+	}
+}
+
+func TestOpenFlags(t *testing.T) {
+	for _, tt := range []struct {
+		flag   int
+		oflags types.OpenFlags
+		dflags types.DescriptorFlags
+	}{
+		{os.O_RDONLY, 0, types.DescriptorFlagsRead},
+		{os.O_WRONLY, 0, types.DescriptorFlagsWrite},
+		{os.O_RDWR, 0, types.DescriptorFlagsRead | types.DescriptorFlagsWrite},
+		{os.O_WRONLY | os.O_APPEND, 0, types.DescriptorFlagsWrite},
+		{os.O_RDWR | os.O_CREATE | os.O_TRUNC, types.OpenFlagsCreate | types.OpenFlagsTruncate, types.DescriptorFlagsRead | types.DescriptorFlagsWrite},
+		{os.O_WRONLY | os.O_CREATE | os.O_EXCL, types.OpenFlagsCreate | types.OpenFlagsExclusive, types.DescriptorFlagsWrite},
+	} {
+		oflags, dflags := openFlags(tt.flag)
+		if oflags != tt.oflags || dflags != tt.dflags {
+			t.Errorf("openFlags(%#x) = %v, %v, want %v, %v", tt.flag, oflags, dflags, tt.oflags, tt.dflags)
+		}
+	}
+}
+
+func TestDirEntryInfo(t *testing.T) {
+	fsys := newTestFS()
+
+	// The directory is closed by ReadDir before Info is called
+	entries, err := fsys.ReadDir("static")
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	var names []string
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil {
+			t.Fatalf("Info(%s): %v", e.Name(), err)
+		}
+		if info.Name() != e.Name() || info.IsDir() != e.IsDir() {
+			t.Errorf("Info(%s) = %s, dir %v", e.Name(), info.Name(), info.IsDir())
+		}
+		names = append(names, e.Name())
+	}
+	if want, got := "css,index.html", strings.Join(names, ","); want != got {
+		t.Errorf("expected entries %s, got %s", want, got)
+	}
+
+	f, err := fsys.Open("static/css")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	entries, err = f.(iofs.ReadDirFile).ReadDir(-1)
+	f.Close()
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("ReadDir: %v, %v", entries, err)
+	}
+	info, err := entries[0].Info()
+	if err != nil {
+		t.Fatalf("Info: %v", err)
+	}
+	if want, got := int64(len("static/css/site.css")), info.Size(); want != got {
+		t.Errorf("expected size %d, got %d", want, got)
+	}
+}
+
+func TestFileReadWrite(t *testing.T) {
+	fsys := newTestFS()
+
+	f, err := fsys.Create("notes.txt")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	defer f.Close()
+	if n, err := f.Write([]byte("hello world")); err != nil || n != 11 {
+		t.Fatalf("Write: %d, %v", n, err)
+	}
+
+	if pos, err := f.Seek(0, io.SeekStart); err != nil || pos != 0 {
+		t.Fatalf("Seek: %d, %v", pos, err)
+	}
+	data, err := io.ReadAll(f)
+	if err != nil || string(data) != "hello world" {
+		t.Errorf("ReadAll = %q, %v", data, err)
+	}
+
+	p := make([]byte, 5)
+	if n, err := f.ReadAt(p, 6); err != nil || string(p[:n]) != "world" {
+		t.Errorf("ReadAt = %q, %v", p[:n], err)
+	}
+	if n, err := f.ReadAt(p, 8); err != io.EOF || string(p[:n]) != "rld" {
+		t.Errorf("ReadAt past the end = %q, %v", p[:n], err)
+	}
+
+	if pos, err := f.Seek(-5, io.SeekEnd); err != nil || pos != 6 {
+		t.Fatalf("Seek from end: %d, %v", pos, err)
+	}
+	if _, err := f.Write([]byte("there")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if pos, err := f.Seek(0, io.SeekCurrent); err != nil || pos != 11 {
+		t.Errorf("Seek from current: %d, %v", pos, err)
+	}
+	if _, err := f.Seek(-1, io.SeekStart); !errors.Is(err, iofs.ErrInvalid) {
+		t.Errorf("expected negative offset to be invalid, got %v", err)
+	}
+	if n, err := f.Read(p); n != 0 || err != io.EOF {
+		t.Errorf("Read at the end = %d, %v", n, err)
+	}
+
+	data, err = fsys.ReadFile("notes.txt")
+	if err != nil || string(data) != "hello there" {
+		t.Errorf("ReadFile = %q, %v", data, err)
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	fsys := newTestFS()
+
+	if err := fsys.WriteFile("log.txt", []byte("first line\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := fsys.WriteFile("log.txt", []byte("one\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	f, err := fsys.OpenFile("log.txt", os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatalf("OpenFile: %v", err)
+	}
+	for _, line := range []string{"two\n", "three\n"} {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if len(testAppends) != 0 {
+		t.Errorf("expected Close to drop the append stream, got %d open", len(testAppends))
+	}
+
+	data, err := fsys.ReadFile("log.txt")
+	if err != nil || string(data) != "one\ntwo\nthree\n" {
+		t.Errorf("ReadFile = %q, %v", data, err)
+	}
+
+	if _, err := fsys.OpenFile("log.txt", os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0); !errors.Is(err, iofs.ErrExist) {
+		t.Errorf("expected O_EXCL to fail on an existing file, got %v", err)
+	}
+}
+
+func TestRenameRemove(t *testing.T) {
+	fsys := newTestFS()
+
+	if err := fsys.WriteFile("draft.txt", []byte("draft"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := fsys.Rename("draft.txt", "static/final.txt"); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+	if _, err := fsys.Stat("draft.txt"); !errors.Is(err, iofs.ErrNotExist) {
+		t.Errorf("expected renamed file to be gone, got %v", err)
+	}
+	if data, err := fsys.ReadFile("static/final.txt"); err != nil || string(data) != "draft" {
+		t.Errorf("ReadFile = %q, %v", data, err)
+	}
+	if err := fsys.Rename("missing.txt", "other.txt"); !errors.Is(err, iofs.ErrNotExist) {
+		t.Errorf("expected renaming a missing file to fail, got %v", err)
+	}
+
+	if err := fsys.Remove("static/final.txt"); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if _, err := fsys.Stat("static/final.txt"); !errors.Is(err, iofs.ErrNotExist) {
+		t.Errorf("expected removed file to be gone, got %v", err)
+	}
+
+	if err := fsys.Mkdir("empty", 0o755); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+	if err := fsys.Remove("empty"); err != nil {
+		t.Errorf("Remove of an empty directory: %v", err)
+	}
+	if err := fsys.Remove("static"); !errors.Is(err, syscall.ENOTEMPTY) {
+		t.Errorf("expected removing a non-empty directory to fail, got %v", err)
+	}
+	if err := fsys.Remove("missing.txt"); !errors.Is(err, iofs.ErrNotExist) {
+		t.Errorf("expected removing a missing file to fail, got %v", err)
+	}
+}
+
+func TestFS(t *testing.T) {
+	if err := fstest.TestFS(newTestFS(), "static/index.html", "static/css/site.css"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testFile is a file or directory of the stubbed wasi:filesystem/types
+// imports below.
+type testFile struct {
+	typ  types.DescriptorType
+	data []byte
+}
+
+const testRoot = types.Descriptor(1)
+
+// testFiles holds the tree by path. Descriptors map open handles to their
+// path, and entry and append streams share the handle table.
+var (
+	testFiles       map[string]*testFile
+	testDescriptors map[uint32]string
+	testStreams     map[uint32][]types.DirectoryEntry
+	testAppends     map[uint32]string
+	testNextHandle  uint32
+)
+
+// newTestFS resets the stubbed tree and returns an FS over its root. The
+// contents of each file initially are its path.
+func newTestFS() *FS {
+	testFiles = map[string]*testFile{
+		".":      {typ: types.DescriptorTypeDirectory},
+		"static": {typ: types.DescriptorTypeDirectory},
+		"static/index.html": {
+			typ:  types.DescriptorTypeRegularFile,
+			data: []byte("static/index.html"),
+		},
+		"static/css": {typ: types.DescriptorTypeDirectory},
+		"static/css/site.css": {
+			typ:  types.DescriptorTypeRegularFile,
+			data: []byte("static/css/site.css"),
+		},
+	}
+	testDescriptors = map[uint32]string{uint32(testRoot): "."}
+	testStreams = map[uint32][]types.DirectoryEntry{}
+	testAppends = map[uint32]string{}
+	testNextHandle = uint32(testRoot)
+	return &FS{fd: testRoot, path: "/"}
+}
+
+func testHandle() uint32 {
+	testNextHandle++
+	return testNextHandle
+}
+
+// testLookup resolves path relative to the directory open as handle.
+func testLookup(handle uint32, p *uint8, n uint32) (string, *testFile, types.ErrorCode, bool) {
+	dir, ok := testDescriptors[handle]
+	if !ok {
+		return "", nil, types.ErrorCodeBadDescriptor, false
+	}
+	name := path.Join(dir, unsafe.String(p, n))
+	file, ok := testFiles[name]
+	if !ok {
+		return name, nil, types.ErrorCodeNoEntry, false
+	}
+	return name, file, 0, true
+}
+
+func testStat(file *testFile) types.DescriptorStat {
+	return types.DescriptorStat{Type: file.typ, Size: types.FileSize(len(file.data))}
+}
+
+// stub wasi:filesystem/types
+//
+//go:linkname wasmimport_DescriptorOpenAt go.wasmcloud.dev/component/gen/wasi/filesystem/types.wasmimport_DescriptorOpenAt
+func wasmimport_DescriptorOpenAt(self0 uint32, pathFlags0 uint32, path0 *uint8, path1 uint32, openFlags0 uint32, flags0 uint32, result *cm.Result[types.Descriptor, types.Descriptor, types.ErrorCode]) {
+	type R = cm.Result[types.Descriptor, types.Descriptor, types.ErrorCode]
+	oflags := types.OpenFlags(openFlags0)
+	name, file, code, ok := testLookup(self0, path0, path1)
+	switch {
+	case ok && oflags&types.OpenFlagsExclusive != 0:
+		*result = cm.Err[R](types.ErrorCodeExist)
+		return
+	case ok && oflags&types.OpenFlagsTruncate != 0:
+		file.data = nil
+	case !ok && code == types.ErrorCodeNoEntry && oflags&types.OpenFlagsCreate != 0:
+		testFiles[name] = &testFile{typ: types.DescriptorTypeRegularFile}
+	case !ok:
+		*result = cm.Err[R](code)
+		return
+	}
+	h := testHandle()
+	testDescriptors[h] = name
+	*result = cm.OK[R](types.Descriptor(h))
+}
+
+//go:linkname wasmimport_DescriptorStatAt go.wasmcloud.dev/component/gen/wasi/filesystem/types.wasmimport_DescriptorStatAt
+func wasmimport_DescriptorStatAt(self0 uint32, pathFlags0 uint32, path0 *uint8, path1 uint32, result *cm.Result[types.DescriptorStatShape, types.DescriptorStat, types.ErrorCode]) {
+	type R = cm.Result[types.DescriptorStatShape, types.DescriptorStat, types.ErrorCode]
+	_, file, code, ok := testLookup(self0, path0, path1)
+	if !ok {
+		*result = cm.Err[R](code)
+		return
+	}
+	*result = cm.OK[R](testStat(file))
+}
+
+//go:linkname wasmimport_DescriptorCreateDirectoryAt go.wasmcloud.dev/component/gen/wasi/filesystem/types.wasmimport_DescriptorCreateDirectoryAt
+func wasmimport_DescriptorCreateDirectoryAt(self0 uint32, path0 *uint8, path1 uint32, result *cm.Result[types.ErrorCode, struct{}, types.ErrorCode]) {
+	type R = cm.Result[types.ErrorCode, struct{}, types.ErrorCode]
+	name, _, code, ok := testLookup(self0, path0, path1)
+	switch {
+	case ok:
+		*result = cm.Err[R](types.ErrorCodeExist)
+	case code != types.ErrorCodeNoEntry:
+		*result = cm.Err[R](code)
+	default:
+		testFiles[name] = &testFile{typ: types.DescriptorTypeDirectory}
+		*result = cm.OK[R](struct{}{})
+	}
+}
+
+//go:linkname wasmimport_DescriptorRenameAt go.wasmcloud.dev/component/gen/wasi/filesystem/types.wasmimport_DescriptorRenameAt
+func wasmimport_DescriptorRenameAt(self0 uint32, oldPath0 *uint8, oldPath1 uint32, newDescriptor0 uint32, newPath0 *uint8, newPath1 uint32, result *cm.Result[types.ErrorCode, struct{}, types.ErrorCode]) {
+	type R = cm.Result[types.ErrorCode, struct{}, types.ErrorCode]
+	oldName, file, code, ok := testLookup(self0, oldPath0, oldPath1)
+	if !ok {
+		*result = cm.Err[R](code)
+		return
+	}
+	newName, _, code, ok := testLookup(newDescriptor0, newPath0, newPath1)
+	if !ok && code != types.ErrorCodeNoEntry {
+		*result = cm.Err[R](code)
+		return
+	}
+	delete(testFiles, oldName)
+	testFiles[newName] = file
+	*result = cm.OK[R](struct{}{})
+}
+
+//go:linkname wasmimport_DescriptorUnlinkFileAt go.wasmcloud.dev/component/gen/wasi/filesystem/types.wasmimport_DescriptorUnlinkFileAt
+func wasmimport_DescriptorUnlinkFileAt(self0 uint32, path0 *uint8, path1 uint32, result *cm.Result[types.ErrorCode, struct{}, types.ErrorCode]) {
+	type R = cm.Result[types.ErrorCode, struct{}, types.ErrorCode]
+	name, file, code, ok := testLookup(self0, path0, path1)
+	switch {
+	case !ok:
+		*result = cm.Err[R](code)
+	case file.typ == types.DescriptorTypeDirectory:
+		*result = cm.Err[R](types.ErrorCodeIsDirectory)
+	default:
+		delete(testFiles, name)
+		*result = cm.OK[R](struct{}{})
+	}
+}
+
+//go:linkname wasmimport_DescriptorRemoveDirectoryAt go.wasmcloud.dev/component/gen/wasi/filesystem/types.wasmimport_DescriptorRemoveDirectoryAt
+func wasmimport_DescriptorRemoveDirectoryAt(self0 uint32, path0 *uint8, path1 uint32, result *cm.Result[types.ErrorCode, struct{}, types.ErrorCode]) {
+	type R = cm.Result[types.ErrorCode, struct{}, types.ErrorCode]
+	name, file, code, ok := testLookup(self0, path0, path1)
+	if !ok {
+		*result = cm.Err[R](code)
+		return
+	}
+	if file.typ != types.DescriptorTypeDirectory {
+		*result = cm.Err[R](types.ErrorCodeNotDirectory)
+		return
+	}
+	for other := range testFiles {
+		if path.Dir(other) == name && other != name {
+			*result = cm.Err[R](types.ErrorCodeNotEmpty)
+			return
+		}
+	}
+	delete(testFiles, name)
+	*result = cm.OK[R](struct{}{})
+}
+
+//go:linkname wasmimport_DescriptorReadDirectory go.wasmcloud.dev/component/gen/wasi/filesystem/types.wasmimport_DescriptorReadDirectory
+func wasmimport_DescriptorReadDirectory(self0 uint32, result *cm.Result[types.DirectoryEntryStream, types.DirectoryEntryStream, types.ErrorCode]) {
+	type R = cm.Result[types.DirectoryEntryStream, types.DirectoryEntryStream, types.ErrorCode]
+	dir, ok := testDescriptors[self0]
+	if !ok {
+		*result = cm.Err[R](types.ErrorCodeBadDescriptor)
+		return
+	}
+	if testFiles[dir].typ != types.DescriptorTypeDirectory {
+		*result = cm.Err[R](types.ErrorCodeNotDirectory)
+		return
+	}
+	var entries []types.DirectoryEntry
+	for name, file := range testFiles {
+		if name != "." && path.Dir(name) == dir {
+			entries = append(entries, types.DirectoryEntry{Type: file.typ, Name: path.Base(name)})
+		}
+	}
+	h := testHandle()
+	testStreams[h] = entries
+	*result = cm.OK[R](types.DirectoryEntryStream(h))
+}
+
+//go:linkname wasmimport_DirectoryEntryStreamReadDirectoryEntry go.wasmcloud.dev/component/gen/wasi/filesystem/types.wasmimport_DirectoryEntryStreamReadDirectoryEntry
+func wasmimport_DirectoryEntryStreamReadDirectoryEntry(self0 uint32, result *cm.Result[types.OptionDirectoryEntryShape, cm.Option[types.DirectoryEntry], types.ErrorCode]) {
+	type R = cm.Result[types.OptionDirectoryEntryShape, cm.Option[types.DirectoryEntry], types.ErrorCode]
+	entries := testStreams[self0]
+	if len(entries) == 0 {
+		*result = cm.OK[R](cm.None[types.DirectoryEntry]())
+		return
+	}
+	testStreams[self0] = entries[1:]
+	*result = cm.OK[R](cm.Some(entries[0]))
+}
+
+//go:linkname wasmimport_DirectoryEntryStreamResourceDrop go.wasmcloud.dev/component/gen/wasi/filesystem/types.wasmimport_DirectoryEntryStreamResourceDrop
+func wasmimport_DirectoryEntryStreamResourceDrop(self0 uint32) {
+	delete(testStreams, self0)
+}
+
+//go:linkname wasmimport_DescriptorResourceDrop go.wasmcloud.dev/component/gen/wasi/filesystem/types.wasmimport_DescriptorResourceDrop
+func wasmimport_DescriptorResourceDrop(self0 uint32) {
+	delete(testDescriptors, self0)
+}
+
+//go:linkname wasmimport_DescriptorStat go.wasmcloud.dev/component/gen/wasi/filesystem/types.wasmimport_DescriptorStat
+func wasmimport_DescriptorStat(self0 uint32, result *cm.Result[types.DescriptorStatShape, types.DescriptorStat, types.ErrorCode]) {
+	type R = cm.Result[types.DescriptorStatShape, types.DescriptorStat, types.ErrorCode]
+	file, ok := testFiles[testDescriptors[self0]]
+	if !ok {
+		*result = cm.Err[R](types.ErrorCodeBadDescriptor)
+		return
+	}
+	*result = cm.OK[R](testStat(file))
+}
+
+//go:linkname wasmimport_DescriptorRead go.wasmcloud.dev/component/gen/wasi/filesystem/types.wasmimport_DescriptorRead
+func wasmimport_DescriptorRead(self0 uint32, length0 uint64, offset0 uint64, result *cm.Result[types.TupleListU8BoolShape, cm.Tuple[cm.List[uint8], bool], types.ErrorCode]) {
+	type R = cm.Result[types.TupleListU8BoolShape, cm.Tuple[cm.List[uint8], bool], types.ErrorCode]
+	file, ok := testFiles[testDescriptors[self0]]
+	switch {
+	case !ok:
+		*result = cm.Err[R](types.ErrorCodeBadDescriptor)
+		return
+	case file.typ == types.DescriptorTypeDirectory:
+		*result = cm.Err[R](types.ErrorCodeIsDirectory)
+		return
+	}
+	start := min(offset0, uint64(len(file.data)))
+	end := min(start+length0, uint64(len(file.data)))
+	data := slices.Clone(file.data[start:end])
+	*result = cm.OK[R](cm.Tuple[cm.List[uint8], bool]{F0: cm.ToList(data), F1: end == uint64(len(file.data))})
+}
+
+//go:linkname wasmimport_DescriptorWrite go.wasmcloud.dev/component/gen/wasi/filesystem/types.wasmimport_DescriptorWrite
+func wasmimport_DescriptorWrite(self0 uint32, buffer0 *uint8, buffer1 uint32, offset0 uint64, result *cm.Result[uint64, types.FileSize, types.ErrorCode]) {
+	type R = cm.Result[uint64, types.FileSize, types.ErrorCode]
+	file, ok := testFiles[testDescriptors[self0]]
+	if !ok {
+		*result = cm.Err[R](types.ErrorCodeBadDescriptor)
+		return
+	}
+	buf := unsafe.Slice(buffer0, buffer1)
+	if end := int(offset0) + len(buf); end > len(file.data) {
+		file.data = append(file.data, make([]byte, end-len(file.data))...)
+	}
+	copy(file.data[offset0:], buf)
+	*result = cm.OK[R](types.FileSize(len(buf)))
+}
+
+//go:linkname wasmimport_DescriptorAppendViaStream go.wasmcloud.dev/component/gen/wasi/filesystem/types.wasmimport_DescriptorAppendViaStream
+func wasmimport_DescriptorAppendViaStream(self0 uint32, result *cm.Result[types.OutputStream, types.OutputStream, types.ErrorCode]) {
+	type R = cm.Result[types.OutputStream, types.OutputStream, types.ErrorCode]
+	name, ok := testDescriptors[self0]
+	if !ok {
+		*result = cm.Err[R](types.ErrorCodeBadDescriptor)
+		return
+	}
+	h := testHandle()
+	testAppends[h] = name
+	*result = cm.OK[R](types.OutputStream(h))
+}
+
+// stub wasi:io/streams
+//
+//go:linkname wasmimport_OutputStreamBlockingWriteAndFlush go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamBlockingWriteAndFlush
+func wasmimport_OutputStreamBlockingWriteAndFlush(self0 uint32, contents0 *uint8, contents1 uint32, result *cm.Result[streams.StreamError, struct{}, streams.StreamError]) {
+	file := testFiles[testAppends[self0]]
+	file.data = append(file.data, unsafe.Slice(contents0, contents1)...)
+	*result = cm.OK[cm.Result[streams.StreamError, struct{}, streams.StreamError]](struct{}{})
+}
+
+//go:linkname wasmimport_OutputStreamResourceDrop go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamResourceDrop
+func wasmimport_OutputStreamResourceDrop(self0 uint32) {
+	delete(testAppends, self0)
+}
+
+// stub wasi:io/error
+//
+//go:linkname wasmimport_ErrorToDebugString go.wasmcloud.dev/component/gen/wasi/io/error.wasmimport_ErrorToDebugString
+func wasmimport_ErrorToDebugString(self0 uint32, result *string) {
+	*result = "stream error"
+}
+
+// stub wasi:filesystem/preopens
+//
+//go:linkname wasmimport_GetDirectories go.wasmcloud.dev/component/gen/wasi/filesystem/preopens.wasmimport_GetDirectories
+func wasmimport_GetDirectories(result *cm.List[cm.Tuple[types.Descriptor, string]]) {
+	*result = cm.ToList([]cm.Tuple[types.Descriptor, string]{{F0: testRoot, F1: "/"}})
+}
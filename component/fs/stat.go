@@ -0,0 +1,103 @@
+package fs
+
+import (
+	iofs "io/fs"
+	"path"
+	"time"
+
+	wallclock "go.wasmcloud.dev/component/gen/wasi/clocks/wall-clock"
+	"go.wasmcloud.dev/component/gen/wasi/filesystem/types"
+)
+
+// fileInfo implements [iofs.FileInfo] over a descriptor stat. Sys returns
+// the [types.DescriptorStat].
+type fileInfo struct {
+	name string
+	stat types.DescriptorStat
+}
+
+func newFileInfo(name string, stat types.DescriptorStat) *fileInfo {
+	return &fileInfo{name: name, stat: stat}
+}
+
+func (fi *fileInfo) Name() string        { return fi.name }
+func (fi *fileInfo) Size() int64         { return int64(fi.stat.Size) }
+func (fi *fileInfo) Mode() iofs.FileMode { return fileMode(fi.stat.Type) }
+func (fi *fileInfo) IsDir() bool         { return fi.stat.Type == types.DescriptorTypeDirectory }
+func (fi *fileInfo) Sys() any            { return fi.stat }
+
+func (fi *fileInfo) ModTime() time.Time {
+	if t := fi.stat.DataModificationTimestamp.Some(); t != nil {
+		return toTime(*t)
+	}
+	return time.Time{}
+}
+
+// dirEntry implements [iofs.DirEntry], stating the entry on demand through
+// the preopened directory, so Info keeps working once the directory it was
+// read from is closed.
+type dirEntry struct {
+	fsys *FS
+	name string
+	path string
+	typ  types.DescriptorType
+}
+
+func (e *dirEntry) Name() string        { return e.name }
+func (e *dirEntry) IsDir() bool         { return e.typ == types.DescriptorTypeDirectory }
+func (e *dirEntry) Type() iofs.FileMode { return fileMode(e.typ).Type() }
+func (e *dirEntry) String() string      { return iofs.FormatDirEntry(e) }
+
+func (e *dirEntry) Info() (iofs.FileInfo, error) {
+	info, err := lstatAt(e.fsys.fd, e.path)
+	if err != nil {
+		return nil, &iofs.PathError{Op: "lstat", Path: e.path, Err: err}
+	}
+	return info, nil
+}
+
+func statAt(fd types.Descriptor, name string) (iofs.FileInfo, error) {
+	res := fd.StatAt(types.PathFlagsSymlinkFollow, name)
+	if err := res.Err(); err != nil {
+		return nil, codeError(*err)
+	}
+	return newFileInfo(basename(name), *res.OK()), nil
+}
+
+func lstatAt(fd types.Descriptor, name string) (iofs.FileInfo, error) {
+	res := fd.StatAt(0, name)
+	if err := res.Err(); err != nil {
+		return nil, codeError(*err)
+	}
+	return newFileInfo(basename(name), *res.OK()), nil
+}
+
+// fileMode synthesizes a mode from the descriptor type, since
+// wasi:filesystem doesn't expose permission bits.
+func fileMode(typ types.DescriptorType) iofs.FileMode {
+	switch typ {
+	case types.DescriptorTypeDirectory:
+		return iofs.ModeDir | 0o755
+	case types.DescriptorTypeRegularFile:
+		return 0o644
+	case types.DescriptorTypeSymbolicLink:
+		return iofs.ModeSymlink | 0o777
+	case types.DescriptorTypeBlockDevice:
+		return iofs.ModeDevice | 0o644
+	case types.DescriptorTypeCharacterDevice:
+		return iofs.ModeDevice | iofs.ModeCharDevice | 0o644
+	case types.DescriptorTypeFIFO:
+		return iofs.ModeNamedPipe | 0o644
+	case types.DescriptorTypeSocket:
+		return iofs.ModeSocket | 0o644
+	}
+	return iofs.ModeIrregular
+}
+
+func toTime(t wallclock.DateTime) time.Time {
+	return time.Unix(int64(t.Seconds), int64(t.Nanoseconds))
+}
+
+func basename(name string) string {
+	return path.Base(name)
+}
@@ -0,0 +1,115 @@
+package fs
+
+import (
+	iofs "io/fs"
+	"os"
+
+	"go.wasmcloud.dev/component/gen/wasi/filesystem/types"
+)
+
+// OpenFile opens the named file with the given [os.O_RDONLY]-style flags,
+// creating it if [os.O_CREATE] is set. perm is ignored.
+func (fsys *FS) OpenFile(name string, flag int, perm iofs.FileMode) (*File, error) {
+	if !iofs.ValidPath(name) {
+		return nil, &iofs.PathError{Op: "open", Path: name, Err: iofs.ErrInvalid}
+	}
+
+	oflags, dflags := openFlags(flag)
+	res := fsys.fd.OpenAt(types.PathFlagsSymlinkFollow, name, oflags, dflags)
+	if err := res.Err(); err != nil {
+		return nil, &iofs.PathError{Op: "open", Path: name, Err: codeError(*err)}
+	}
+	return newFile(fsys, *res.OK(), name, flag&os.O_APPEND != 0), nil
+}
+
+// openFlags maps os.OpenFile flags to wasi:filesystem open and descriptor
+// flags. O_APPEND has no descriptor flag and is handled by [File] through
+// an append stream.
+func openFlags(flag int) (oflags types.OpenFlags, dflags types.DescriptorFlags) {
+	if flag&os.O_CREATE != 0 {
+		oflags |= types.OpenFlagsCreate
+	}
+	if flag&os.O_EXCL != 0 {
+		oflags |= types.OpenFlagsExclusive
+	}
+	if flag&os.O_TRUNC != 0 {
+		oflags |= types.OpenFlagsTruncate
+	}
+
+	switch flag & (os.O_RDONLY | os.O_WRONLY | os.O_RDWR) {
+	case os.O_RDONLY:
+		dflags = types.DescriptorFlagsRead
+	case os.O_WRONLY:
+		dflags = types.DescriptorFlagsWrite
+	case os.O_RDWR:
+		dflags = types.DescriptorFlagsRead | types.DescriptorFlagsWrite
+	}
+	return oflags, dflags
+}
+
+// Create creates or truncates the named file and opens it for reading and
+// writing.
+func (fsys *FS) Create(name string) (*File, error) {
+	return fsys.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o666)
+}
+
+// WriteFile writes data to the named file, creating it if necessary and
+// truncating it otherwise. perm is ignored.
+func (fsys *FS) WriteFile(name string, data []byte, perm iofs.FileMode) error {
+	f, err := fsys.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Mkdir creates the named directory. perm is ignored.
+func (fsys *FS) Mkdir(name string, perm iofs.FileMode) error {
+	if !iofs.ValidPath(name) {
+		return &iofs.PathError{Op: "mkdir", Path: name, Err: iofs.ErrInvalid}
+	}
+	res := fsys.fd.CreateDirectoryAt(name)
+	if err := res.Err(); err != nil {
+		return &iofs.PathError{Op: "mkdir", Path: name, Err: codeError(*err)}
+	}
+	return nil
+}
+
+// Rename moves oldname to newname, replacing newname if it exists.
+func (fsys *FS) Rename(oldname, newname string) error {
+	if !iofs.ValidPath(oldname) || !iofs.ValidPath(newname) {
+		return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: iofs.ErrInvalid}
+	}
+	res := fsys.fd.RenameAt(oldname, fsys.fd, newname)
+	if err := res.Err(); err != nil {
+		return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: codeError(*err)}
+	}
+	return nil
+}
+
+// Remove removes the named file or empty directory.
+func (fsys *FS) Remove(name string) error {
+	if !iofs.ValidPath(name) {
+		return &iofs.PathError{Op: "remove", Path: name, Err: iofs.ErrInvalid}
+	}
+	res := fsys.fd.UnlinkFileAt(name)
+	err := res.Err()
+	if err == nil {
+		return nil
+	}
+	// Unlinking a directory fails with a platform dependent code, so retry
+	// as a directory and keep the first error if that fails too
+	dirRes := fsys.fd.RemoveDirectoryAt(name)
+	dirErr := dirRes.Err()
+	if dirErr == nil {
+		return nil
+	}
+	if *dirErr != types.ErrorCodeNotDirectory {
+		return &iofs.PathError{Op: "remove", Path: name, Err: codeError(*dirErr)}
+	}
+	return &iofs.PathError{Op: "remove", Path: name, Err: codeError(*err)}
+}
@@ -0,0 +1,182 @@
+// Package wasiio adapts [wasi:io/streams] to the [io] interfaces, so every
+// package exposing streams shares the same read and write loops.
+//
+// [wasi:io/streams]: https://github.com/WebAssembly/wasi-io/blob/v0.2.0/wit/streams.wit
+package wasiio
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go.bytecodealliance.org/cm"
+	"go.wasmcloud.dev/component/gen/wasi/io/streams"
+	poll "go.wasmcloud.dev/component/poll"
+)
+
+// writeChunkSize keeps writes within the stream buffer limits of hosts.
+const writeChunkSize = 4096
+
+// Read waits until stream is ready and reads at most len(p) bytes from it,
+// returning [io.EOF] once the stream is closed.
+func Read(stream streams.InputStream, p []byte) (int, error) {
+	return ReadContext(context.Background(), stream, p)
+}
+
+// ReadContext is like [Read] but gives up waiting once ctx is done,
+// returning the context error.
+func ReadContext(ctx context.Context, stream streams.InputStream, p []byte) (int, error) {
+	pollable := stream.Subscribe()
+	err := poll.WaitContext(ctx, pollable)
+	pollable.ResourceDrop()
+	if err != nil {
+		return 0, err
+	}
+
+	readResult := stream.Read(uint64(len(p)))
+	if err := readResult.Err(); err != nil {
+		if err.Closed() {
+			return 0, io.EOF
+		}
+		return 0, fmt.Errorf("failed to read from InputStream %s", err.LastOperationFailed().ToDebugString())
+	}
+
+	readList := *readResult.OK()
+	copy(p, readList.Slice())
+	return int(readList.Len()), nil
+}
+
+// Write writes p to stream in chunks, blocking until each chunk is flushed.
+// It returns [io.EOF] if the stream is closed before p is written.
+func Write(stream streams.OutputStream, p []byte) (int, error) {
+	totalWritten := 0
+	for offset := 0; offset < len(p); offset += writeChunkSize {
+		end := min(offset+writeChunkSize, len(p))
+		chunk := cm.ToList(p[offset:end])
+
+		writeResult := stream.BlockingWriteAndFlush(chunk)
+		if err := writeResult.Err(); err != nil {
+			if err.Closed() {
+				return totalWritten, io.EOF
+			}
+			return totalWritten, fmt.Errorf("failed to write to OutputStream %s", err.LastOperationFailed().ToDebugString())
+		}
+
+		totalWritten += end - offset
+	}
+	return totalWritten, nil
+}
+
+// WriteAvailable writes p to stream as fast as its check-write budget
+// allows, waiting for budget when it runs out. Unlike [Write] it doesn't
+// flush, leaving the host free to batch the data. It returns [io.EOF] if
+// the stream is closed before p is written.
+func WriteAvailable(stream streams.OutputStream, p []byte) (int, error) {
+	totalWritten := 0
+	for totalWritten < len(p) {
+		checkResult := stream.CheckWrite()
+		if err := checkResult.Err(); err != nil {
+			return totalWritten, streamWriteError(err)
+		}
+		budget := *checkResult.OK()
+		if budget == 0 {
+			pollable := stream.Subscribe()
+			poll.Wait(pollable)
+			pollable.ResourceDrop()
+			continue
+		}
+
+		chunk := p[totalWritten:]
+		if uint64(len(chunk)) > budget {
+			chunk = chunk[:budget]
+		}
+		writeResult := stream.Write(cm.ToList(chunk))
+		if err := writeResult.Err(); err != nil {
+			return totalWritten, streamWriteError(err)
+		}
+		totalWritten += len(chunk)
+	}
+	return totalWritten, nil
+}
+
+// Flush starts flushing stream and waits until the host has written out
+// everything, without blocking other goroutines.
+func Flush(stream streams.OutputStream) error {
+	flushResult := stream.Flush()
+	if err := flushResult.Err(); err != nil {
+		return streamWriteError(err)
+	}
+	// The stream becomes ready again once the flush completes
+	pollable := stream.Subscribe()
+	poll.Wait(pollable)
+	pollable.ResourceDrop()
+
+	checkResult := stream.CheckWrite()
+	if err := checkResult.Err(); err != nil {
+		return streamWriteError(err)
+	}
+	return nil
+}
+
+func streamWriteError(err *streams.StreamError) error {
+	if err.Closed() {
+		return io.EOF
+	}
+	ioErr := err.LastOperationFailed()
+	defer ioErr.ResourceDrop()
+	return fmt.Errorf("failed to write to OutputStream %s", ioErr.ToDebugString())
+}
+
+// Reader is an [io.ReadCloser] owning an input stream.
+type Reader struct {
+	stream streams.InputStream
+	closed bool
+}
+
+// NewReader takes ownership of stream, which is dropped by [Reader.Close].
+func NewReader(stream streams.InputStream) *Reader {
+	return &Reader{stream: stream}
+}
+
+func (r *Reader) Read(p []byte) (int, error) {
+	if r.closed {
+		return 0, io.ErrClosedPipe
+	}
+	return Read(r.stream, p)
+}
+
+// Close drops the stream. It is safe to call more than once.
+func (r *Reader) Close() error {
+	if !r.closed {
+		r.closed = true
+		r.stream.ResourceDrop()
+	}
+	return nil
+}
+
+// Writer is an [io.WriteCloser] owning an output stream.
+type Writer struct {
+	stream streams.OutputStream
+	closed bool
+}
+
+// NewWriter takes ownership of stream, which is dropped by [Writer.Close].
+func NewWriter(stream streams.OutputStream) *Writer {
+	return &Writer{stream: stream}
+}
+
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, io.ErrClosedPipe
+	}
+	return Write(w.stream, p)
+}
+
+// Close drops the stream. It is safe to call more than once.
+func (w *Writer) Close() error {
+	if !w.closed {
+		w.closed = true
+		w.stream.ResourceDrop()
+	}
+	return nil
+}
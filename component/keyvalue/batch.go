@@ -0,0 +1,52 @@
+package keyvalue
+
+import (
+	"go.bytecodealliance.org/cm"
+	"go.wasmcloud.dev/component/gen/wasi/keyvalue/batch"
+	"go.wasmcloud.dev/component/gen/wasi/keyvalue/store"
+)
+
+// GetMany returns the values stored under keys in a single call to the host.
+// Missing keys are left out of the returned map.
+func (b *Bucket) GetMany(keys ...string) (map[string][]byte, error) {
+	values := make(map[string][]byte, len(keys))
+	err := b.use(func(h store.Bucket) error {
+		res := batch.GetMany(h, cm.ToList(keys))
+		if err := resultError(res.Err()); err != nil {
+			return err
+		}
+		for _, opt := range res.OK().Slice() {
+			if kv := opt.Some(); kv != nil {
+				values[kv.F0] = kv.F1.Slice()
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// SetMany stores all of values in a single call to the host. The batch is not
+// atomic: on error, some of the keys may already have been written.
+func (b *Bucket) SetMany(values map[string][]byte) error {
+	kvs := make([]cm.Tuple[string, cm.List[uint8]], 0, len(values))
+	for k, v := range values {
+		kvs = append(kvs, cm.Tuple[string, cm.List[uint8]]{F0: k, F1: cm.ToList(v)})
+	}
+	return b.use(func(h store.Bucket) error {
+		res := batch.SetMany(h, cm.ToList(kvs))
+		return resultError(res.Err())
+	})
+}
+
+// DeleteMany removes all of keys in a single call to the host, skipping keys
+// that don't exist. The batch is not atomic: on error, some of the keys may
+// already have been deleted.
+func (b *Bucket) DeleteMany(keys ...string) error {
+	return b.use(func(h store.Bucket) error {
+		res := batch.DeleteMany(h, cm.ToList(keys))
+		return resultError(res.Err())
+	})
+}
@@ -0,0 +1,43 @@
+package keyvalue
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// GetJSON reads the value under key and decodes it as JSON into v.
+func (b *Bucket) GetJSON(key string, v any) error {
+	data, err := b.Get(key)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// SetJSON encodes v as JSON and stores it under key.
+func (b *Bucket) SetJSON(key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return b.Set(key, data)
+}
+
+// GetGob reads the value under key and decodes it with [encoding/gob] into v.
+func (b *Bucket) GetGob(key string, v any) error {
+	data, err := b.Get(key)
+	if err != nil {
+		return err
+	}
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// SetGob encodes v with [encoding/gob] and stores it under key.
+func (b *Bucket) SetGob(key string, v any) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return err
+	}
+	return b.Set(key, buf.Bytes())
+}
@@ -0,0 +1,135 @@
+package keyvalue
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"unsafe"
+
+	"go.bytecodealliance.org/cm"
+	"go.wasmcloud.dev/component/gen/wasi/keyvalue/store"
+)
+
+type record struct {
+	Name  string
+	Count int
+	Tags  []string
+	Inner *struct{ Enabled bool }
+}
+
+func testRecord() record {
+	return record{
+		Name:  "visits",
+		Count: 42,
+		Tags:  []string{"a", "b"},
+		Inner: &struct{ Enabled bool }{Enabled: true},
+	}
+}
+
+func openTestBucket(t *testing.T) *Bucket {
+	t.Helper()
+	clear(testStore)
+	b, err := Open("default")
+	if err != nil {
+		t.Fatalf("failed to open bucket: %v", err)
+	}
+	t.Cleanup(func() { b.Close() })
+	return b
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	b := openTestBucket(t)
+
+	want := testRecord()
+	if err := b.SetJSON("record", want); err != nil {
+		t.Fatalf("SetJSON: %v", err)
+	}
+	if got := string(testStore["record"]); got != `{"Name":"visits","Count":42,"Tags":["a","b"],"Inner":{"Enabled":true}}` {
+		t.Errorf("unexpected stored JSON %s", got)
+	}
+
+	var got record
+	if err := b.GetJSON("record", &got); err != nil {
+		t.Fatalf("GetJSON: %v", err)
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestGobRoundTrip(t *testing.T) {
+	b := openTestBucket(t)
+
+	want := testRecord()
+	if err := b.SetGob("record", want); err != nil {
+		t.Fatalf("SetGob: %v", err)
+	}
+
+	var got record
+	if err := b.GetGob("record", &got); err != nil {
+		t.Fatalf("GetGob: %v", err)
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestCodecErrors(t *testing.T) {
+	b := openTestBucket(t)
+
+	var v record
+	if err := b.GetJSON("missing", &v); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound from GetJSON, got %v", err)
+	}
+	if err := b.GetGob("missing", &v); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound from GetGob, got %v", err)
+	}
+
+	if err := b.SetJSON("chan", make(chan int)); err == nil {
+		t.Error("expected SetJSON to fail on an unsupported type")
+	}
+	if _, ok := testStore["chan"]; ok {
+		t.Error("expected nothing to be stored after an encoding error")
+	}
+
+	testStore["garbage"] = []byte("not json")
+	if err := b.GetJSON("garbage", &v); err == nil {
+		t.Error("expected GetJSON to fail on invalid data")
+	}
+	if err := b.GetGob("garbage", &v); err == nil {
+		t.Error("expected GetGob to fail on invalid data")
+	}
+}
+
+// testStore backs the stubbed wasi:keyvalue/store imports below.
+var testStore = map[string][]byte{}
+
+func stubString(ptr *uint8, n uint32) string {
+	return unsafe.String(ptr, n)
+}
+
+// stub wasi:keyvalue/store
+//
+//go:linkname wasmimport_Open go.wasmcloud.dev/component/gen/wasi/keyvalue/store.wasmimport_Open
+func wasmimport_Open(identifier0 *uint8, identifier1 uint32, result *cm.Result[store.ErrorShape, store.Bucket, store.Error]) {
+	*result = cm.OK[cm.Result[store.ErrorShape, store.Bucket, store.Error]](store.Bucket(1))
+}
+
+//go:linkname wasmimport_BucketResourceDrop go.wasmcloud.dev/component/gen/wasi/keyvalue/store.wasmimport_BucketResourceDrop
+func wasmimport_BucketResourceDrop(self0 uint32) {}
+
+//go:linkname wasmimport_BucketGet go.wasmcloud.dev/component/gen/wasi/keyvalue/store.wasmimport_BucketGet
+func wasmimport_BucketGet(self0 uint32, key0 *uint8, key1 uint32, result *cm.Result[store.OptionListU8Shape, cm.Option[cm.List[uint8]], store.Error]) {
+	value, ok := testStore[stubString(key0, key1)]
+	if !ok {
+		*result = cm.OK[cm.Result[store.OptionListU8Shape, cm.Option[cm.List[uint8]], store.Error]](cm.None[cm.List[uint8]]())
+		return
+	}
+	*result = cm.OK[cm.Result[store.OptionListU8Shape, cm.Option[cm.List[uint8]], store.Error]](cm.Some(cm.ToList(value)))
+}
+
+//go:linkname wasmimport_BucketSet go.wasmcloud.dev/component/gen/wasi/keyvalue/store.wasmimport_BucketSet
+func wasmimport_BucketSet(self0 uint32, key0 *uint8, key1 uint32, value0 *uint8, value1 uint32, result *cm.Result[store.Error, struct{}, store.Error]) {
+	testStore[stubString(key0, key1)] = append([]byte(nil), unsafe.Slice(value0, value1)...)
+	*result = cm.OK[cm.Result[store.Error, struct{}, store.Error]](struct{}{})
+}
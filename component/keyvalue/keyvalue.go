@@ -0,0 +1,199 @@
+// Package keyvalue provides a client for buckets exposed through
+// [wasi:keyvalue], hiding result unwrapping and resource handles behind a
+// plain Go API.
+//
+// [wasi:keyvalue]: https://github.com/WebAssembly/wasi-keyvalue
+package keyvalue
+
+import (
+	"errors"
+	"iter"
+	"runtime"
+	"sync"
+
+	"go.bytecodealliance.org/cm"
+	"go.wasmcloud.dev/component/gen/wasi/keyvalue/atomics"
+	"go.wasmcloud.dev/component/gen/wasi/keyvalue/store"
+)
+
+var (
+	// ErrNoSuchStore is returned when the host doesn't recognize the bucket identifier.
+	ErrNoSuchStore = errors.New("keyvalue: no such store")
+	// ErrAccessDenied is returned when the component may not access the bucket.
+	ErrAccessDenied = errors.New("keyvalue: access denied")
+	// ErrNotFound is returned when reading a key that doesn't exist.
+	ErrNotFound = errors.New("keyvalue: key not found")
+	// ErrClosed is returned when using a bucket after [Bucket.Close].
+	ErrClosed = errors.New("keyvalue: bucket closed")
+)
+
+// Error is an implementation-specific error reported by the host, such as an
+// I/O failure in the underlying store.
+type Error struct {
+	Message string
+}
+
+func (e *Error) Error() string {
+	return "keyvalue: " + e.Message
+}
+
+// Bucket is an open key-value bucket.
+//
+// The underlying resource is released by [Bucket.Close]. Buckets that are
+// never closed are released once garbage collected, on runtimes that
+// support finalizers.
+type Bucket struct {
+	mu     sync.RWMutex
+	handle store.Bucket
+	closed bool
+}
+
+// Open opens the bucket with the given identifier, as configured on the link
+// to the key-value provider.
+func Open(identifier string) (*Bucket, error) {
+	res := store.Open(identifier)
+	if err := resultError(res.Err()); err != nil {
+		return nil, err
+	}
+	b := &Bucket{handle: *res.OK()}
+	runtime.SetFinalizer(b, (*Bucket).Close)
+	return b, nil
+}
+
+// Close releases the bucket. It is safe to call more than once.
+func (b *Bucket) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	b.handle.ResourceDrop()
+	runtime.SetFinalizer(b, nil)
+	return nil
+}
+
+// use runs fn with the bucket handle, holding it open until fn returns.
+func (b *Bucket) use(fn func(store.Bucket) error) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return ErrClosed
+	}
+	return fn(b.handle)
+}
+
+// Get returns the value stored under key, or [ErrNotFound].
+func (b *Bucket) Get(key string) ([]byte, error) {
+	var value []byte
+	err := b.use(func(h store.Bucket) error {
+		res := h.Get(key)
+		if err := resultError(res.Err()); err != nil {
+			return err
+		}
+		opt := res.OK()
+		if opt.None() {
+			return ErrNotFound
+		}
+		value = opt.Some().Slice()
+		return nil
+	})
+	return value, err
+}
+
+// Set stores value under key, overwriting any existing value.
+func (b *Bucket) Set(key string, value []byte) error {
+	return b.use(func(h store.Bucket) error {
+		res := h.Set(key, cm.ToList(value))
+		return resultError(res.Err())
+	})
+}
+
+// Delete removes key. Deleting a missing key is not an error.
+func (b *Bucket) Delete(key string) error {
+	return b.use(func(h store.Bucket) error {
+		res := h.Delete(key)
+		return resultError(res.Err())
+	})
+}
+
+// Exists reports whether key is present in the bucket.
+func (b *Bucket) Exists(key string) (bool, error) {
+	var exists bool
+	err := b.use(func(h store.Bucket) error {
+		res := h.Exists(key)
+		if err := resultError(res.Err()); err != nil {
+			return err
+		}
+		exists = *res.OK()
+		return nil
+	})
+	return exists, err
+}
+
+// ListKeys iterates over all keys in the bucket, fetching pages from the host
+// as needed. Keys are not returned in any particular order, and may be out of
+// date if the bucket is written concurrently.
+//
+// Iteration stops at the first error, which is yielded with an empty key.
+func (b *Bucket) ListKeys() iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		cursor := cm.None[uint64]()
+		for {
+			var page store.KeyResponse
+			err := b.use(func(h store.Bucket) error {
+				res := h.ListKeys(cursor)
+				if err := resultError(res.Err()); err != nil {
+					return err
+				}
+				page = *res.OK()
+				return nil
+			})
+			if err != nil {
+				yield("", err)
+				return
+			}
+			for _, key := range page.Keys.Slice() {
+				if !yield(key, nil) {
+					return
+				}
+			}
+			if page.Cursor.None() {
+				return
+			}
+			cursor = page.Cursor
+		}
+	}
+}
+
+// Increment atomically adds delta to the number stored under key and returns
+// the new value. A missing key is created with the value delta.
+func (b *Bucket) Increment(key string, delta uint64) (uint64, error) {
+	var value uint64
+	err := b.use(func(h store.Bucket) error {
+		res := atomics.Increment(h, key, delta)
+		if err := resultError(res.Err()); err != nil {
+			return err
+		}
+		value = *res.OK()
+		return nil
+	})
+	return value, err
+}
+
+// resultError converts the error case of a store result, nil when the result
+// is OK.
+func resultError(e *store.Error) error {
+	switch {
+	case e == nil:
+		return nil
+	case e.NoSuchStore():
+		return ErrNoSuchStore
+	case e.AccessDenied():
+		return ErrAccessDenied
+	case e.Other() != nil:
+		return &Error{Message: *e.Other()}
+	default:
+		return &Error{Message: e.String()}
+	}
+}
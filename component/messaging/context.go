@@ -0,0 +1,56 @@
+package messaging
+
+import (
+	"context"
+	"log/slog"
+
+	"go.wasmcloud.dev/component/log/wasilog"
+)
+
+type contextKey int
+
+const (
+	subjectKey contextKey = iota
+	replyToKey
+	loggerKey
+)
+
+// newContext returns ctx carrying the subject, reply-to and logger of msg.
+func newContext(ctx context.Context, msg Message) context.Context {
+	ctx = context.WithValue(ctx, subjectKey, msg.Subject)
+	if msg.ReplyTo != "" {
+		ctx = context.WithValue(ctx, replyToKey, msg.ReplyTo)
+	}
+	return context.WithValue(ctx, loggerKey, messageLogger(msg))
+}
+
+// SubjectFromContext returns the subject of the message being handled.
+func SubjectFromContext(ctx context.Context) string {
+	subject, _ := ctx.Value(subjectKey).(string)
+	return subject
+}
+
+// ReplyToFromContext returns the reply-to subject of the message being
+// handled, if the sender expects a reply.
+func ReplyToFromContext(ctx context.Context) (string, bool) {
+	replyTo, ok := ctx.Value(replyToKey).(string)
+	return replyTo, ok
+}
+
+// LoggerFromContext returns a logger tagged with the subject and reply-to of
+// the message being handled, or [wasilog.DefaultLogger] outside of a handler.
+func LoggerFromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey).(*slog.Logger); ok {
+		return logger
+	}
+	return wasilog.DefaultLogger
+}
+
+// messageLogger returns the logger attached to the context of msg.
+func messageLogger(msg Message) *slog.Logger {
+	logger := wasilog.ContextLogger("messaging").With(slog.String("subject", msg.Subject))
+	if msg.ReplyTo != "" {
+		logger = logger.With(slog.String("reply-to", msg.ReplyTo))
+	}
+	return logger
+}
@@ -0,0 +1,124 @@
+// Package messaging lets components receive messages through the
+// [wasmcloud:messaging] handler export and send them through the consumer
+// import.
+//
+// [wasmcloud:messaging]: https://github.com/wasmCloud/messaging
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"go.bytecodealliance.org/cm"
+	"go.wasmcloud.dev/component/gen/wasmcloud/messaging/consumer"
+	"go.wasmcloud.dev/component/gen/wasmcloud/messaging/handler"
+	"go.wasmcloud.dev/component/gen/wasmcloud/messaging/types"
+)
+
+// ErrNoReplyTo is returned by [Reply] when the message expects no reply.
+var ErrNoReplyTo = errors.New("messaging: message has no reply-to subject")
+
+// Message is a message sent to or received from a broker.
+type Message struct {
+	Subject string
+	Body    []byte
+	// ReplyTo is the subject replies should be published to, empty if the
+	// sender expects no reply.
+	ReplyTo string
+}
+
+// HandlerFunc handles a message received from a subscription. Returning an
+// error reports the failure back to the host.
+type HandlerFunc func(ctx context.Context, msg Message) error
+
+// handlerFunc is the function that will be called for every message.
+var handlerFunc = defaultHandler
+
+// defaultHandler is a placeholder for returning a useful error to stderr when
+// the handler is not set.
+var defaultHandler = func(context.Context, Message) error {
+	fmt.Fprintln(os.Stderr, "messaging handler undefined")
+	return errors.New("messaging handler undefined")
+}
+
+// HandleMessage sets the handler function for the messaging trigger.
+// It must be set in an init() function.
+func HandleMessage(h HandlerFunc) {
+	handlerFunc = h
+}
+
+// Publish publishes msg without waiting for a response.
+func Publish(msg Message) error {
+	res := consumer.Publish(toBrokerMessage(msg))
+	if res.IsErr() {
+		return fmt.Errorf("messaging: publish: %s", *res.Err())
+	}
+	return nil
+}
+
+// Request publishes body to subject and waits up to timeout for the reply.
+// Timeouts are rounded down to milliseconds, and negative timeouts are treated
+// as zero.
+func Request(subject string, body []byte, timeout time.Duration) (*Message, error) {
+	res := consumer.Request(subject, cm.ToList(body), timeoutMillis(timeout))
+	if res.IsErr() {
+		return nil, fmt.Errorf("messaging: request: %s", *res.Err())
+	}
+	msg := fromBrokerMessage(*res.OK())
+	return &msg, nil
+}
+
+// timeoutMillis converts timeout to the milliseconds expected by the host,
+// clamped to the range of a uint32.
+func timeoutMillis(timeout time.Duration) uint32 {
+	return uint32(min(max(timeout.Milliseconds(), 0), math.MaxUint32))
+}
+
+// Reply publishes body to the reply-to subject of the message being handled
+// in ctx.
+func Reply(ctx context.Context, body []byte) error {
+	replyTo, ok := ReplyToFromContext(ctx)
+	if !ok {
+		return ErrNoReplyTo
+	}
+	return Publish(Message{Subject: replyTo, Body: body})
+}
+
+func wasiHandleMessage(msg types.BrokerMessage) cm.Result[string, struct{}, string] {
+	m := fromBrokerMessage(msg)
+	if err := handlerFunc(newContext(context.Background(), m), m); err != nil {
+		return cm.Err[cm.Result[string, struct{}, string]](err.Error())
+	}
+	return cm.OK[cm.Result[string, struct{}, string]](struct{}{})
+}
+
+func toBrokerMessage(msg Message) types.BrokerMessage {
+	replyTo := cm.None[string]()
+	if msg.ReplyTo != "" {
+		replyTo = cm.Some(msg.ReplyTo)
+	}
+	return types.BrokerMessage{
+		Subject: msg.Subject,
+		Body:    cm.ToList(msg.Body),
+		ReplyTo: replyTo,
+	}
+}
+
+func fromBrokerMessage(msg types.BrokerMessage) Message {
+	m := Message{
+		Subject: msg.Subject,
+		Body:    msg.Body.Slice(),
+	}
+	if replyTo := msg.ReplyTo.Some(); replyTo != nil {
+		m.ReplyTo = *replyTo
+	}
+	return m
+}
+
+func init() {
+	handler.Exports.HandleMessage = wasiHandleMessage
+}
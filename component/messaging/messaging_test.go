@@ -0,0 +1,142 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+	"unsafe"
+
+	"go.bytecodealliance.org/cm"
+	"go.wasmcloud.dev/component/gen/wasmcloud/messaging/types"
+)
+
+func TestTimeoutMillis(t *testing.T) {
+	for _, tt := range []struct {
+		timeout time.Duration
+		want    uint32
+	}{
+		{0, 0},
+		{time.Millisecond, 1},
+		{1500 * time.Microsecond, 1},
+		{5 * time.Second, 5000},
+		{-time.Second, 0},
+		{math.MinInt64, 0},
+		{math.MaxUint32 * time.Millisecond, math.MaxUint32},
+		{math.MaxInt64, math.MaxUint32},
+	} {
+		if got := timeoutMillis(tt.timeout); got != tt.want {
+			t.Errorf("timeoutMillis(%v) = %d, want %d", tt.timeout, got, tt.want)
+		}
+	}
+}
+
+func TestWasiHandleMessage(t *testing.T) {
+	t.Cleanup(func() { handlerFunc = defaultHandler })
+
+	t.Run("context", func(t *testing.T) {
+		var got Message
+		var subject, replyTo string
+		var hasReplyTo bool
+		HandleMessage(func(ctx context.Context, msg Message) error {
+			got = msg
+			subject = SubjectFromContext(ctx)
+			replyTo, hasReplyTo = ReplyToFromContext(ctx)
+			LoggerFromContext(ctx).Info("handled")
+			return nil
+		})
+
+		res := wasiHandleMessage(types.BrokerMessage{
+			Subject: "orders.created",
+			Body:    cm.ToList([]byte("order-1")),
+			ReplyTo: cm.Some("_INBOX.1"),
+		})
+		if res.IsErr() {
+			t.Fatalf("unexpected error %s", *res.Err())
+		}
+		if got.Subject != "orders.created" || string(got.Body) != "order-1" || got.ReplyTo != "_INBOX.1" {
+			t.Errorf("unexpected message %+v", got)
+		}
+		if subject != "orders.created" {
+			t.Errorf("expected subject orders.created in the context, got %q", subject)
+		}
+		if !hasReplyTo || replyTo != "_INBOX.1" {
+			t.Errorf("expected reply-to _INBOX.1 in the context, got %q (%v)", replyTo, hasReplyTo)
+		}
+		if want, got := "messaging", lastLog.context; want != got {
+			t.Errorf("expected log context %s, got %s", want, got)
+		}
+		for _, attr := range []string{`subject="orders.created"`, `reply-to="_INBOX.1"`} {
+			if !strings.Contains(lastLog.message, attr) {
+				t.Errorf("expected %s in log message %q", attr, lastLog.message)
+			}
+		}
+	})
+
+	t.Run("no reply-to", func(t *testing.T) {
+		var hasReplyTo bool
+		var replyErr error
+		HandleMessage(func(ctx context.Context, msg Message) error {
+			_, hasReplyTo = ReplyToFromContext(ctx)
+			replyErr = Reply(ctx, []byte("ignored"))
+			return nil
+		})
+
+		res := wasiHandleMessage(types.BrokerMessage{Subject: "orders.created", ReplyTo: cm.None[string]()})
+		if res.IsErr() {
+			t.Fatalf("unexpected error %s", *res.Err())
+		}
+		if hasReplyTo {
+			t.Error("expected no reply-to in the context")
+		}
+		if !errors.Is(replyErr, ErrNoReplyTo) {
+			t.Errorf("expected ErrNoReplyTo, got %v", replyErr)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		HandleMessage(func(context.Context, Message) error {
+			return errors.New("invalid order")
+		})
+
+		res := wasiHandleMessage(types.BrokerMessage{Subject: "orders.created"})
+		if !res.IsErr() {
+			t.Fatal("expected an error result")
+		}
+		if want, got := "invalid order", *res.Err(); want != got {
+			t.Errorf("expected error %q, got %q", want, got)
+		}
+	})
+
+	t.Run("undefined handler", func(t *testing.T) {
+		handlerFunc = defaultHandler
+
+		res := wasiHandleMessage(types.BrokerMessage{Subject: "orders.created"})
+		if !res.IsErr() {
+			t.Fatal("expected an error result")
+		}
+	})
+}
+
+// lastLog records the last entry written through the wasi:logging stub.
+var lastLog struct {
+	context string
+	message string
+}
+
+// stub wasi:logging
+//
+//go:linkname wasmimport_Log go.wasmcloud.dev/component/gen/wasi/logging/logging.wasmimport_Log
+func wasmimport_Log(level0 uint32, context0 *uint8, context1 uint32, message0 *uint8, message1 uint32) {
+	lastLog.context = strings.Clone(unsafe.String(context0, context1))
+	lastLog.message = strings.Clone(unsafe.String(message0, message1))
+}
+
+// stub wasmcloud:messaging/consumer, no test publishes
+//
+//go:linkname wasmimport_Publish go.wasmcloud.dev/component/gen/wasmcloud/messaging/consumer.wasmimport_Publish
+func wasmimport_Publish(msg0 *uint8, msg1 uint32, msg2 *uint8, msg3 uint32, msg4 uint32, msg5 *uint8, msg6 uint32, result *cm.Result[string, struct{}, string]) {
+	*result = cm.Err[cm.Result[string, struct{}, string]]("unexpected publish")
+}
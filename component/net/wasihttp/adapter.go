@@ -6,14 +6,29 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
+	"strings"
 	"sync"
 
 	"go.bytecodealliance.org/cm"
 	"go.wasmcloud.dev/component/gen/wasi/http/types"
 	"go.wasmcloud.dev/component/gen/wasi/io/streams"
+	"go.wasmcloud.dev/component/internal/wasiio"
 )
 
-var _ http.ResponseWriter = (*ResponseOutparamWriter)(nil)
+var (
+	_ http.ResponseWriter = (*ResponseOutparamWriter)(nil)
+	_ http.Flusher        = (*ResponseOutparamWriter)(nil)
+)
+
+// DefaultResponseBufferSize is the default value of [ResponseBufferSize].
+const DefaultResponseBufferSize = 4096
+
+// ResponseBufferSize is the number of response body bytes buffered by a
+// [ResponseOutparamWriter] before they are written to the host. Zero
+// disables buffering, flushing every write.
+// It must be set in an init() function.
+var ResponseBufferSize = DefaultResponseBufferSize
 
 // IncomingRequest represents an incoming HTTP request as defined in [wasi:http/types.incoming-request]
 //
@@ -22,6 +37,11 @@ type IncomingRequest = types.IncomingRequest
 
 // ResponseOutparamWriter implements a [net/http.ResponseWriter] for [wasi:http]
 //
+// Writes are buffered up to [ResponseBufferSize] bytes and written to the
+// host within its check-write budget. Handlers streaming a response can
+// push buffered data out with [ResponseOutparamWriter.Flush], or through
+// [net/http.ResponseController].
+//
 // [wasi:http]: https://github.com/WebAssembly/wasi-http/tree/v0.2.0
 type ResponseOutparamWriter struct {
 	outparam    types.ResponseOutparam
@@ -30,6 +50,8 @@ type ResponseOutparamWriter struct {
 	httpHeaders http.Header
 	body        *types.OutgoingBody
 	stream      *streams.OutputStream
+	buf         []byte
+	trailers    []string
 
 	headerOnce sync.Once
 	headerErr  error
@@ -49,20 +71,62 @@ func (row *ResponseOutparamWriter) Write(buf []byte) (int, error) {
 	if row.headerErr != nil {
 		return 0, row.headerErr
 	}
+	if row.stream == nil {
+		return 0, io.ErrClosedPipe
+	}
 
-	contents := cm.ToList(buf)
-	writeResult := row.stream.Write(contents)
-	if writeResult.IsErr() {
-		if writeResult.Err().Closed() {
-			return 0, io.EOF
+	if cap(row.buf) == 0 {
+		n, err := wasiio.WriteAvailable(*row.stream, buf)
+		if err != nil {
+			return n, err
 		}
+		return n, wasiio.Flush(*row.stream)
+	}
 
-		return 0, fmt.Errorf("failed to write to response body's stream: %s", writeResult.Err().LastOperationFailed().ToDebugString())
+	if len(row.buf)+len(buf) > cap(row.buf) {
+		if err := row.writeBuffer(); err != nil {
+			return 0, err
+		}
+		// Writes that wouldn't fit in an empty buffer skip it
+		if len(buf) >= cap(row.buf) {
+			return wasiio.WriteAvailable(*row.stream, buf)
+		}
 	}
+	row.buf = append(row.buf, buf...)
+	return len(buf), nil
+}
 
-	row.stream.BlockingFlush()
+// Flush sends the headers, if not yet sent, and any buffered data to the
+// client.
+func (row *ResponseOutparamWriter) Flush() {
+	_ = row.FlushError()
+}
 
-	return int(contents.Len()), nil
+// FlushError is like [ResponseOutparamWriter.Flush] but reports failures. It
+// is used by [net/http.ResponseController].
+func (row *ResponseOutparamWriter) FlushError() error {
+	row.headerOnce.Do(row.reconcile)
+	if row.headerErr != nil {
+		return row.headerErr
+	}
+	if row.stream == nil {
+		return io.ErrClosedPipe
+	}
+	if err := row.writeBuffer(); err != nil {
+		return err
+	}
+	return wasiio.Flush(*row.stream)
+}
+
+// writeBuffer writes the buffered data to the stream and empties the
+// buffer.
+func (row *ResponseOutparamWriter) writeBuffer() error {
+	if len(row.buf) == 0 {
+		return nil
+	}
+	_, err := wasiio.WriteAvailable(*row.stream, row.buf)
+	row.buf = row.buf[:0]
+	return err
 }
 
 // WriteHeader sends an HTTP response header with the provided
@@ -74,9 +138,15 @@ func (row *ResponseOutparamWriter) WriteHeader(statusCode int) {
 	})
 }
 
-// reconcile headers from go to wasi
+// reconcile headers from go to wasi. Announced trailers and keys prefixed
+// with [net/http.TrailerPrefix] are held back until [ResponseOutparamWriter.Close].
 func (row *ResponseOutparamWriter) reconcileHeaders() error {
+	row.trailers = trailerKeys(row.httpHeaders)
 	for key, vals := range row.httpHeaders {
+		if strings.HasPrefix(key, http.TrailerPrefix) || slices.Contains(row.trailers, key) {
+			continue
+		}
+
 		fieldVals := []types.FieldValue{}
 		for _, val := range vals {
 			fieldVals = append(fieldVals, types.FieldValue(cm.ToList([]uint8(val))))
@@ -87,12 +157,24 @@ func (row *ResponseOutparamWriter) reconcileHeaders() error {
 		}
 	}
 
-	// NOTE(lxf): once headers are written we clear them out so they can emit http trailers
-	row.httpHeaders = http.Header{}
-
 	return nil
 }
 
+// responseTrailers collects the trailers to send once the body is done, as
+// [net/http] does: the values of announced trailers, and keys prefixed with
+// [net/http.TrailerPrefix].
+func (row *ResponseOutparamWriter) responseTrailers() http.Header {
+	trailers := http.Header{}
+	for key, vals := range row.httpHeaders {
+		if name, ok := strings.CutPrefix(key, http.TrailerPrefix); ok {
+			trailers[http.CanonicalHeaderKey(name)] = vals
+		} else if slices.Contains(row.trailers, key) && len(vals) > 0 {
+			trailers[key] = vals
+		}
+	}
+	return trailers
+}
+
 func (row *ResponseOutparamWriter) reconcile() {
 	if row.headerErr = row.reconcileHeaders(); row.headerErr != nil {
 		return
@@ -114,6 +196,9 @@ func (row *ResponseOutparamWriter) reconcile() {
 		return
 	}
 	row.stream = writeResult.OK()
+	if ResponseBufferSize > 0 {
+		row.buf = make([]byte, 0, ResponseBufferSize)
+	}
 
 	result := cm.OK[cm.Result[types.ErrorCodeShape, types.OutgoingResponse, types.ErrorCode]](row.response)
 	types.ResponseOutparamSet(row.outparam, result)
@@ -122,37 +207,37 @@ func (row *ResponseOutparamWriter) reconcile() {
 // Close closes out the underlying stream by flushing the response and making
 // sure that the underlying resource handle is dropped.
 func (row *ResponseOutparamWriter) Close() error {
+	// Send the response even if the handler wrote nothing
+	row.headerOnce.Do(row.reconcile)
+	if row.headerErr != nil {
+		return row.headerErr
+	}
 	if row.stream == nil {
 		return nil
 	}
 
-	row.stream.BlockingFlush()
+	flushErr := row.writeBuffer()
+	if flushErr == nil {
+		flushErr = wasiio.Flush(*row.stream)
+	}
 	row.stream.ResourceDrop()
 	row.stream = nil
 
-	var maybeTrailers cm.Option[types.Fields]
-	wasiTrailers := types.NewFields()
-	for key, vals := range row.httpHeaders {
-		fieldVals := []types.FieldValue{}
-		for _, val := range vals {
-			fieldVals = append(fieldVals, types.FieldValue(cm.ToList([]uint8(val))))
+	maybeTrailers := cm.None[types.Fields]()
+	if trailers := row.responseTrailers(); len(trailers) > 0 {
+		wasiTrailers := types.NewFields()
+		if err := HTTPtoWASIHeader(trailers, wasiTrailers); err != nil {
+			wasiTrailers.ResourceDrop()
+			return fmt.Errorf("failed to set trailers: %w", err)
 		}
-
-		if result := wasiTrailers.Set(types.FieldKey(key), cm.ToList(fieldVals)); result.IsErr() {
-			return fmt.Errorf("failed to set trailer %s: %s", key, result.Err())
-		}
-	}
-	if len(row.httpHeaders) > 0 {
 		maybeTrailers = cm.Some(wasiTrailers)
-	} else {
-		maybeTrailers = cm.None[types.Fields]()
 	}
 
 	res := types.OutgoingBodyFinish(*row.body, maybeTrailers)
 	if res.IsErr() {
 		return fmt.Errorf("failed to set trailer: %v", res.Err())
 	}
-	return nil
+	return flushErr
 }
 
 // WASItoHTTPResponseWriter takes a [types.ResponseOutparam] representing [wasi:http/types.response-outparam]
@@ -209,6 +294,9 @@ func WASItoHTTPRequest(ir IncomingRequest) (req *http.Request, err error) {
 	headers := ir.Headers()
 	WASItoHTTPHeader(headers, &req.Header)
 	headers.ResourceDrop()
+	if trailers != nil {
+		declareTrailers(req.Header, trailers)
+	}
 
 	req.Host = authority
 	req.URL.Host = authority
@@ -0,0 +1,121 @@
+package wasihttp
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"syscall"
+
+	"go.wasmcloud.dev/component/gen/wasi/http/types"
+)
+
+// Error is a failure reported by the host through a wasi:http error-code.
+// DNS failures are reported as [*net.DNSError] and TLS failures as
+// [*TLSError] instead, both wrapping an Error.
+type Error struct {
+	Code types.ErrorCode
+}
+
+var _ net.Error = (*Error)(nil)
+
+func (e *Error) Error() string {
+	return "wasihttp: " + e.Code.String()
+}
+
+// Timeout reports whether the host timed the request out.
+func (e *Error) Timeout() bool {
+	switch {
+	case e.Code.DNSTimeout(), e.Code.ConnectionTimeout(), e.Code.ConnectionReadTimeout(),
+		e.Code.ConnectionWriteTimeout(), e.Code.HTTPResponseTimeout():
+		return true
+	}
+	return false
+}
+
+// Temporary reports whether retrying the request may succeed.
+//
+// Deprecated: as with [net.Error], temporary errors are not well-defined.
+// Use Timeout or inspect the Code instead.
+func (e *Error) Temporary() bool {
+	return e.Timeout() || e.Code.ConnectionLimitReached() || e.Code.DestinationUnavailable()
+}
+
+// Unwrap maps the code to the matching standard library error, so callers
+// can use errors.Is(err, os.ErrDeadlineExceeded) or
+// errors.Is(err, syscall.ECONNREFUSED) as with [net/http.Transport].
+func (e *Error) Unwrap() error {
+	switch {
+	case e.Timeout():
+		return os.ErrDeadlineExceeded
+	case e.Code.ConnectionRefused():
+		return syscall.ECONNREFUSED
+	case e.Code.ConnectionTerminated():
+		return syscall.ECONNRESET
+	case e.Code.DestinationIPUnroutable():
+		return syscall.EHOSTUNREACH
+	}
+	return nil
+}
+
+// TLSError reports a failed TLS handshake or session.
+type TLSError struct {
+	// Alert is the TLS alert description received from the peer, or zero.
+	Alert uint8
+	// Message describes the failure.
+	Message string
+	// Err is the underlying host error.
+	Err *Error
+}
+
+var _ net.Error = (*TLSError)(nil)
+
+func (e *TLSError) Error() string {
+	return "wasihttp: tls: " + e.Message
+}
+
+func (e *TLSError) Timeout() bool { return false }
+
+// Temporary always returns false.
+//
+// Deprecated: as with [net.Error], temporary errors are not well-defined.
+func (e *TLSError) Temporary() bool { return false }
+
+func (e *TLSError) Unwrap() error {
+	return e.Err
+}
+
+// errorFromCode converts a wasi:http error-code for a request to host into
+// a typed error.
+func errorFromCode(code types.ErrorCode, host string) error {
+	err := &Error{Code: code}
+	switch {
+	case code.DNSTimeout():
+		return &net.DNSError{Err: "i/o timeout", Name: host, IsTimeout: true, UnwrapErr: err}
+	case code.DNSError() != nil:
+		payload := code.DNSError()
+		dnsErr := &net.DNSError{Err: "dns error", Name: host, UnwrapErr: err}
+		if rcode := payload.Rcode.Some(); rcode != nil {
+			dnsErr.Err = *rcode
+			dnsErr.IsNotFound = *rcode == "NXDOMAIN"
+		}
+		return dnsErr
+	case code.DestinationNotFound():
+		return &net.DNSError{Err: "no such host", Name: host, IsNotFound: true, UnwrapErr: err}
+	case code.TLSProtocolError():
+		return &TLSError{Message: "protocol error", Err: err}
+	case code.TLSCertificateError():
+		return &TLSError{Message: "certificate error", Err: err}
+	case code.TLSAlertReceived() != nil:
+		payload := code.TLSAlertReceived()
+		tlsErr := &TLSError{Message: "alert received", Err: err}
+		if id := payload.AlertID.Some(); id != nil {
+			tlsErr.Alert = *id
+			tlsErr.Message = "alert " + strconv.Itoa(int(*id)) + " received"
+		}
+		if msg := payload.AlertMessage.Some(); msg != nil {
+			tlsErr.Message += ": " + *msg
+		}
+		return tlsErr
+	}
+	return err
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+)
+
+// MaxBodySize returns middleware that limits request bodies to n bytes.
+// Requests declaring a larger Content-Length are rejected with 413 Request
+// Entity Too Large. Otherwise reads past the limit fail with
+// [*http.MaxBytesError].
+func MaxBodySize(n int64) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > n {
+				http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+				return
+			}
+			if r.Body != nil {
+				r.Body = http.MaxBytesReader(w, r.Body, n)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// CORSOptions configures [CORS].
+type CORSOptions struct {
+	// AllowedOrigins lists the origins allowed to make cross-origin
+	// requests. "*" allows any origin. Empty allows none.
+	AllowedOrigins []string
+
+	// AllowedMethods lists the methods allowed in cross-origin requests.
+	// Empty allows GET, HEAD and POST.
+	AllowedMethods []string
+
+	// AllowedHeaders lists the request headers allowed in cross-origin
+	// requests. Empty reflects the headers requested in the preflight.
+	AllowedHeaders []string
+
+	// ExposedHeaders lists the response headers visible to the client.
+	ExposedHeaders []string
+
+	// AllowCredentials allows requests with credentials. Browsers reject
+	// credentialed responses allowing any origin, so it requires explicit
+	// AllowedOrigins.
+	AllowCredentials bool
+
+	// MaxAge is how many seconds preflight responses may be cached. Zero
+	// omits the header.
+	MaxAge int
+}
+
+// CORS returns middleware implementing cross-origin resource sharing.
+// Preflight requests are answered with 204 No Content without calling the
+// handler.
+//
+// CORS panics if AllowCredentials is combined with the "*" origin, since
+// allowing credentials from every origin lets any site act as the user.
+func CORS(opts CORSOptions) Middleware {
+	methods := opts.AllowedMethods
+	if len(methods) == 0 {
+		methods = []string{http.MethodGet, http.MethodHead, http.MethodPost}
+	}
+	allowAny := slices.Contains(opts.AllowedOrigins, "*")
+	if allowAny && opts.AllowCredentials {
+		panic("middleware: CORS AllowCredentials requires explicit AllowedOrigins, not \"*\"")
+	}
+
+	allowedOrigin := func(origin string) string {
+		switch {
+		case allowAny:
+			return "*"
+		case slices.Contains(opts.AllowedOrigins, origin):
+			return origin
+		}
+		return ""
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Add("Vary", "Origin")
+			allowed := allowedOrigin(origin)
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+			if allowed != "" {
+				h.Set("Access-Control-Allow-Origin", allowed)
+				if opts.AllowCredentials {
+					h.Set("Access-Control-Allow-Credentials", "true")
+				}
+			}
+			if !preflight {
+				if allowed != "" && len(opts.ExposedHeaders) > 0 {
+					h.Set("Access-Control-Expose-Headers", strings.Join(opts.ExposedHeaders, ", "))
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h.Add("Vary", "Access-Control-Request-Method")
+			h.Add("Vary", "Access-Control-Request-Headers")
+			if allowed != "" && slices.Contains(methods, r.Header.Get("Access-Control-Request-Method")) {
+				h.Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+				if len(opts.AllowedHeaders) > 0 {
+					h.Set("Access-Control-Allow-Headers", strings.Join(opts.AllowedHeaders, ", "))
+				} else if requested := r.Header.Get("Access-Control-Request-Headers"); requested != "" {
+					h.Set("Access-Control-Allow-Headers", requested)
+				}
+				if opts.MaxAge > 0 {
+					h.Set("Access-Control-Max-Age", strconv.Itoa(opts.MaxAge))
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
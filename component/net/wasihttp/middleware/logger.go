@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"go.wasmcloud.dev/component/log/wasilog"
+)
+
+// Logger returns middleware that logs each request once it has been served,
+// with its method, path, status, response size and duration, and its
+// request ID when [RequestID] runs first. A nil logger logs through
+// wasilog with the "http" context.
+func Logger(logger *slog.Logger) Middleware {
+	if logger == nil {
+		logger = wasilog.ContextLogger("http")
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := newResponseRecorder(w)
+			next.ServeHTTP(rec, r)
+
+			attrs := []slog.Attr{
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", rec.Status()),
+				slog.Int64("bytes", rec.written),
+				slog.Duration("duration", time.Since(start)),
+			}
+			if id := RequestIDFromContext(r.Context()); id != "" {
+				attrs = append(attrs, slog.String("request_id", id))
+			}
+			level := slog.LevelInfo
+			if rec.Status() >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+			logger.LogAttrs(r.Context(), level, "served request", attrs...)
+		})
+	}
+}
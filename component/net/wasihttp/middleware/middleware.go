@@ -0,0 +1,95 @@
+// Package middleware provides [net/http] middleware commonly needed by HTTP
+// components: panic recovery, request logging, request IDs, CORS, body size
+// limits and gzip compression. OpenTelemetry tracing lives in
+// go.wasmcloud.dev/x/wasitel/wasitelhttp, so this package doesn't depend on
+// OpenTelemetry.
+//
+// Each middleware wraps an [http.Handler], so they compose with each other,
+// with third-party middleware, and with [wasihttp.Handle]:
+//
+//	func init() {
+//		mux := http.NewServeMux()
+//		mux.HandleFunc("/", index)
+//		wasihttp.Handle(middleware.Chain(mux,
+//			middleware.Recover(),
+//			middleware.RequestID(),
+//			middleware.Logger(nil),
+//			middleware.Gzip(),
+//		))
+//	}
+//
+// [wasihttp.Handle]: https://pkg.go.dev/go.wasmcloud.dev/component/net/wasihttp#Handle
+package middleware
+
+import (
+	"net/http"
+)
+
+// Middleware wraps an [http.Handler] with additional behavior.
+type Middleware func(http.Handler) http.Handler
+
+// Chain wraps h with the given middleware. The first middleware is the
+// outermost, so it sees the request first and the response last.
+func Chain(h http.Handler, middleware ...Middleware) http.Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		h = middleware[i](h)
+	}
+	return h
+}
+
+// responseRecorder tracks the status and size of a response written
+// through it.
+type responseRecorder struct {
+	http.ResponseWriter
+	status  int
+	written int64
+}
+
+func newResponseRecorder(w http.ResponseWriter) *responseRecorder {
+	if rec, ok := w.(*responseRecorder); ok {
+		return rec
+	}
+	return &responseRecorder{ResponseWriter: w}
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(p []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(p)
+	r.written += int64(n)
+	return n, err
+}
+
+// Status returns the response status, or 200 if the handler didn't write
+// anything.
+func (r *responseRecorder) Status() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
+
+// wroteHeader reports whether the response headers have been sent.
+func (r *responseRecorder) wroteHeader() bool {
+	return r.status != 0
+}
+
+func (r *responseRecorder) Flush() {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	http.NewResponseController(r.ResponseWriter).Flush()
+}
+
+// Unwrap allows [http.ResponseController] to reach the underlying writer.
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
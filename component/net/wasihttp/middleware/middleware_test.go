@@ -0,0 +1,246 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	_ "unsafe"
+)
+
+func TestChainOrder(t *testing.T) {
+	var order []string
+	mark := func(name string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+	h := Chain(http.NotFoundHandler(), mark("a"), mark("b"), mark("c"))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if got := strings.Join(order, ","); got != "a,b,c" {
+		t.Errorf("expected: a,b,c, got: %v", got)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	h := Recover()(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected: %v, got: %v", http.StatusInternalServerError, w.Code)
+	}
+}
+
+func TestRecoverAbortHandler(t *testing.T) {
+	h := Recover()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("partial"))
+		panic(http.ErrAbortHandler)
+	}))
+	defer func() {
+		if v := recover(); v != http.ErrAbortHandler {
+			t.Errorf("expected: %v, got: %v", http.ErrAbortHandler, v)
+		}
+	}()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	t.Error("expected the abort panic to propagate")
+}
+
+func TestRequestID(t *testing.T) {
+	var seen string
+	h := RequestID()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestIDFromContext(r.Context())
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if len(seen) != 32 || w.Header().Get(RequestIDHeader) != seen {
+		t.Errorf("expected generated id in context and header, got: %q, %q", seen, w.Header().Get(RequestIDHeader))
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(RequestIDHeader, "abc")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	if seen != "abc" {
+		t.Errorf("expected: abc, got: %v", seen)
+	}
+	for _, id := range []string{"a b", "id\x00", "ünicode", strings.Repeat("a", 129)} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(RequestIDHeader, id)
+		h.ServeHTTP(httptest.NewRecorder(), req)
+		if len(seen) != 32 {
+			t.Errorf("expected %q to be replaced by a generated id, got: %q", id, seen)
+		}
+	}
+}
+
+func TestCORS(t *testing.T) {
+	called := false
+	h := CORS(CORSOptions{
+		AllowedOrigins: []string{"https://example.com"},
+		AllowedMethods: []string{http.MethodGet, http.MethodPut},
+		MaxAge:         600,
+	})(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		called = true
+	}))
+
+	tt := map[string]struct {
+		origin      string
+		method      string
+		allowOrigin string
+		status      int
+		called      bool
+	}{
+		"preflight": {
+			origin:      "https://example.com",
+			method:      http.MethodPut,
+			allowOrigin: "https://example.com",
+			status:      http.StatusNoContent,
+		},
+		"preflight disallowed origin": {
+			origin: "https://evil.example",
+			method: http.MethodPut,
+			status: http.StatusNoContent,
+		},
+		"simple": {
+			origin:      "https://example.com",
+			allowOrigin: "https://example.com",
+			status:      http.StatusOK,
+			called:      true,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			called = false
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.method != "" {
+				req.Method = http.MethodOptions
+				req.Header.Set("Access-Control-Request-Method", tc.method)
+			}
+			req.Header.Set("Origin", tc.origin)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+
+			if w.Code != tc.status {
+				t.Errorf("expected status: %v, got: %v", tc.status, w.Code)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tc.allowOrigin {
+				t.Errorf("expected origin: %q, got: %q", tc.allowOrigin, got)
+			}
+			if called != tc.called {
+				t.Errorf("expected called: %v, got: %v", tc.called, called)
+			}
+		})
+	}
+}
+
+func TestCORSAnyOrigin(t *testing.T) {
+	h := CORS(CORSOptions{AllowedOrigins: []string{"*"}})(http.NotFoundHandler())
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Origin", "https://example.com")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("expected origin: %q, got: %q", "*", got)
+	}
+}
+
+func TestCORSCredentials(t *testing.T) {
+	h := CORS(CORSOptions{
+		AllowedOrigins:   []string{"https://example.com"},
+		AllowCredentials: true,
+	})(http.NotFoundHandler())
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Origin", "https://example.com")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://example.com" {
+		t.Errorf("expected origin: %q, got: %q", "https://example.com", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Errorf("expected credentials: %q, got: %q", "true", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected CORS to reject credentials with any origin")
+		}
+	}()
+	CORS(CORSOptions{AllowedOrigins: []string{"*"}, AllowCredentials: true})
+}
+
+func TestMaxBodySize(t *testing.T) {
+	h := MaxBodySize(4)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		}
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("too long")))
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected: %v, got: %v", http.StatusRequestEntityTooLarge, w.Code)
+	}
+
+	// Without a Content-Length the limit applies while reading
+	req := httptest.NewRequest(http.MethodPost, "/", io.NopCloser(strings.NewReader("too long")))
+	req.ContentLength = -1
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected: %v, got: %v", http.StatusRequestEntityTooLarge, w.Code)
+	}
+}
+
+func TestGzip(t *testing.T) {
+	body := strings.Repeat("hello, world\n", 100)
+	h := Gzip()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, body)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "br, gzip")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("expected: gzip, got: %q", got)
+	}
+	if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/plain") {
+		t.Errorf("expected sniffed text/plain, got: %q", got)
+	}
+	zr, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != body {
+		t.Errorf("unexpected body after decompression: %q", got)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip;q=0")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if got := w.Header().Get("Content-Encoding"); got != "" {
+		t.Errorf("expected no encoding, got: %q", got)
+	}
+}
+
+// stub wasi:logging
+//
+//go:linkname wasmimport_Log go.wasmcloud.dev/component/gen/wasi/logging/logging.wasmimport_Log
+func wasmimport_Log(level0 uint32, context0 *uint8, context1 uint32, message0 *uint8, message1 uint32) {
+}
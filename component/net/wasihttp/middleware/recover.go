@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+
+	"go.wasmcloud.dev/component/log/wasilog"
+)
+
+// Recover returns middleware that recovers from panics in the handler,
+// logging them through wasilog and responding with 500 Internal Server
+// Error if nothing has been written yet. Without it a panic traps the
+// component instance.
+//
+// Panics with [http.ErrAbortHandler] are not logged or recovered. They are
+// re-raised so the response is aborted instead of completing as an empty
+// 200 OK, as with [net/http.Server].
+func Recover() Middleware {
+	logger := wasilog.ContextLogger("http")
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := newResponseRecorder(w)
+			defer func() {
+				v := recover()
+				if v == nil {
+					return
+				}
+				if v == http.ErrAbortHandler {
+					panic(v)
+				}
+				logger.ErrorContext(r.Context(), "panic serving request",
+					slog.String("method", r.Method),
+					slog.String("path", r.URL.Path),
+					slog.String("panic", fmt.Sprint(v)),
+					slog.String("stack", string(debug.Stack())),
+				)
+				if !rec.wroteHeader() {
+					http.Error(rec, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				}
+			}()
+			next.ServeHTTP(rec, r)
+		})
+	}
+}
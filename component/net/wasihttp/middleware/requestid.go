@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+const (
+	// RequestIDHeader is the header carrying the request ID.
+	RequestIDHeader = "X-Request-Id"
+	// maxRequestIDLength bounds the length of IDs accepted from clients.
+	maxRequestIDLength = 128
+)
+
+type requestIDKey struct{}
+
+// RequestID returns middleware that assigns each request an ID, reusing the
+// one in the [RequestIDHeader] request header when it is at most 128
+// printable ASCII characters without spaces. The ID is
+// echoed in the response header and available to handlers through
+// [RequestIDFromContext].
+func RequestID() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(RequestIDHeader)
+			if !validRequestID(id) {
+				id = newRequestID()
+			}
+			w.Header().Set(RequestIDHeader, id)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id)))
+		})
+	}
+}
+
+// RequestIDFromContext returns the ID assigned by [RequestID], or an empty
+// string.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// validRequestID reports whether a client supplied ID is safe to reuse in
+// headers and logs.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID returns 16 random bytes, hex encoded. Randomness comes from
+// wasi:random through crypto/rand.
+func newRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -1,9 +1,13 @@
 package wasihttp
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	"go.bytecodealliance.org/cm"
@@ -15,9 +19,26 @@ import (
 
 // Transport implements [http.RoundTripper] for [wasi:http].
 //
+// Timeouts are enforced by the host. A deadline on the request context also
+// bounds the connect, first-byte and between-bytes timeouts. Canceling the
+// context abandons the request while waiting for the response, and makes
+// later reads of the response body fail with the context error. Failures
+// reported by the host are returned as [*Error], [*TLSError] or
+// [*net.DNSError].
+//
 // [wasi:http]: https://github.com/WebAssembly/wasi-http/tree/v0.2.0
 type Transport struct {
+	// ConnectTimeout is the maximum amount of time to wait for a connection
+	// to be established. Zero means no timeout.
 	ConnectTimeout time.Duration
+
+	// FirstByteTimeout is the maximum amount of time to wait for the first
+	// byte of the response. Zero means no timeout.
+	FirstByteTimeout time.Duration
+
+	// BetweenBytesTimeout is the maximum amount of time to wait between
+	// bytes of the response. Zero means no timeout.
+	BetweenBytesTimeout time.Duration
 }
 
 var _ http.RoundTripper = (*Transport)(nil)
@@ -33,27 +54,41 @@ var DefaultTransport = &Transport{
 // [wasi:http]: https://github.com/WebAssembly/wasi-http/tree/v0.2.0
 var DefaultClient = &http.Client{Transport: DefaultTransport}
 
-func (r *Transport) requestOptions() types.RequestOptions {
+func (r *Transport) requestOptions(ctx context.Context) types.RequestOptions {
 	options := types.NewRequestOptions()
-	if r.ConnectTimeout > 0 {
-		// Go’s time.Duration is a nanosecond count, and WASI’s monotonicclock.Duration is also a u64 of nanoseconds
-		options.SetConnectTimeout(
-			cm.Some(monotonicclock.Duration(r.ConnectTimeout)),
-		)
-	} else {
-		options.SetConnectTimeout(
-			cm.None[monotonicclock.Duration](),
-		)
-	}
+	// Go’s time.Duration is a nanosecond count, and WASI’s monotonicclock.Duration is also a u64 of nanoseconds
+	deadline, _ := ctx.Deadline()
+	options.SetConnectTimeout(timeoutOption(r.ConnectTimeout, deadline))
+	options.SetFirstByteTimeout(timeoutOption(r.FirstByteTimeout, deadline))
+	options.SetBetweenBytesTimeout(timeoutOption(r.BetweenBytesTimeout, deadline))
 	return options
 }
 
+// timeoutOption returns the shorter of timeout and the time left until
+// deadline, ignoring either when zero.
+func timeoutOption(timeout time.Duration, deadline time.Time) cm.Option[monotonicclock.Duration] {
+	if !deadline.IsZero() {
+		// Never pass zero, which hosts may treat as no timeout
+		remaining := max(time.Until(deadline), time.Nanosecond)
+		if timeout <= 0 || remaining < timeout {
+			timeout = remaining
+		}
+	}
+	if timeout <= 0 {
+		return cm.None[monotonicclock.Duration]()
+	}
+	return cm.Some(monotonicclock.Duration(timeout))
+}
+
 // RoundTrip implements the [net/http.RoundTripper] interface.
 func (r *Transport) RoundTrip(incomingRequest *http.Request) (*http.Response, error) {
-	var err error
+	ctx := incomingRequest.Context()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	outHeaders := types.NewFields()
-	if err := HTTPtoWASIHeader(incomingRequest.Header, outHeaders); err != nil {
+	if err := HTTPtoWASIHeader(announceTrailers(incomingRequest.Header, incomingRequest.Trailer), outHeaders); err != nil {
 		return nil, fmt.Errorf("failed to convert outgoing headers: %w", err)
 	}
 
@@ -82,19 +117,12 @@ func (r *Transport) RoundTrip(incomingRequest *http.Request) (*http.Response, er
 		return nil, fmt.Errorf("failed to acquire resource handle to request body: %s", bodyErr)
 	}
 
-	futureResponse, handlerErr, isErr := outgoinghandler.Handle(outRequest, cm.Some(r.requestOptions())).Result()
+	futureResponse, handlerErr, isErr := outgoinghandler.Handle(outRequest, cm.Some(r.requestOptions(ctx))).Result()
 	if isErr {
-		return nil, fmt.Errorf("failed to acquire handle to outbound request: %s", handlerErr)
-	}
-
-	maybeTrailers := cm.None[types.Fields]()
-	if len(incomingRequest.Trailer) > 0 {
-		outTrailers := types.NewFields()
-		if err := HTTPtoWASIHeader(incomingRequest.Trailer, outTrailers); err != nil {
-			return nil, fmt.Errorf("failed to convert outgoing trailers: %w", err)
-		}
-		maybeTrailers = cm.Some(outTrailers)
+		return nil, errorFromCode(handlerErr, incomingRequest.URL.Hostname())
 	}
+	// Dropping the future abandons the request if no response was received
+	defer futureResponse.ResourceDrop()
 
 	// NOTE(lxf): If request includes a body, copy it to the adapted wasi body
 	if incomingRequest.Body != nil {
@@ -112,6 +140,16 @@ func (r *Transport) RoundTrip(incomingRequest *http.Request) (*http.Response, er
 		}
 	}
 
+	// Trailer values may be set while the body is read, so collect them last
+	maybeTrailers := cm.None[types.Fields]()
+	if trailers := requestTrailers(incomingRequest.Trailer); len(trailers) > 0 {
+		outTrailers := types.NewFields()
+		if err := HTTPtoWASIHeader(trailers, outTrailers); err != nil {
+			return nil, fmt.Errorf("failed to convert outgoing trailers: %w", err)
+		}
+		maybeTrailers = cm.Some(outTrailers)
+	}
+
 	// From `outgoing-body` documentation:
 	// Finalize an outgoing body, optionally providing trailers. This must be
 	// called to signal that the response is complete.
@@ -120,8 +158,13 @@ func (r *Transport) RoundTrip(incomingRequest *http.Request) (*http.Response, er
 		return nil, fmt.Errorf("failed to finish body: %s", outFinish.Err())
 	}
 
-	// wait until resp is returned
-	poll.Resolve(futureResponse.Subscribe())
+	// wait until resp is returned or the request is canceled
+	pollable := futureResponse.Subscribe()
+	err := poll.WaitContext(ctx, pollable)
+	pollable.ResourceDrop()
+	if err != nil {
+		return nil, err
+	}
 
 	incomingResponseOuterOption := futureResponse.Get()
 	if incomingResponseOuterOption.None() {
@@ -138,10 +181,14 @@ func (r *Transport) RoundTrip(incomingRequest *http.Request) (*http.Response, er
 	// Unwrap the inner Result
 	incomingResponse, innerResultErr, isErr := innerResult.Result()
 	if isErr {
-		return nil, fmt.Errorf("failed to unwrap the inner result for incoming-response: %s", innerResultErr)
+		// A deadline mapped to a host timeout is reported as the context error
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errorFromCode(innerResultErr, incomingRequest.URL.Hostname())
 	}
 
-	incomingBody, incomingTrailers, err := NewIncomingBodyTrailer(incomingResponse)
+	incomingBody, incomingTrailers, err := newIncomingBodyTrailer(ctx, incomingResponse)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse incoming-response: %w", err)
 	}
@@ -150,6 +197,7 @@ func (r *Transport) RoundTrip(incomingRequest *http.Request) (*http.Response, er
 	headers := incomingResponse.Headers()
 	WASItoHTTPHeader(headers, &incomingHeaders)
 	headers.ResourceDrop()
+	declareTrailers(incomingHeaders, incomingTrailers)
 
 	resp := &http.Response{
 		StatusCode: int(incomingResponse.Status()),
@@ -162,3 +210,29 @@ func (r *Transport) RoundTrip(incomingRequest *http.Request) (*http.Response, er
 
 	return resp, nil
 }
+
+// announceTrailers returns header with a Trailer header naming the keys of
+// trailer, unless the caller already set one.
+func announceTrailers(header, trailer http.Header) http.Header {
+	if len(trailer) == 0 || header.Get("Trailer") != "" {
+		return header
+	}
+	keys := slices.Sorted(maps.Keys(trailer))
+	header = header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Trailer", strings.Join(keys, ", "))
+	return header
+}
+
+// requestTrailers returns the trailers that were given values.
+func requestTrailers(trailer http.Header) http.Header {
+	trailers := http.Header{}
+	for key, vals := range trailer {
+		if len(vals) > 0 {
+			trailers[key] = vals
+		}
+	}
+	return trailers
+}
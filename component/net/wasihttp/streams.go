@@ -1,15 +1,18 @@
 package wasihttp
 
 import (
+	"context"
 	"errors"
-	"fmt"
 	"io"
 	"net/http"
+	"net/textproto"
+	"strings"
 	"sync"
 
 	"go.bytecodealliance.org/cm"
 	"go.wasmcloud.dev/component/gen/wasi/http/types"
 	"go.wasmcloud.dev/component/gen/wasi/io/streams"
+	"go.wasmcloud.dev/component/internal/wasiio"
 	poll "go.wasmcloud.dev/component/poll"
 )
 
@@ -25,44 +28,52 @@ type BodyConsumer interface {
 }
 
 type inputStreamReader struct {
+	ctx         context.Context
 	consumer    BodyConsumer
 	body        *types.IncomingBody
 	stream      *streams.InputStream
 	trailerLock sync.Mutex
 	trailers    http.Header
 	trailerOnce sync.Once
+	closed      bool
 }
 
+// Close releases the body. Trailers are only read once the body has been
+// read to EOF, since the host can't deliver them before.
 func (r *inputStreamReader) Close() error {
-	r.trailerOnce.Do(r.parseTrailers)
-
-	if r.stream != nil {
-		r.stream.ResourceDrop()
-	}
-
-	if r.body != nil {
-		r.body.ResourceDrop()
-		r.body = nil
-	}
-
+	r.trailerOnce.Do(r.release)
+	r.closed = true
 	return nil
 }
 
+// release drops the body without waiting for trailers.
+func (r *inputStreamReader) release() {
+	r.stream.ResourceDrop()
+	r.stream = nil
+	r.body.ResourceDrop()
+	r.body = nil
+}
+
+// parseTrailers finishes the body and copies the trailers sent after it, if
+// any, into r.trailers.
 func (r *inputStreamReader) parseTrailers() {
 	r.trailerLock.Lock()
 	defer r.trailerLock.Unlock()
 
-	// if we got this far, then we release ownership from body, otherwise it is our responsibility to drop it
+	// the stream is a child of the body and must be dropped before finishing it
 	r.stream.ResourceDrop()
 	r.stream = nil
 
 	futureTrailers := types.IncomingBodyFinish(*r.body)
+	r.body = nil
 	defer futureTrailers.ResourceDrop()
 
-	trailersResult := futureTrailers.Get()
-	r.body = nil
+	pollable := futureTrailers.Subscribe()
+	poll.Wait(pollable)
+	pollable.ResourceDrop()
 
 	// unroll the future
+	trailersResult := futureTrailers.Get()
 	if trailersResult.None() {
 		return
 	}
@@ -79,34 +90,60 @@ func (r *inputStreamReader) parseTrailers() {
 	}
 
 	wasiTrailers := maybeWasiTrailers.Some()
+	defer wasiTrailers.ResourceDrop()
 	for _, kv := range wasiTrailers.Entries().Slice() {
 		r.trailers.Add(string(kv.F0), string(kv.F1.Slice()))
 	}
-
-	wasiTrailers.ResourceDrop()
 }
 
 func (r *inputStreamReader) Read(p []byte) (n int, err error) {
-	pollable := r.stream.Subscribe()
-	poll.Resolve(pollable)
-	pollable.ResourceDrop()
+	if r.closed {
+		return 0, io.ErrClosedPipe
+	}
+	if r.stream == nil {
+		return 0, io.EOF
+	}
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	n, err = wasiio.ReadContext(r.ctx, *r.stream, p)
+	if err == io.EOF {
+		r.trailerOnce.Do(r.parseTrailers)
+	}
+	return n, err
+}
 
-	readResult := r.stream.Read(uint64(len(p)))
-	if err := readResult.Err(); err != nil {
-		if err.Closed() {
-			r.trailerOnce.Do(r.parseTrailers)
-			return 0, io.EOF
-		}
-		return 0, fmt.Errorf("failed to read from InputStream %s", err.LastOperationFailed().ToDebugString())
+// declareTrailers adds the trailer names announced by the Trailer header to
+// trailers with nil values, as [net/http] does, so handlers can tell which
+// trailers to expect before reading the body.
+func declareTrailers(header, trailers http.Header) {
+	for _, key := range trailerKeys(header) {
+		trailers[key] = nil
 	}
+}
 
-	readList := *readResult.OK()
-	copy(p, readList.Slice())
-	return int(readList.Len()), nil
+// trailerKeys returns the canonical trailer names announced by the Trailer
+// header.
+func trailerKeys(header http.Header) []string {
+	var keys []string
+	for _, v := range header.Values("Trailer") {
+		for key := range strings.SplitSeq(v, ",") {
+			if key = textproto.TrimString(key); key != "" {
+				keys = append(keys, http.CanonicalHeaderKey(key))
+			}
+		}
+	}
+	return keys
 }
 
 // NewIncomingBodyTrailer takes a [BodyConsumer] and parses it into corresponding [io.ReadCloser] and [net/http.Header].
 func NewIncomingBodyTrailer(consumer BodyConsumer) (io.ReadCloser, http.Header, error) {
+	return newIncomingBodyTrailer(context.Background(), consumer)
+}
+
+// newIncomingBodyTrailer is like [NewIncomingBodyTrailer], but reads from
+// the body fail with the context error once ctx is done.
+func newIncomingBodyTrailer(ctx context.Context, consumer BodyConsumer) (io.ReadCloser, http.Header, error) {
 	consumeResult := consumer.Consume()
 	if consumeResult.IsErr() {
 		return nil, nil, errors.New("failed to consume incoming request")
@@ -122,6 +159,7 @@ func NewIncomingBodyTrailer(consumer BodyConsumer) (io.ReadCloser, http.Header,
 
 	trailers := http.Header{}
 	return &inputStreamReader{
+		ctx:      ctx,
 		consumer: consumer,
 		trailers: trailers,
 		body:     body,
@@ -152,23 +190,5 @@ func (r *outgoingBody) Close() error {
 }
 
 func (r *outgoingBody) Write(p []byte) (n int, err error) {
-	// Split the input into 4096-byte chunks to avoid exceeding stream buffer limits
-	const chunkSize = 4096
-	totalWritten := 0
-
-	for offset := 0; offset < len(p); offset += chunkSize {
-		end := min(offset+chunkSize, len(p))
-		chunk := cm.ToList(p[offset:end])
-
-		writeResult := r.stream.BlockingWriteAndFlush(chunk)
-		if err := writeResult.Err(); err != nil {
-			if err.Closed() {
-				return totalWritten, io.EOF
-			}
-			return totalWritten, fmt.Errorf("failed to write to response body's stream: %s", err.LastOperationFailed().ToDebugString())
-		}
-
-		totalWritten += end - offset
-	}
-	return totalWritten, nil
+	return wasiio.Write(*r.stream, p)
 }
@@ -0,0 +1,231 @@
+package wasisocket
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.wasmcloud.dev/component/gen/wasi/sockets/network"
+	"go.wasmcloud.dev/component/gen/wasi/sockets/tcp"
+	tcpcreatesocket "go.wasmcloud.dev/component/gen/wasi/sockets/tcp-create-socket"
+)
+
+// defaultKeepAlive matches the keep-alive period of [net.Dialer].
+const defaultKeepAlive = 15 * time.Second
+
+// Dialer contains options for connecting to an address over wasi:sockets.
+// Its zero value is ready to use, and its DialContext method can be passed
+// to clients accepting a custom dial function.
+type Dialer struct {
+	// Timeout is the maximum amount of time a dial will wait for a connect to
+	// complete, including name resolution. A context deadline may end the
+	// dial sooner. Zero means no timeout.
+	Timeout time.Duration
+
+	// Deadline is the absolute point in time after which dials fail. Zero
+	// means no deadline.
+	Deadline time.Time
+
+	// KeepAlive is the idle time before keep-alive probes are sent. Zero
+	// uses a default of 15 seconds, a negative value disables keep-alives.
+	// Hosts that don't support keep-alives ignore it.
+	KeepAlive time.Duration
+
+	// Resolver optionally specifies the resolver for host names. Nil uses
+	// [DefaultResolver].
+	Resolver *Resolver
+}
+
+// Dial connects to the address on the named network using a zero [Dialer].
+func Dial(network, address string) (net.Conn, error) {
+	var d Dialer
+	return d.Dial(network, address)
+}
+
+// Dial connects to the address on the named network.
+func (d *Dialer) Dial(network, address string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, address)
+}
+
+// DialContext connects to the address on the named network using the
+// provided context. Supported networks are "tcp", "tcp4", "tcp6", "udp",
+// "udp4" and "udp6". UDP connections are connected to the remote address, as
+// with [net.DialUDP].
+//
+// Host names are resolved with wasi:sockets/ip-name-lookup and each address
+// is tried in turn until one connects.
+func (d *Dialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	opError := func(err error) error {
+		return &net.OpError{Op: "dial", Net: network, Err: err}
+	}
+	switch network {
+	case "tcp", "tcp4", "tcp6", "udp", "udp4", "udp6":
+	default:
+		return nil, opError(net.UnknownNetworkError(network))
+	}
+
+	deadline := d.deadline(ctx, time.Now())
+	if !deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+
+	addrs, err := d.resolver().resolveAddrs(ctx, network, address)
+	if err != nil {
+		return nil, opError(err)
+	}
+
+	var firstErr error
+	for _, addr := range addrs {
+		var conn net.Conn
+		var err error
+		if strings.HasPrefix(network, "udp") {
+			conn, err = dialUDP(ctx, addr)
+		} else {
+			conn, err = d.connect(ctx, addr)
+		}
+		if err == nil {
+			return conn, nil
+		}
+		if firstErr == nil {
+			firstErr = &net.OpError{Op: "dial", Net: network, Addr: sockAddr(network, addr), Err: err}
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, firstErr
+}
+
+func (d *Dialer) resolver() *Resolver {
+	if d.Resolver != nil {
+		return d.Resolver
+	}
+	return DefaultResolver
+}
+
+// deadline returns the earliest of now+Timeout, Deadline and the context
+// deadline, or zero if none is set.
+func (d *Dialer) deadline(ctx context.Context, now time.Time) time.Time {
+	var earliest time.Time
+	consider := func(t time.Time) {
+		if !t.IsZero() && (earliest.IsZero() || t.Before(earliest)) {
+			earliest = t
+		}
+	}
+	if d.Timeout > 0 {
+		consider(now.Add(d.Timeout))
+	}
+	consider(d.Deadline)
+	if t, ok := ctx.Deadline(); ok {
+		consider(t)
+	}
+	return earliest
+}
+
+func (d *Dialer) connect(ctx context.Context, addr netip.AddrPort) (*TCPConn, error) {
+	created := tcpcreatesocket.CreateTCPSocket(addressFamily(addr.Addr()))
+	if err := created.Err(); err != nil {
+		return nil, codeError(*err)
+	}
+	socket := *created.OK()
+
+	started := socket.StartConnect(instanceNetwork(), toSocketAddress(addr))
+	if err := started.Err(); err != nil {
+		socket.ResourceDrop()
+		return nil, codeError(*err)
+	}
+
+	ctxDeadline := func() time.Time {
+		t, _ := ctx.Deadline()
+		return t
+	}
+	for {
+		res := socket.FinishConnect()
+		if err := res.Err(); err != nil {
+			if *err != network.ErrorCodeWouldBlock {
+				socket.ResourceDrop()
+				return nil, codeError(*err)
+			}
+			// The pollable is a child of the socket and must be dropped first
+			pollable := socket.Subscribe()
+			err := wait(pollable, ctxDeadline, ctx.Err)
+			pollable.ResourceDrop()
+			if err != nil {
+				socket.ResourceDrop()
+				return nil, err
+			}
+			continue
+		}
+		setKeepAlive(socket, d.KeepAlive)
+		streams := res.OK()
+		return newTCPConn(socket, streams.F0, streams.F1, addr), nil
+	}
+}
+
+// setKeepAlive configures keep-alives as described by [Dialer.KeepAlive],
+// ignoring hosts that don't support them.
+func setKeepAlive(socket tcp.TCPSocket, idle time.Duration) {
+	if idle < 0 {
+		socket.SetKeepAliveEnabled(false)
+		return
+	}
+	if idle == 0 {
+		idle = defaultKeepAlive
+	}
+	socket.SetKeepAliveEnabled(true)
+	socket.SetKeepAliveIdleTime(tcp.Duration(idle))
+}
+
+// resolveAddrs splits address into host and port and resolves the host,
+// keeping only addresses usable on network.
+func (r *Resolver) resolveAddrs(ctx context.Context, network, address string) ([]netip.AddrPort, error) {
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	port, err := parsePort(network, portStr)
+	if err != nil {
+		return nil, err
+	}
+
+	var ips []netip.Addr
+	if ip, err := netip.ParseAddr(host); err == nil {
+		ips = []netip.Addr{ip}
+	} else {
+		if host == "" {
+			host = "localhost"
+		}
+		if ips, err = r.lookupIP(ctx, host); err != nil {
+			return nil, err
+		}
+	}
+
+	addrs := make([]netip.AddrPort, 0, len(ips))
+	for _, ip := range ips {
+		is4 := ip.Is4() || ip.Is4In6()
+		if (strings.HasSuffix(network, "4") && !is4) || (strings.HasSuffix(network, "6") && is4) {
+			continue
+		}
+		addrs = append(addrs, netip.AddrPortFrom(ip, port))
+	}
+	if len(addrs) == 0 {
+		return nil, &net.AddrError{Err: "no suitable address found", Addr: host}
+	}
+	return addrs, nil
+}
+
+func parsePort(network, service string) (uint16, error) {
+	if port, err := strconv.ParseUint(service, 10, 16); err == nil {
+		return uint16(port), nil
+	}
+	port, err := net.LookupPort(network, service)
+	if err != nil {
+		return 0, err
+	}
+	return uint16(port), nil
+}
@@ -0,0 +1,37 @@
+package wasisocket
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDialerDeadline(t *testing.T) {
+	now := time.Date(2024, time.January, 31, 10, 0, 0, 0, time.UTC)
+	ctxWithDeadline := func(d time.Duration) context.Context {
+		ctx, cancel := context.WithDeadline(context.Background(), now.Add(d))
+		t.Cleanup(cancel)
+		return ctx
+	}
+
+	for name, tt := range map[string]struct {
+		dialer Dialer
+		ctx    context.Context
+		want   time.Time
+	}{
+		"none":             {Dialer{}, context.Background(), time.Time{}},
+		"timeout":          {Dialer{Timeout: time.Second}, context.Background(), now.Add(time.Second)},
+		"negative timeout": {Dialer{Timeout: -time.Second}, context.Background(), time.Time{}},
+		"deadline":         {Dialer{Deadline: now.Add(time.Minute)}, context.Background(), now.Add(time.Minute)},
+		"context":          {Dialer{}, ctxWithDeadline(time.Hour), now.Add(time.Hour)},
+		"timeout first":    {Dialer{Timeout: time.Second, Deadline: now.Add(time.Minute)}, ctxWithDeadline(time.Hour), now.Add(time.Second)},
+		"deadline first":   {Dialer{Timeout: time.Hour, Deadline: now.Add(time.Minute)}, ctxWithDeadline(time.Hour), now.Add(time.Minute)},
+		"context first":    {Dialer{Timeout: time.Hour, Deadline: now.Add(time.Minute)}, ctxWithDeadline(time.Second), now.Add(time.Second)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.dialer.deadline(tt.ctx, now); !got.Equal(tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
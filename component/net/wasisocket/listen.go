@@ -0,0 +1,256 @@
+package wasisocket
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.bytecodealliance.org/cm"
+	"go.wasmcloud.dev/component/gen/wasi/io/poll"
+	"go.wasmcloud.dev/component/gen/wasi/sockets/network"
+	"go.wasmcloud.dev/component/gen/wasi/sockets/tcp"
+	tcpcreatesocket "go.wasmcloud.dev/component/gen/wasi/sockets/tcp-create-socket"
+)
+
+// ListenConfig contains options for listening on an address over
+// wasi:sockets.
+type ListenConfig struct {
+	// Backlog is the maximum number of pending connections. Zero uses the
+	// host default.
+	Backlog uint64
+
+	// KeepAlive is applied to accepted connections as in [Dialer].
+	KeepAlive time.Duration
+}
+
+// Listen announces on the local network address using a zero
+// [ListenConfig].
+func Listen(network, address string) (net.Listener, error) {
+	var lc ListenConfig
+	return lc.Listen(context.Background(), network, address)
+}
+
+// Listen announces on the local network address. Only "tcp", "tcp4" and
+// "tcp6" networks are supported. An empty host listens on the unspecified
+// address, IPv6 for "tcp6" and IPv4 otherwise, and port 0 picks a port.
+func (lc *ListenConfig) Listen(ctx context.Context, network, address string) (net.Listener, error) {
+	opError := func(err error) error {
+		return &net.OpError{Op: "listen", Net: network, Err: err}
+	}
+	switch network {
+	case "tcp", "tcp4", "tcp6":
+	default:
+		return nil, opError(net.UnknownNetworkError(network))
+	}
+
+	addr, err := listenAddr(ctx, network, address)
+	if err != nil {
+		return nil, opError(err)
+	}
+	l, err := lc.listen(ctx, addr)
+	if err != nil {
+		return nil, &net.OpError{Op: "listen", Net: network, Addr: tcpAddr(addr), Err: err}
+	}
+	return l, nil
+}
+
+func listenAddr(ctx context.Context, network, address string) (netip.AddrPort, error) {
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return netip.AddrPort{}, err
+	}
+	if host == "" {
+		port, err := parsePort(network, portStr)
+		if err != nil {
+			return netip.AddrPort{}, err
+		}
+		ip := netip.IPv4Unspecified()
+		if strings.HasSuffix(network, "6") {
+			ip = netip.IPv6Unspecified()
+		}
+		return netip.AddrPortFrom(ip, port), nil
+	}
+	addrs, err := DefaultResolver.resolveAddrs(ctx, network, address)
+	if err != nil {
+		return netip.AddrPort{}, err
+	}
+	return addrs[0], nil
+}
+
+func (lc *ListenConfig) listen(ctx context.Context, addr netip.AddrPort) (*TCPListener, error) {
+	created := tcpcreatesocket.CreateTCPSocket(addressFamily(addr.Addr()))
+	if err := created.Err(); err != nil {
+		return nil, codeError(*err)
+	}
+	socket := *created.OK()
+
+	fail := func(err error) (*TCPListener, error) {
+		socket.ResourceDrop()
+		return nil, err
+	}
+	ctxDeadline := func() time.Time {
+		t, _ := ctx.Deadline()
+		return t
+	}
+
+	bound := socket.StartBind(instanceNetwork(), toSocketAddress(addr))
+	if err := bound.Err(); err != nil {
+		return fail(codeError(*err))
+	}
+	if err := finish(socket.Subscribe, socket.FinishBind, ctxDeadline, ctx.Err); err != nil {
+		return fail(err)
+	}
+
+	if lc.Backlog > 0 {
+		// Not all hosts allow tuning the backlog, it is only a hint
+		socket.SetListenBacklogSize(lc.Backlog)
+	}
+	listening := socket.StartListen()
+	if err := listening.Err(); err != nil {
+		return fail(codeError(*err))
+	}
+	if err := finish(socket.Subscribe, socket.FinishListen, ctxDeadline, ctx.Err); err != nil {
+		return fail(err)
+	}
+
+	l := &TCPListener{
+		socket:    socket,
+		keepAlive: lc.KeepAlive,
+		addr:      tcpAddr(addr),
+	}
+	local := socket.LocalAddress()
+	if bound := local.OK(); bound != nil {
+		l.addr = tcpAddr(fromSocketAddress(*bound))
+	}
+	return l, nil
+}
+
+// finish completes a two-phase socket operation, waiting on the socket
+// pollable while the host reports it would block.
+func finish(subscribe func() poll.Pollable, fn func() cm.Result[network.ErrorCode, struct{}, network.ErrorCode], deadline func() time.Time, canceled func() error) error {
+	for {
+		res := fn()
+		err := res.Err()
+		if err == nil {
+			return nil
+		}
+		if *err != network.ErrorCodeWouldBlock {
+			return codeError(*err)
+		}
+		pollable := subscribe()
+		werr := wait(pollable, deadline, canceled)
+		pollable.ResourceDrop()
+		if werr != nil {
+			return werr
+		}
+	}
+}
+
+// TCPListener is a [net.Listener] over a listening wasi:sockets TCP socket.
+//
+// Accept waits on the socket pollable without blocking other goroutines, so
+// accepted connections can be served concurrently. Close unblocks a pending
+// Accept.
+type TCPListener struct {
+	socket    tcp.TCPSocket
+	keepAlive time.Duration
+	addr      net.Addr
+
+	acceptMu sync.Mutex
+	deadline atomic.Int64
+	closed   atomic.Bool
+}
+
+var _ net.Listener = (*TCPListener)(nil)
+
+// Accept waits for and returns the next connection.
+func (l *TCPListener) Accept() (net.Conn, error) {
+	return l.AcceptTCP()
+}
+
+// AcceptTCP waits for and returns the next connection as a [TCPConn].
+func (l *TCPListener) AcceptTCP() (*TCPConn, error) {
+	l.acceptMu.Lock()
+	defer l.acceptMu.Unlock()
+
+	for {
+		if l.closed.Load() {
+			return nil, l.opError(net.ErrClosed)
+		}
+		res := l.socket.Accept()
+		if err := res.Err(); err != nil {
+			if *err != network.ErrorCodeWouldBlock {
+				return nil, l.opError(codeError(*err))
+			}
+			pollable := l.socket.Subscribe()
+			werr := wait(pollable, l.deadlineTime, l.closedErr)
+			pollable.ResourceDrop()
+			if werr != nil {
+				return nil, l.opError(werr)
+			}
+			continue
+		}
+
+		accepted := res.OK()
+		socket := accepted.F0
+		setKeepAlive(socket, l.keepAlive)
+		var raddr netip.AddrPort
+		remote := socket.RemoteAddress()
+		if addr := remote.OK(); addr != nil {
+			raddr = fromSocketAddress(*addr)
+		}
+		return newTCPConn(socket, accepted.F1, accepted.F2, raddr), nil
+	}
+}
+
+// Close stops listening. Already accepted connections are not closed.
+func (l *TCPListener) Close() error {
+	if l.closed.Swap(true) {
+		return l.opError(net.ErrClosed)
+	}
+	// Wait for a pending Accept to notice the close before dropping
+	l.acceptMu.Lock()
+	defer l.acceptMu.Unlock()
+	l.socket.ResourceDrop()
+	return nil
+}
+
+// Addr returns the listener's network address.
+func (l *TCPListener) Addr() net.Addr {
+	return l.addr
+}
+
+// SetDeadline sets the deadline for Accept. A zero value disables it.
+func (l *TCPListener) SetDeadline(t time.Time) error {
+	if l.closed.Load() {
+		return l.opError(net.ErrClosed)
+	}
+	if t.IsZero() {
+		l.deadline.Store(0)
+	} else {
+		l.deadline.Store(t.UnixNano())
+	}
+	return nil
+}
+
+func (l *TCPListener) deadlineTime() time.Time {
+	if ns := l.deadline.Load(); ns != 0 {
+		return time.Unix(0, ns)
+	}
+	return time.Time{}
+}
+
+func (l *TCPListener) closedErr() error {
+	if l.closed.Load() {
+		return net.ErrClosed
+	}
+	return nil
+}
+
+func (l *TCPListener) opError(err error) error {
+	return &net.OpError{Op: "accept", Net: "tcp", Addr: l.addr, Err: err}
+}
@@ -0,0 +1,44 @@
+package wasisocket
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+)
+
+func TestListenAddr(t *testing.T) {
+	for _, tt := range []struct {
+		network, address string
+		want             string
+	}{
+		{"tcp", ":8080", "0.0.0.0:8080"},
+		{"tcp4", ":0", "0.0.0.0:0"},
+		{"tcp6", ":http", "[::]:80"},
+		{"tcp", "127.0.0.1:9000", "127.0.0.1:9000"},
+		{"tcp6", "[::1]:9000", "[::1]:9000"},
+		{"tcp", "localhost:80", "[::1]:80"},
+		{"tcp4", "localhost:80", "127.0.0.1:80"},
+	} {
+		got, err := listenAddr(context.Background(), tt.network, tt.address)
+		if err != nil {
+			t.Errorf("listenAddr(%q, %q): unexpected error %v", tt.network, tt.address, err)
+			continue
+		}
+		if want := netip.MustParseAddrPort(tt.want); got != want {
+			t.Errorf("listenAddr(%q, %q) = %s, want %s", tt.network, tt.address, got, want)
+		}
+	}
+
+	for _, tt := range []struct{ network, address string }{
+		{"tcp", "8080"},
+		{"tcp", ":nosuchservice"},
+		{"tcp4", "[::1]:80"},
+		{"tcp6", "127.0.0.1:80"},
+		{"tcp", "nosuchhost.test:80"},
+		{"tcp4", "v6only.test:80"},
+	} {
+		if _, err := listenAddr(context.Background(), tt.network, tt.address); err == nil {
+			t.Errorf("listenAddr(%q, %q): expected an error", tt.network, tt.address)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package wasisocket
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"os"
+	"time"
+
+	ipnamelookup "go.wasmcloud.dev/component/gen/wasi/sockets/ip-name-lookup"
+	"go.wasmcloud.dev/component/gen/wasi/sockets/network"
+)
+
+// DefaultResolver is the resolver used by the package-level Lookup functions
+// and by a [Dialer] without a Resolver.
+var DefaultResolver = &Resolver{}
+
+// Resolver looks up host names with wasi:sockets/ip-name-lookup. Its zero
+// value is ready to use.
+//
+// Lookups wait on the host without blocking other goroutines and honor
+// context cancellation and deadlines. Errors are reported as
+// [*net.DNSError].
+type Resolver struct{}
+
+// LookupHost looks up host using [DefaultResolver].
+func LookupHost(ctx context.Context, host string) ([]string, error) {
+	return DefaultResolver.LookupHost(ctx, host)
+}
+
+// LookupIPAddr looks up host using [DefaultResolver].
+func LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	return DefaultResolver.LookupIPAddr(ctx, host)
+}
+
+// LookupHost looks up host, returning a slice of its addresses.
+func (r *Resolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	ips, err := r.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]string, len(ips))
+	for i, ip := range ips {
+		addrs[i] = ip.String()
+	}
+	return addrs, nil
+}
+
+// LookupIPAddr looks up host, returning its IPv4 and IPv6 addresses.
+func (r *Resolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	ips, err := r.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]net.IPAddr, len(ips))
+	for i, ip := range ips {
+		addrs[i] = net.IPAddr{IP: ip.AsSlice(), Zone: ip.Zone()}
+	}
+	return addrs, nil
+}
+
+// LookupNetIP looks up host, returning only addresses of the given network,
+// which must be "ip", "ip4" or "ip6".
+func (r *Resolver) LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error) {
+	var want4, want6 bool
+	switch network {
+	case "ip":
+		want4, want6 = true, true
+	case "ip4":
+		want4 = true
+	case "ip6":
+		want6 = true
+	default:
+		return nil, net.UnknownNetworkError(network)
+	}
+
+	var ips []netip.Addr
+	if ip, err := netip.ParseAddr(host); err == nil {
+		ips = []netip.Addr{ip}
+	} else if ips, err = r.lookupIP(ctx, host); err != nil {
+		return nil, err
+	}
+
+	filtered := ips[:0]
+	for _, ip := range ips {
+		if is4 := ip.Is4() || ip.Is4In6(); (is4 && want4) || (!is4 && want6) {
+			filtered = append(filtered, ip)
+		}
+	}
+	if len(filtered) == 0 {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return filtered, nil
+}
+
+// lookupIP resolves host with wasi:sockets/ip-name-lookup.
+func (r *Resolver) lookupIP(ctx context.Context, host string) ([]netip.Addr, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, ctxDNSError(host, err)
+	}
+
+	res := ipnamelookup.ResolveAddresses(instanceNetwork(), host)
+	if err := res.Err(); err != nil {
+		return nil, codeDNSError(host, *err)
+	}
+	stream := *res.OK()
+	defer stream.ResourceDrop()
+
+	ctxDeadline := func() time.Time {
+		t, _ := ctx.Deadline()
+		return t
+	}
+	var ips []netip.Addr
+	for {
+		next := stream.ResolveNextAddress()
+		if err := next.Err(); err != nil {
+			if *err != network.ErrorCodeWouldBlock {
+				return nil, codeDNSError(host, *err)
+			}
+			// The pollable is a child of the stream and must be dropped first
+			pollable := stream.Subscribe()
+			err := wait(pollable, ctxDeadline, ctx.Err)
+			pollable.ResourceDrop()
+			if err != nil {
+				return nil, ctxDNSError(host, err)
+			}
+			continue
+		}
+		addr := next.OK()
+		if addr.None() {
+			return ips, nil
+		}
+		ips = append(ips, fromIPAddress(*addr.Some()))
+	}
+}
+
+func codeDNSError(host string, code network.ErrorCode) error {
+	err := &net.DNSError{Err: code.String(), Name: host}
+	switch code {
+	case network.ErrorCodeNameUnresolvable:
+		err.Err = "no such host"
+		err.IsNotFound = true
+	case network.ErrorCodeTemporaryResolverFailure:
+		err.IsTemporary = true
+	case network.ErrorCodeTimeout:
+		err.IsTimeout = true
+	}
+	return err
+}
+
+// ctxDNSError wraps a cancellation or deadline error from waiting on the
+// host, keeping the cause available to [errors.Is].
+func ctxDNSError(host string, err error) error {
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		err = context.DeadlineExceeded
+	}
+	return &net.DNSError{
+		Err:       err.Error(),
+		Name:      host,
+		IsTimeout: errors.Is(err, context.DeadlineExceeded),
+		UnwrapErr: err,
+	}
+}
@@ -0,0 +1,144 @@
+package wasisocket
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"os"
+	"slices"
+	"testing"
+
+	"go.wasmcloud.dev/component/gen/wasi/sockets/network"
+)
+
+func TestResolveAddrs(t *testing.T) {
+	for _, tt := range []struct {
+		network, address string
+		want             []string
+	}{
+		{"tcp", "example.test:80", []string{"192.0.2.1:80", "[2001:db8::1]:80", "192.0.2.2:80"}},
+		{"tcp4", "example.test:80", []string{"192.0.2.1:80", "192.0.2.2:80"}},
+		{"tcp6", "example.test:80", []string{"[2001:db8::1]:80"}},
+		{"udp4", "example.test:domain", []string{"192.0.2.1:53", "192.0.2.2:53"}},
+		{"tcp", ":80", []string{"[::1]:80", "127.0.0.1:80"}},
+		{"tcp4", "[::ffff:10.0.0.1]:80", []string{"[::ffff:10.0.0.1]:80"}},
+		{"tcp", "192.0.2.9:443", []string{"192.0.2.9:443"}},
+	} {
+		addrs, err := DefaultResolver.resolveAddrs(context.Background(), tt.network, tt.address)
+		if err != nil {
+			t.Errorf("resolveAddrs(%q, %q): unexpected error %v", tt.network, tt.address, err)
+			continue
+		}
+		got := make([]string, len(addrs))
+		for i, addr := range addrs {
+			got[i] = addr.String()
+		}
+		if !slices.Equal(tt.want, got) {
+			t.Errorf("resolveAddrs(%q, %q) = %v, want %v", tt.network, tt.address, got, tt.want)
+		}
+	}
+}
+
+func TestResolveAddrsErrors(t *testing.T) {
+	var addrErr *net.AddrError
+	if _, err := DefaultResolver.resolveAddrs(context.Background(), "tcp4", "v6only.test:80"); !errors.As(err, &addrErr) {
+		t.Errorf("expected *net.AddrError without an IPv4 address, got %v", err)
+	}
+	if _, err := DefaultResolver.resolveAddrs(context.Background(), "tcp6", "192.0.2.1:80"); !errors.As(err, &addrErr) {
+		t.Errorf("expected *net.AddrError for an IPv4 literal on tcp6, got %v", err)
+	}
+
+	var dnsErr *net.DNSError
+	_, err := DefaultResolver.resolveAddrs(context.Background(), "tcp", "nosuchhost.test:80")
+	if !errors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+		t.Errorf("expected a not found *net.DNSError, got %v", err)
+	}
+	if _, err := DefaultResolver.resolveAddrs(context.Background(), "tcp", "example.test"); err == nil {
+		t.Error("expected an error for an address without a port")
+	}
+}
+
+func TestLookupNetIP(t *testing.T) {
+	for _, tt := range []struct {
+		network string
+		want    []string
+	}{
+		{"ip", []string{"192.0.2.1", "2001:db8::1", "192.0.2.2"}},
+		{"ip4", []string{"192.0.2.1", "192.0.2.2"}},
+		{"ip6", []string{"2001:db8::1"}},
+	} {
+		ips, err := DefaultResolver.LookupNetIP(context.Background(), tt.network, "example.test")
+		if err != nil {
+			t.Errorf("LookupNetIP(%q): unexpected error %v", tt.network, err)
+			continue
+		}
+		want := make([]netip.Addr, len(tt.want))
+		for i, s := range tt.want {
+			want[i] = netip.MustParseAddr(s)
+		}
+		if !slices.Equal(want, ips) {
+			t.Errorf("LookupNetIP(%q) = %v, want %v", tt.network, ips, want)
+		}
+	}
+
+	var dnsErr *net.DNSError
+	if _, err := DefaultResolver.LookupNetIP(context.Background(), "ip4", "v6only.test"); !errors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+		t.Errorf("expected a not found *net.DNSError, got %v", err)
+	}
+	var unknown net.UnknownNetworkError
+	if _, err := DefaultResolver.LookupNetIP(context.Background(), "tcp", "example.test"); !errors.As(err, &unknown) {
+		t.Errorf("expected net.UnknownNetworkError, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := DefaultResolver.LookupNetIP(ctx, "ip", "example.test"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestCodeDNSError(t *testing.T) {
+	for _, tt := range []struct {
+		code                         network.ErrorCode
+		msg                          string
+		notFound, temporary, timeout bool
+	}{
+		{network.ErrorCodeNameUnresolvable, "no such host", true, false, false},
+		{network.ErrorCodeTemporaryResolverFailure, "temporary-resolver-failure", false, true, false},
+		{network.ErrorCodeTimeout, "timeout", false, true, true},
+		{network.ErrorCodePermanentResolverFailure, "permanent-resolver-failure", false, false, false},
+	} {
+		var err *net.DNSError
+		if !errors.As(codeDNSError("example.test", tt.code), &err) {
+			t.Fatalf("expected *net.DNSError for %v", tt.code)
+		}
+		if err.Name != "example.test" || err.Err != tt.msg {
+			t.Errorf("%v: unexpected error %v", tt.code, err)
+		}
+		if err.IsNotFound != tt.notFound || err.Temporary() != tt.temporary || err.Timeout() != tt.timeout {
+			t.Errorf("%v: expected not found %v, temporary %v, timeout %v, got %+v", tt.code, tt.notFound, tt.temporary, tt.timeout, err)
+		}
+	}
+}
+
+func TestCtxDNSError(t *testing.T) {
+	for _, tt := range []struct {
+		err     error
+		want    error
+		timeout bool
+	}{
+		{context.Canceled, context.Canceled, false},
+		{context.DeadlineExceeded, context.DeadlineExceeded, true},
+		{os.ErrDeadlineExceeded, context.DeadlineExceeded, true},
+	} {
+		err := ctxDNSError("example.test", tt.err)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%v: expected errors.Is(%v)", tt.err, tt.want)
+		}
+		var dnsErr *net.DNSError
+		if !errors.As(err, &dnsErr) || dnsErr.Timeout() != tt.timeout {
+			t.Errorf("%v: expected *net.DNSError with Timeout() = %v, got %v", tt.err, tt.timeout, err)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package wasisocket
+
+import (
+	"unsafe"
+
+	"go.bytecodealliance.org/cm"
+	"go.wasmcloud.dev/component/gen/wasi/io/poll"
+	ipnamelookup "go.wasmcloud.dev/component/gen/wasi/sockets/ip-name-lookup"
+	"go.wasmcloud.dev/component/gen/wasi/sockets/network"
+)
+
+// testHosts backs the stubbed wasi:sockets/ip-name-lookup imports below.
+// Looking up any other name fails with name-unresolvable.
+var testHosts = map[string][]network.IPAddress{
+	"localhost": {
+		network.IPAddressIPv6(network.IPv6Address{0, 0, 0, 0, 0, 0, 0, 1}),
+		network.IPAddressIPv4(network.IPv4Address{127, 0, 0, 1}),
+	},
+	"example.test": {
+		network.IPAddressIPv4(network.IPv4Address{192, 0, 2, 1}),
+		network.IPAddressIPv6(network.IPv6Address{0x2001, 0xdb8, 0, 0, 0, 0, 0, 1}),
+		network.IPAddressIPv4(network.IPv4Address{192, 0, 2, 2}),
+	},
+	"v6only.test": {
+		network.IPAddressIPv6(network.IPv6Address{0x2001, 0xdb8, 0, 0, 0, 0, 0, 2}),
+	},
+}
+
+// testStream is the state of the last stream opened by ResolveAddresses.
+// Its first read would block, so lookups go through the wait path.
+var testStream struct {
+	addrs   []network.IPAddress
+	found   bool
+	blocked bool
+}
+
+// stub wasi:sockets/instance-network, referenced by the network handle
+//
+//go:linkname wasmimport_InstanceNetwork go.wasmcloud.dev/component/gen/wasi/sockets/instance-network.wasmimport_InstanceNetwork
+func wasmimport_InstanceNetwork() (result0 uint32) {
+	return 0
+}
+
+// stub wasi:sockets/ip-name-lookup
+//
+//go:linkname wasmimport_ResolveAddresses go.wasmcloud.dev/component/gen/wasi/sockets/ip-name-lookup.wasmimport_ResolveAddresses
+func wasmimport_ResolveAddresses(network0 uint32, name0 *uint8, name1 uint32, result *cm.Result[ipnamelookup.ResolveAddressStream, ipnamelookup.ResolveAddressStream, network.ErrorCode]) {
+	addrs, ok := testHosts[unsafe.String(name0, name1)]
+	testStream.addrs, testStream.found, testStream.blocked = addrs, ok, false
+	*result = cm.OK[cm.Result[ipnamelookup.ResolveAddressStream, ipnamelookup.ResolveAddressStream, network.ErrorCode]](ipnamelookup.ResolveAddressStream(1))
+}
+
+//go:linkname wasmimport_ResolveAddressStreamResolveNextAddress go.wasmcloud.dev/component/gen/wasi/sockets/ip-name-lookup.wasmimport_ResolveAddressStreamResolveNextAddress
+func wasmimport_ResolveAddressStreamResolveNextAddress(self0 uint32, result *cm.Result[ipnamelookup.OptionIPAddressShape, cm.Option[network.IPAddress], network.ErrorCode]) {
+	type R = cm.Result[ipnamelookup.OptionIPAddressShape, cm.Option[network.IPAddress], network.ErrorCode]
+	switch {
+	case !testStream.blocked:
+		testStream.blocked = true
+		*result = cm.Err[R](network.ErrorCodeWouldBlock)
+	case !testStream.found:
+		*result = cm.Err[R](network.ErrorCodeNameUnresolvable)
+	case len(testStream.addrs) == 0:
+		*result = cm.OK[R](cm.None[network.IPAddress]())
+	default:
+		*result = cm.OK[R](cm.Some(testStream.addrs[0]))
+		testStream.addrs = testStream.addrs[1:]
+	}
+}
+
+//go:linkname wasmimport_ResolveAddressStreamSubscribe go.wasmcloud.dev/component/gen/wasi/sockets/ip-name-lookup.wasmimport_ResolveAddressStreamSubscribe
+func wasmimport_ResolveAddressStreamSubscribe(self0 uint32) (result0 uint32) {
+	return 2
+}
+
+//go:linkname wasmimport_ResolveAddressStreamResourceDrop go.wasmcloud.dev/component/gen/wasi/sockets/ip-name-lookup.wasmimport_ResolveAddressStreamResourceDrop
+func wasmimport_ResolveAddressStreamResourceDrop(self0 uint32) {}
+
+// stub wasi:io/poll, with every pollable always ready
+//
+//go:linkname wasmimport_PollableReady go.wasmcloud.dev/component/gen/wasi/io/poll.wasmimport_PollableReady
+func wasmimport_PollableReady(self0 uint32) (result0 uint32) {
+	return 1
+}
+
+//go:linkname wasmimport_PollableResourceDrop go.wasmcloud.dev/component/gen/wasi/io/poll.wasmimport_PollableResourceDrop
+func wasmimport_PollableResourceDrop(self0 uint32) {}
+
+//go:linkname wasmimport_Poll go.wasmcloud.dev/component/gen/wasi/io/poll.wasmimport_Poll
+func wasmimport_Poll(in0 *poll.Pollable, in1 uint32, result *cm.List[uint32]) {
+	ready := make([]uint32, in1)
+	for i := range ready {
+		ready[i] = uint32(i)
+	}
+	*result = cm.ToList(ready)
+}
+
+// stub wasi:clocks/monotonic-clock
+//
+//go:linkname wasmimport_SubscribeDuration go.wasmcloud.dev/component/gen/wasi/clocks/monotonic-clock.wasmimport_SubscribeDuration
+func wasmimport_SubscribeDuration(when0 uint64) (result0 uint32) {
+	return 3
+}
@@ -0,0 +1,215 @@
+package wasisocket
+
+import (
+	"io"
+	"net"
+	"net/netip"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.bytecodealliance.org/cm"
+	"go.wasmcloud.dev/component/gen/wasi/io/streams"
+	"go.wasmcloud.dev/component/gen/wasi/sockets/tcp"
+)
+
+// TCPConn is a [net.Conn] over a connected wasi:sockets TCP socket.
+//
+// Reads and writes wait on the stream pollables, honoring deadlines. Close
+// may be called while other goroutines are blocked reading or writing.
+type TCPConn struct {
+	socket tcp.TCPSocket
+	in     streams.InputStream
+	out    streams.OutputStream
+	laddr  net.Addr
+	raddr  net.Addr
+
+	readMu  sync.Mutex
+	writeMu sync.Mutex
+
+	readDeadline  atomic.Int64
+	writeDeadline atomic.Int64
+	closed        atomic.Bool
+}
+
+var _ net.Conn = (*TCPConn)(nil)
+
+func newTCPConn(socket tcp.TCPSocket, in streams.InputStream, out streams.OutputStream, raddr netip.AddrPort) *TCPConn {
+	c := &TCPConn{
+		socket: socket,
+		in:     in,
+		out:    out,
+		raddr:  tcpAddr(raddr),
+	}
+	local := socket.LocalAddress()
+	if addr := local.OK(); addr != nil {
+		c.laddr = tcpAddr(fromSocketAddress(*addr))
+	}
+	return c
+}
+
+func (c *TCPConn) Read(p []byte) (int, error) {
+	c.readMu.Lock()
+	defer c.readMu.Unlock()
+	if c.closed.Load() {
+		return 0, c.opError("read", net.ErrClosed)
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	for {
+		res := c.in.Read(uint64(len(p)))
+		if err := res.Err(); err != nil {
+			if err.Closed() {
+				return 0, io.EOF
+			}
+			return 0, c.opError("read", streamError(err))
+		}
+		if data := res.OK(); data.Len() > 0 {
+			return copy(p, data.Slice()), nil
+		}
+
+		pollable := c.in.Subscribe()
+		err := wait(pollable, c.deadlineFunc(&c.readDeadline), c.closedErr)
+		pollable.ResourceDrop()
+		if err != nil {
+			return 0, c.opError("read", err)
+		}
+	}
+}
+
+func (c *TCPConn) Write(p []byte) (int, error) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if c.closed.Load() {
+		return 0, c.opError("write", net.ErrClosed)
+	}
+
+	written := 0
+	for written < len(p) {
+		res := c.out.CheckWrite()
+		if err := res.Err(); err != nil {
+			return written, c.opError("write", streamError(err))
+		}
+		if n := *res.OK(); n > 0 {
+			chunk := p[written:]
+			if uint64(len(chunk)) > n {
+				chunk = chunk[:n]
+			}
+			wres := c.out.Write(cm.ToList(chunk))
+			if err := wres.Err(); err != nil {
+				return written, c.opError("write", streamError(err))
+			}
+			written += len(chunk)
+			continue
+		}
+
+		pollable := c.out.Subscribe()
+		err := wait(pollable, c.deadlineFunc(&c.writeDeadline), c.closedErr)
+		pollable.ResourceDrop()
+		if err != nil {
+			return written, c.opError("write", err)
+		}
+	}
+
+	// Ask the host to send buffered data, failures surface on the next write
+	flushed := c.out.Flush()
+	if err := flushed.Err(); err != nil {
+		return written, c.opError("write", streamError(err))
+	}
+	return written, nil
+}
+
+// Close closes the connection, unblocking pending reads and writes.
+func (c *TCPConn) Close() error {
+	if c.closed.Swap(true) {
+		return c.opError("close", net.ErrClosed)
+	}
+	// Wait for blocked operations to notice the close before dropping the
+	// streams, which are children of the socket and must go first.
+	c.readMu.Lock()
+	c.writeMu.Lock()
+	defer c.readMu.Unlock()
+	defer c.writeMu.Unlock()
+
+	c.in.ResourceDrop()
+	c.out.ResourceDrop()
+	c.socket.ResourceDrop()
+	return nil
+}
+
+// CloseRead shuts down the reading side of the connection.
+func (c *TCPConn) CloseRead() error {
+	return c.shutdown("close", tcp.ShutdownTypeReceive)
+}
+
+// CloseWrite shuts down the writing side of the connection.
+func (c *TCPConn) CloseWrite() error {
+	return c.shutdown("close", tcp.ShutdownTypeSend)
+}
+
+func (c *TCPConn) shutdown(op string, how tcp.ShutdownType) error {
+	if c.closed.Load() {
+		return c.opError(op, net.ErrClosed)
+	}
+	res := c.socket.Shutdown(how)
+	if err := res.Err(); err != nil {
+		return c.opError(op, codeError(*err))
+	}
+	return nil
+}
+
+// LocalAddr returns the local address, or nil if the host didn't report it.
+func (c *TCPConn) LocalAddr() net.Addr {
+	return c.laddr
+}
+
+func (c *TCPConn) RemoteAddr() net.Addr {
+	return c.raddr
+}
+
+func (c *TCPConn) SetDeadline(t time.Time) error {
+	c.SetReadDeadline(t)
+	return c.SetWriteDeadline(t)
+}
+
+func (c *TCPConn) SetReadDeadline(t time.Time) error {
+	return c.setDeadline(&c.readDeadline, t)
+}
+
+func (c *TCPConn) SetWriteDeadline(t time.Time) error {
+	return c.setDeadline(&c.writeDeadline, t)
+}
+
+func (c *TCPConn) setDeadline(d *atomic.Int64, t time.Time) error {
+	if c.closed.Load() {
+		return c.opError("set", net.ErrClosed)
+	}
+	if t.IsZero() {
+		d.Store(0)
+	} else {
+		d.Store(t.UnixNano())
+	}
+	return nil
+}
+
+func (c *TCPConn) deadlineFunc(d *atomic.Int64) func() time.Time {
+	return func() time.Time {
+		if ns := d.Load(); ns != 0 {
+			return time.Unix(0, ns)
+		}
+		return time.Time{}
+	}
+}
+
+func (c *TCPConn) closedErr() error {
+	if c.closed.Load() {
+		return net.ErrClosed
+	}
+	return nil
+}
+
+func (c *TCPConn) opError(op string, err error) error {
+	return &net.OpError{Op: op, Net: "tcp", Source: c.laddr, Addr: c.raddr, Err: err}
+}
@@ -0,0 +1,309 @@
+package wasisocket
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.bytecodealliance.org/cm"
+	"go.wasmcloud.dev/component/gen/wasi/sockets/network"
+	"go.wasmcloud.dev/component/gen/wasi/sockets/udp"
+	udpcreatesocket "go.wasmcloud.dev/component/gen/wasi/sockets/udp-create-socket"
+)
+
+// ErrWriteToConnected is returned by [UDPConn.WriteTo] on a connected socket
+// when the destination address is set.
+var ErrWriteToConnected = errors.New("use of WriteTo with pre-connected connection")
+
+// ListenPacket announces on the local network address using a zero
+// [ListenConfig].
+func ListenPacket(network, address string) (net.PacketConn, error) {
+	var lc ListenConfig
+	return lc.ListenPacket(context.Background(), network, address)
+}
+
+// ListenPacket announces on the local network address. Only "udp", "udp4"
+// and "udp6" networks are supported. An empty host listens on the
+// unspecified address, IPv6 for "udp6" and IPv4 otherwise, and port 0 picks
+// a port.
+func (lc *ListenConfig) ListenPacket(ctx context.Context, network, address string) (net.PacketConn, error) {
+	opError := func(err error) error {
+		return &net.OpError{Op: "listen", Net: network, Err: err}
+	}
+	switch network {
+	case "udp", "udp4", "udp6":
+	default:
+		return nil, opError(net.UnknownNetworkError(network))
+	}
+
+	addr, err := listenAddr(ctx, network, address)
+	if err != nil {
+		return nil, opError(err)
+	}
+	conn, err := newUDPConn(ctx, addr, netip.AddrPort{})
+	if err != nil {
+		return nil, &net.OpError{Op: "listen", Net: network, Addr: udpAddr(addr), Err: err}
+	}
+	return conn, nil
+}
+
+// dialUDP returns a UDP socket bound to an ephemeral port and connected to
+// raddr.
+func dialUDP(ctx context.Context, raddr netip.AddrPort) (*UDPConn, error) {
+	laddr := netip.AddrPortFrom(netip.IPv4Unspecified(), 0)
+	if addressFamily(raddr.Addr()) == network.IPAddressFamilyIPv6 {
+		laddr = netip.AddrPortFrom(netip.IPv6Unspecified(), 0)
+	}
+	return newUDPConn(ctx, laddr, raddr)
+}
+
+// newUDPConn binds a socket to laddr and opens its datagram streams,
+// connected to raddr if it is valid.
+func newUDPConn(ctx context.Context, laddr, raddr netip.AddrPort) (*UDPConn, error) {
+	created := udpcreatesocket.CreateUDPSocket(addressFamily(laddr.Addr()))
+	if err := created.Err(); err != nil {
+		return nil, codeError(*err)
+	}
+	socket := *created.OK()
+	ctxDeadline := func() time.Time {
+		t, _ := ctx.Deadline()
+		return t
+	}
+
+	bound := socket.StartBind(instanceNetwork(), toSocketAddress(laddr))
+	if err := bound.Err(); err != nil {
+		socket.ResourceDrop()
+		return nil, codeError(*err)
+	}
+	if err := finish(socket.Subscribe, socket.FinishBind, ctxDeadline, ctx.Err); err != nil {
+		socket.ResourceDrop()
+		return nil, err
+	}
+
+	remote := cm.None[network.IPSocketAddress]()
+	if raddr.IsValid() {
+		remote = cm.Some(toSocketAddress(raddr))
+	}
+	streamed := socket.Stream(remote)
+	if err := streamed.Err(); err != nil {
+		socket.ResourceDrop()
+		return nil, codeError(*err)
+	}
+	s := streamed.OK()
+
+	c := &UDPConn{
+		socket: socket,
+		in:     s.F0,
+		out:    s.F1,
+		laddr:  udpAddr(laddr),
+	}
+	if raddr.IsValid() {
+		c.raddr = udpAddr(raddr)
+	}
+	local := socket.LocalAddress()
+	if addr := local.OK(); addr != nil {
+		c.laddr = udpAddr(fromSocketAddress(*addr))
+	}
+	return c, nil
+}
+
+// UDPConn is a [net.PacketConn] over a wasi:sockets UDP socket. Sockets
+// returned by [Dialer.DialContext] are connected, and also implement
+// [net.Conn].
+//
+// Datagrams longer than the buffer passed to a read are truncated.
+type UDPConn struct {
+	socket udp.UDPSocket
+	in     udp.IncomingDatagramStream
+	out    udp.OutgoingDatagramStream
+	laddr  net.Addr
+	raddr  net.Addr
+
+	readMu  sync.Mutex
+	writeMu sync.Mutex
+
+	readDeadline  atomic.Int64
+	writeDeadline atomic.Int64
+	closed        atomic.Bool
+}
+
+var (
+	_ net.PacketConn = (*UDPConn)(nil)
+	_ net.Conn       = (*UDPConn)(nil)
+)
+
+// ReadFrom reads a datagram, returning its size and sender.
+func (c *UDPConn) ReadFrom(p []byte) (int, net.Addr, error) {
+	c.readMu.Lock()
+	defer c.readMu.Unlock()
+	if c.closed.Load() {
+		return 0, nil, c.opError("read", nil, net.ErrClosed)
+	}
+
+	for {
+		res := c.in.Receive(1)
+		if err := res.Err(); err != nil {
+			return 0, nil, c.opError("read", nil, codeError(*err))
+		}
+		if datagrams := res.OK().Slice(); len(datagrams) > 0 {
+			d := datagrams[0]
+			return copy(p, d.Data.Slice()), udpAddr(fromSocketAddress(d.RemoteAddress)), nil
+		}
+
+		pollable := c.in.Subscribe()
+		err := wait(pollable, c.deadlineFunc(&c.readDeadline), c.closedErr)
+		pollable.ResourceDrop()
+		if err != nil {
+			return 0, nil, c.opError("read", nil, err)
+		}
+	}
+}
+
+// Read reads a datagram from a connected socket.
+func (c *UDPConn) Read(p []byte) (int, error) {
+	n, _, err := c.ReadFrom(p)
+	return n, err
+}
+
+// WriteTo sends p as a single datagram to addr. On a connected socket addr
+// must be nil, use [UDPConn.Write] instead.
+func (c *UDPConn) WriteTo(p []byte, addr net.Addr) (int, error) {
+	if c.raddr != nil {
+		if addr != nil {
+			return 0, c.opError("write", addr, ErrWriteToConnected)
+		}
+		return c.send(p, cm.None[network.IPSocketAddress](), c.raddr)
+	}
+	if addr == nil {
+		return 0, c.opError("write", nil, errors.New("missing address"))
+	}
+	dest, err := addrPort(addr)
+	if err != nil {
+		return 0, c.opError("write", addr, err)
+	}
+	return c.send(p, cm.Some(toSocketAddress(dest)), addr)
+}
+
+// addrPort converts a destination address passed to WriteTo.
+func addrPort(addr net.Addr) (netip.AddrPort, error) {
+	if a, ok := addr.(*net.UDPAddr); ok {
+		ap := a.AddrPort()
+		return netip.AddrPortFrom(ap.Addr().Unmap(), ap.Port()), nil
+	}
+	return netip.ParseAddrPort(addr.String())
+}
+
+// Write sends p as a single datagram on a connected socket.
+func (c *UDPConn) Write(p []byte) (int, error) {
+	return c.WriteTo(p, nil)
+}
+
+func (c *UDPConn) send(p []byte, dest cm.Option[network.IPSocketAddress], addr net.Addr) (int, error) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if c.closed.Load() {
+		return 0, c.opError("write", addr, net.ErrClosed)
+	}
+
+	datagram := []udp.OutgoingDatagram{{Data: cm.ToList(p), RemoteAddress: dest}}
+	for {
+		permit := c.out.CheckSend()
+		if err := permit.Err(); err != nil {
+			return 0, c.opError("write", addr, codeError(*err))
+		}
+		if *permit.OK() > 0 {
+			sent := c.out.Send(cm.ToList(datagram))
+			if err := sent.Err(); err != nil {
+				return 0, c.opError("write", addr, codeError(*err))
+			}
+			if *sent.OK() == 1 {
+				return len(p), nil
+			}
+		}
+
+		pollable := c.out.Subscribe()
+		err := wait(pollable, c.deadlineFunc(&c.writeDeadline), c.closedErr)
+		pollable.ResourceDrop()
+		if err != nil {
+			return 0, c.opError("write", addr, err)
+		}
+	}
+}
+
+// Close closes the socket, unblocking pending reads and writes.
+func (c *UDPConn) Close() error {
+	if c.closed.Swap(true) {
+		return c.opError("close", nil, net.ErrClosed)
+	}
+	c.readMu.Lock()
+	c.writeMu.Lock()
+	defer c.readMu.Unlock()
+	defer c.writeMu.Unlock()
+
+	// The streams are children of the socket and must be dropped first
+	c.in.ResourceDrop()
+	c.out.ResourceDrop()
+	c.socket.ResourceDrop()
+	return nil
+}
+
+func (c *UDPConn) LocalAddr() net.Addr {
+	return c.laddr
+}
+
+// RemoteAddr returns the connected address, or nil if not connected.
+func (c *UDPConn) RemoteAddr() net.Addr {
+	return c.raddr
+}
+
+func (c *UDPConn) SetDeadline(t time.Time) error {
+	c.SetReadDeadline(t)
+	return c.SetWriteDeadline(t)
+}
+
+func (c *UDPConn) SetReadDeadline(t time.Time) error {
+	return c.setDeadline(&c.readDeadline, t)
+}
+
+func (c *UDPConn) SetWriteDeadline(t time.Time) error {
+	return c.setDeadline(&c.writeDeadline, t)
+}
+
+func (c *UDPConn) setDeadline(d *atomic.Int64, t time.Time) error {
+	if c.closed.Load() {
+		return c.opError("set", nil, net.ErrClosed)
+	}
+	if t.IsZero() {
+		d.Store(0)
+	} else {
+		d.Store(t.UnixNano())
+	}
+	return nil
+}
+
+func (c *UDPConn) deadlineFunc(d *atomic.Int64) func() time.Time {
+	return func() time.Time {
+		if ns := d.Load(); ns != 0 {
+			return time.Unix(0, ns)
+		}
+		return time.Time{}
+	}
+}
+
+func (c *UDPConn) closedErr() error {
+	if c.closed.Load() {
+		return net.ErrClosed
+	}
+	return nil
+}
+
+func (c *UDPConn) opError(op string, addr net.Addr, err error) error {
+	if addr == nil {
+		addr = c.raddr
+	}
+	return &net.OpError{Op: op, Net: "udp", Source: c.laddr, Addr: addr, Err: err}
+}
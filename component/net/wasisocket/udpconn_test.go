@@ -0,0 +1,33 @@
+package wasisocket
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+)
+
+func TestAddrPort(t *testing.T) {
+	for _, tt := range []struct {
+		addr net.Addr
+		want string
+	}{
+		{&net.UDPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 53}, "192.0.2.1:53"},
+		{&net.UDPAddr{IP: net.ParseIP("2001:db8::1"), Port: 443}, "[2001:db8::1]:443"},
+		{&net.UDPAddr{IP: net.ParseIP("::ffff:10.0.0.1"), Port: 80}, "10.0.0.1:80"},
+		{&net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8080}, "127.0.0.1:8080"},
+		{&net.TCPAddr{IP: net.IPv6loopback, Port: 22}, "[::1]:22"},
+	} {
+		got, err := addrPort(tt.addr)
+		if err != nil {
+			t.Errorf("addrPort(%v): unexpected error %v", tt.addr, err)
+			continue
+		}
+		if want := netip.MustParseAddrPort(tt.want); got != want {
+			t.Errorf("addrPort(%v) = %s, want %s", tt.addr, got, want)
+		}
+	}
+
+	if _, err := addrPort(&net.UnixAddr{Name: "/tmp/sock", Net: "unixgram"}); err == nil {
+		t.Error("expected an error for a non-IP address")
+	}
+}
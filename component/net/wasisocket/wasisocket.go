@@ -0,0 +1,205 @@
+// Package wasisocket implements [net.Conn] and friends on top of
+// [wasi:sockets], so clients accepting a custom dialer can run inside
+// components.
+//
+// [wasi:sockets]: https://github.com/WebAssembly/wasi-sockets/tree/v0.2.0
+package wasisocket
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.wasmcloud.dev/component/gen/wasi/io/poll"
+	"go.wasmcloud.dev/component/gen/wasi/io/streams"
+	instancenetwork "go.wasmcloud.dev/component/gen/wasi/sockets/instance-network"
+	"go.wasmcloud.dev/component/gen/wasi/sockets/network"
+	reactor "go.wasmcloud.dev/component/poll"
+)
+
+// maxPollInterval bounds how long a blocked operation waits before checking
+// for deadline changes and Close.
+const maxPollInterval = 50 * time.Millisecond
+
+// instanceNetwork is the network handle granted to the component, kept for
+// the lifetime of the instance.
+var instanceNetwork = sync.OnceValue(instancenetwork.InstanceNetwork)
+
+// Error is an error code reported by wasi:sockets.
+type Error struct {
+	Code network.ErrorCode
+}
+
+func (e *Error) Error() string {
+	return e.Code.String()
+}
+
+// Timeout reports whether the host timed the operation out.
+func (e *Error) Timeout() bool {
+	return e.Code == network.ErrorCodeTimeout
+}
+
+// Temporary reports whether retrying the operation may succeed.
+func (e *Error) Temporary() bool {
+	switch e.Code {
+	case network.ErrorCodeTimeout, network.ErrorCodeWouldBlock,
+		network.ErrorCodeTemporaryResolverFailure, network.ErrorCodeNewSocketLimit:
+		return true
+	}
+	return false
+}
+
+// Unwrap maps the code to the matching [syscall.Errno], so callers can use
+// errors.Is(err, syscall.ECONNREFUSED) as with the standard library.
+func (e *Error) Unwrap() error {
+	switch e.Code {
+	case network.ErrorCodeAccessDenied:
+		return syscall.EACCES
+	case network.ErrorCodeNotSupported:
+		return syscall.ENOTSUP
+	case network.ErrorCodeInvalidArgument:
+		return syscall.EINVAL
+	case network.ErrorCodeOutOfMemory:
+		return syscall.ENOMEM
+	case network.ErrorCodeTimeout:
+		return syscall.ETIMEDOUT
+	case network.ErrorCodeWouldBlock:
+		return syscall.EAGAIN
+	case network.ErrorCodeAddressNotBindable:
+		return syscall.EADDRNOTAVAIL
+	case network.ErrorCodeAddressInUse:
+		return syscall.EADDRINUSE
+	case network.ErrorCodeRemoteUnreachable:
+		return syscall.EHOSTUNREACH
+	case network.ErrorCodeConnectionRefused:
+		return syscall.ECONNREFUSED
+	case network.ErrorCodeConnectionReset:
+		return syscall.ECONNRESET
+	case network.ErrorCodeConnectionAborted:
+		return syscall.ECONNABORTED
+	case network.ErrorCodeDatagramTooLarge:
+		return syscall.EMSGSIZE
+	}
+	return nil
+}
+
+func codeError(code network.ErrorCode) error {
+	return &Error{Code: code}
+}
+
+// streamError converts a failed stream operation, releasing the host error.
+func streamError(e *streams.StreamError) error {
+	if e.Closed() {
+		return net.ErrClosed
+	}
+	ioErr := e.LastOperationFailed()
+	defer ioErr.ResourceDrop()
+	return errors.New(ioErr.ToDebugString())
+}
+
+// wait blocks until p is ready. It gives up with [os.ErrDeadlineExceeded]
+// once the deadline returned by deadline passes, or with the error returned
+// by canceled. Either function is checked at least every [maxPollInterval],
+// so deadlines may be moved and sockets closed while waiting.
+func wait(p poll.Pollable, deadline func() time.Time, canceled func() error) error {
+	for !p.Ready() {
+		if err := canceled(); err != nil {
+			return err
+		}
+		timeout := maxPollInterval
+		if d := deadline(); !d.IsZero() {
+			remaining := time.Until(d)
+			if remaining <= 0 {
+				return os.ErrDeadlineExceeded
+			}
+			timeout = min(timeout, remaining)
+		}
+		reactor.WaitTimeout(p, timeout)
+	}
+	return nil
+}
+
+func toSocketAddress(addr netip.AddrPort) network.IPSocketAddress {
+	ip := addr.Addr()
+	if ip.Is4() || ip.Is4In6() {
+		return network.IPSocketAddressIPv4(network.IPv4SocketAddress{
+			Port:    addr.Port(),
+			Address: network.IPv4Address(ip.Unmap().As4()),
+		})
+	}
+	b := ip.As16()
+	var segments network.IPv6Address
+	for i := range segments {
+		segments[i] = uint16(b[2*i])<<8 | uint16(b[2*i+1])
+	}
+	return network.IPSocketAddressIPv6(network.IPv6SocketAddress{
+		Port:    addr.Port(),
+		Address: segments,
+	})
+}
+
+func fromSocketAddress(addr network.IPSocketAddress) netip.AddrPort {
+	if v4 := addr.IPv4(); v4 != nil {
+		return netip.AddrPortFrom(netip.AddrFrom4(v4.Address), v4.Port)
+	}
+	v6 := addr.IPv6()
+	var b [16]byte
+	for i, segment := range v6.Address {
+		b[2*i] = byte(segment >> 8)
+		b[2*i+1] = byte(segment)
+	}
+	return netip.AddrPortFrom(netip.AddrFrom16(b), v6.Port)
+}
+
+func fromIPAddress(addr network.IPAddress) netip.Addr {
+	if v4 := addr.IPv4(); v4 != nil {
+		return netip.AddrFrom4(*v4)
+	}
+	var b [16]byte
+	for i, segment := range addr.IPv6() {
+		b[2*i] = byte(segment >> 8)
+		b[2*i+1] = byte(segment)
+	}
+	return netip.AddrFrom16(b)
+}
+
+func addressFamily(addr netip.Addr) network.IPAddressFamily {
+	if addr.Is4() || addr.Is4In6() {
+		return network.IPAddressFamilyIPv4
+	}
+	return network.IPAddressFamilyIPv6
+}
+
+func udpAddr(addr netip.AddrPort) *net.UDPAddr {
+	return &net.UDPAddr{
+		IP:   addr.Addr().AsSlice(),
+		Port: int(addr.Port()),
+		Zone: addr.Addr().Zone(),
+	}
+}
+
+// sockAddr returns addr as the [net.Addr] type matching network.
+func sockAddr(network string, addr netip.AddrPort) net.Addr {
+	if strings.HasPrefix(network, "udp") {
+		return udpAddr(addr)
+	}
+	return tcpAddr(addr)
+}
+
+func tcpAddr(addr netip.AddrPort) *net.TCPAddr {
+	return &net.TCPAddr{
+		IP:   addr.Addr().AsSlice(),
+		Port: int(addr.Port()),
+		Zone: addr.Addr().Zone(),
+	}
+}
+
+// noCancel is used by waits that can't be canceled.
+func noCancel() error {
+	return nil
+}
@@ -0,0 +1,136 @@
+package wasisocket
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"syscall"
+	"testing"
+
+	"go.wasmcloud.dev/component/gen/wasi/sockets/network"
+)
+
+func TestSocketAddressRoundTrip(t *testing.T) {
+	for _, s := range []string{
+		"0.0.0.0:0",
+		"127.0.0.1:8080",
+		"192.168.1.254:65535",
+		"[::]:0",
+		"[::1]:443",
+		"[2001:db8::1:2:3]:53",
+		"[fe80::1234:5678:9abc:def0]:8080",
+	} {
+		addr := netip.MustParseAddrPort(s)
+		if got := fromSocketAddress(toSocketAddress(addr)); got != addr {
+			t.Errorf("expected %s, got %s", addr, got)
+		}
+	}
+}
+
+func TestSocketAddressFamily(t *testing.T) {
+	v4 := toSocketAddress(netip.MustParseAddrPort("10.0.0.1:80"))
+	if v4.IPv4() == nil {
+		t.Fatalf("expected an IPv4 socket address, got %v", v4)
+	}
+	if want, got := (network.IPv4Address{10, 0, 0, 1}), v4.IPv4().Address; want != got {
+		t.Errorf("expected address %v, got %v", want, got)
+	}
+
+	// IPv4-mapped addresses use the IPv4 family, as with net.Dial
+	mapped := toSocketAddress(netip.MustParseAddrPort("[::ffff:10.0.0.1]:80"))
+	if mapped.IPv4() == nil {
+		t.Errorf("expected an IPv4 socket address for a mapped address, got %v", mapped)
+	}
+
+	v6 := toSocketAddress(netip.MustParseAddrPort("[2001:db8::ff00:42:8329]:80"))
+	if v6.IPv6() == nil {
+		t.Fatalf("expected an IPv6 socket address, got %v", v6)
+	}
+	want := network.IPv6Address{0x2001, 0xdb8, 0, 0, 0, 0xff00, 0x42, 0x8329}
+	if got := v6.IPv6().Address; want != got {
+		t.Errorf("expected segments %x, got %x", want, got)
+	}
+
+	if want, got := network.IPAddressFamilyIPv4, addressFamily(netip.MustParseAddr("::ffff:10.0.0.1")); want != got {
+		t.Errorf("expected family %v, got %v", want, got)
+	}
+	if want, got := network.IPAddressFamilyIPv6, addressFamily(netip.MustParseAddr("::1")); want != got {
+		t.Errorf("expected family %v, got %v", want, got)
+	}
+}
+
+func TestFromIPAddress(t *testing.T) {
+	for _, tt := range []struct {
+		addr network.IPAddress
+		want string
+	}{
+		{network.IPAddressIPv4(network.IPv4Address{127, 0, 0, 1}), "127.0.0.1"},
+		{network.IPAddressIPv4(network.IPv4Address{}), "0.0.0.0"},
+		{network.IPAddressIPv6(network.IPv6Address{0, 0, 0, 0, 0, 0, 0, 1}), "::1"},
+		{network.IPAddressIPv6(network.IPv6Address{0x2001, 0xdb8, 0, 0, 0, 0, 0, 0xabcd}), "2001:db8::abcd"},
+	} {
+		if got := fromIPAddress(tt.addr); got != netip.MustParseAddr(tt.want) {
+			t.Errorf("expected %s, got %s", tt.want, got)
+		}
+	}
+}
+
+func TestSockAddr(t *testing.T) {
+	addr := netip.MustParseAddrPort("[::1]:53")
+	if _, ok := sockAddr("udp6", addr).(*net.UDPAddr); !ok {
+		t.Errorf("expected *net.UDPAddr for udp6, got %T", sockAddr("udp6", addr))
+	}
+	tcp, ok := sockAddr("tcp", addr).(*net.TCPAddr)
+	if !ok {
+		t.Fatalf("expected *net.TCPAddr for tcp, got %T", sockAddr("tcp", addr))
+	}
+	if want, got := "[::1]:53", tcp.String(); want != got {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestErrorUnwrap(t *testing.T) {
+	for _, tt := range []struct {
+		code      network.ErrorCode
+		want      error
+		timeout   bool
+		temporary bool
+	}{
+		{network.ErrorCodeAccessDenied, syscall.EACCES, false, false},
+		{network.ErrorCodeNotSupported, syscall.ENOTSUP, false, false},
+		{network.ErrorCodeInvalidArgument, syscall.EINVAL, false, false},
+		{network.ErrorCodeOutOfMemory, syscall.ENOMEM, false, false},
+		{network.ErrorCodeTimeout, syscall.ETIMEDOUT, true, true},
+		{network.ErrorCodeWouldBlock, syscall.EAGAIN, false, true},
+		{network.ErrorCodeAddressNotBindable, syscall.EADDRNOTAVAIL, false, false},
+		{network.ErrorCodeAddressInUse, syscall.EADDRINUSE, false, false},
+		{network.ErrorCodeRemoteUnreachable, syscall.EHOSTUNREACH, false, false},
+		{network.ErrorCodeConnectionRefused, syscall.ECONNREFUSED, false, false},
+		{network.ErrorCodeConnectionReset, syscall.ECONNRESET, false, false},
+		{network.ErrorCodeConnectionAborted, syscall.ECONNABORTED, false, false},
+		{network.ErrorCodeDatagramTooLarge, syscall.EMSGSIZE, false, false},
+		{network.ErrorCodeNewSocketLimit, nil, false, true},
+		{network.ErrorCodeTemporaryResolverFailure, nil, false, true},
+		{network.ErrorCodeUnknown, nil, false, false},
+	} {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			err := &net.OpError{Op: "dial", Net: "tcp", Err: codeError(tt.code)}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("expected errors.Is(%v)", tt.want)
+			}
+			var e *Error
+			if !errors.As(err, &e) {
+				t.Fatalf("expected *Error, got %T", err.Err)
+			}
+			if tt.want == nil && e.Unwrap() != nil {
+				t.Errorf("expected no errno, got %v", e.Unwrap())
+			}
+			if e.Timeout() != tt.timeout {
+				t.Errorf("expected Timeout() = %v", tt.timeout)
+			}
+			if e.Temporary() != tt.temporary {
+				t.Errorf("expected Temporary() = %v", tt.temporary)
+			}
+		})
+	}
+}
@@ -1,26 +1,17 @@
+// Package io schedules goroutines waiting on [wasi:io/poll] pollables. A
+// single reactor polls every pending pollable at once and wakes the
+// goroutines whose pollables are ready, so concurrent I/O doesn't
+// serialize on busy waits.
+//
+// [wasi:io/poll]: https://github.com/WebAssembly/wasi-io/blob/v0.2.0/wit/poll.wit
 package io
 
 import (
-	"runtime"
-	"time"
-
-	monotonicclock "go.wasmcloud.dev/component/gen/wasi/clocks/monotonic-clock"
 	"go.wasmcloud.dev/component/gen/wasi/http/types"
 )
 
-// Resolve is a utility function that polls a given Pollable object
-// until it is ready, using an exponential backoff strategy starting at 1ms
-// and capping at 5 seconds. It uses a wasi:clocks/monotonic-clock pollable
-// to backoff and yield the thread to the Go runtime scheduler.
+// Resolve blocks the calling goroutine until the given Pollable is ready.
+// It is equivalent to [Wait] and kept for existing callers.
 func Resolve(pollable types.Pollable) {
-	backoffDuration := 1 * time.Millisecond
-	for !pollable.Ready() {
-		runtime.Gosched()
-		backoff := monotonicclock.SubscribeDuration(monotonicclock.Duration(backoffDuration))
-		backoff.Block()
-		backoffDuration *= 2
-		if backoffDuration > 5*time.Second {
-			backoffDuration = 5 * time.Second // Cap the backoff duration
-		}
-	}
+	Wait(pollable)
 }
@@ -0,0 +1,183 @@
+package io
+
+import (
+	"context"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+
+	"go.bytecodealliance.org/cm"
+	monotonicclock "go.wasmcloud.dev/component/gen/wasi/clocks/monotonic-clock"
+	"go.wasmcloud.dev/component/gen/wasi/io/poll"
+)
+
+// maxPollInterval bounds how long the reactor blocks the instance in a
+// single call to wasi:io/poll.poll, so goroutines waiting on timers or
+// channels still get to run.
+const maxPollInterval = 50 * time.Millisecond
+
+// waiter is a goroutine blocked on a pollable.
+type waiter struct {
+	pollable poll.Pollable
+	deadline time.Time
+	ready    bool
+	done     chan struct{}
+}
+
+// reactor collects the pollables goroutines are waiting on. While any are
+// registered, a single goroutine polls all of them together and wakes the
+// waiters whose pollables became ready.
+var reactor struct {
+	mu      sync.Mutex
+	waiters []*waiter
+	running bool
+}
+
+// Wait blocks the calling goroutine until p is ready. Other goroutines keep
+// running while it waits.
+func Wait(p poll.Pollable) {
+	if p.Ready() {
+		return
+	}
+	<-register(p, time.Time{}).done
+}
+
+// WaitTimeout blocks the calling goroutine until p is ready or timeout has
+// elapsed, and reports whether p is ready.
+func WaitTimeout(p poll.Pollable, timeout time.Duration) bool {
+	if p.Ready() {
+		return true
+	}
+	if timeout <= 0 {
+		return false
+	}
+	w := register(p, time.Now().Add(timeout))
+	<-w.done
+	return w.ready
+}
+
+// WaitContext blocks the calling goroutine until p is ready or ctx is done,
+// in which case it returns the context error.
+func WaitContext(ctx context.Context, p poll.Pollable) error {
+	if p.Ready() {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	w := register(p, deadline)
+	select {
+	case <-w.done:
+		if w.ready {
+			return nil
+		}
+		// The reactor noticed the deadline before the context timer fired
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return context.DeadlineExceeded
+	case <-ctx.Done():
+		if unregister(w) {
+			return nil
+		}
+		return ctx.Err()
+	}
+}
+
+// register adds a waiter for p, starting the reactor if needed. A zero
+// deadline means the waiter only wakes once p is ready.
+func register(p poll.Pollable, deadline time.Time) *waiter {
+	w := &waiter{pollable: p, deadline: deadline, done: make(chan struct{})}
+	reactor.mu.Lock()
+	defer reactor.mu.Unlock()
+	reactor.waiters = append(reactor.waiters, w)
+	if !reactor.running {
+		reactor.running = true
+		go run()
+	}
+	return w
+}
+
+// unregister removes w if it is still waiting, so the caller may drop its
+// pollable. It reports whether w was already woken with its pollable ready.
+func unregister(w *waiter) bool {
+	reactor.mu.Lock()
+	defer reactor.mu.Unlock()
+	if i := slices.Index(reactor.waiters, w); i >= 0 {
+		reactor.waiters = slices.Delete(reactor.waiters, i, i+1)
+		return false
+	}
+	return w.ready
+}
+
+// wake removes w and releases its goroutine. The caller must hold
+// reactor.mu.
+func wake(w *waiter, ready bool) {
+	i := slices.Index(reactor.waiters, w)
+	if i < 0 {
+		return
+	}
+	reactor.waiters = slices.Delete(reactor.waiters, i, i+1)
+	w.ready = ready
+	close(w.done)
+}
+
+// run polls the registered pollables until no waiters are left. The poll
+// is bounded by the nearest waiter deadline and by an interval that backs
+// off from 1ms to [maxPollInterval] while nothing becomes ready.
+func run() {
+	interval := time.Millisecond
+	for {
+		// Let other goroutines run, and register or unregister, before
+		// blocking the instance. Nothing yields between the snapshot and the
+		// poll, so no pollable in it can be dropped in between.
+		runtime.Gosched()
+
+		reactor.mu.Lock()
+		if len(reactor.waiters) == 0 {
+			reactor.running = false
+			reactor.mu.Unlock()
+			return
+		}
+		waiters := slices.Clone(reactor.waiters)
+		reactor.mu.Unlock()
+
+		timeout := interval
+		now := time.Now()
+		pollables := make([]poll.Pollable, 0, len(waiters)+1)
+		for _, w := range waiters {
+			pollables = append(pollables, w.pollable)
+			if !w.deadline.IsZero() {
+				timeout = max(min(timeout, w.deadline.Sub(now)), 0)
+			}
+		}
+		timer := monotonicclock.SubscribeDuration(monotonicclock.Duration(timeout))
+		pollables = append(pollables, timer)
+		ready := poll.Poll(cm.ToList(pollables)).Slice()
+		timer.ResourceDrop()
+
+		woke := false
+		now = time.Now()
+		reactor.mu.Lock()
+		for _, i := range ready {
+			if int(i) < len(waiters) {
+				wake(waiters[i], true)
+				woke = true
+			}
+		}
+		for _, w := range waiters {
+			if !w.deadline.IsZero() && !now.Before(w.deadline) {
+				wake(w, false)
+			}
+		}
+		reactor.mu.Unlock()
+
+		if woke {
+			interval = time.Millisecond
+		} else {
+			interval = min(interval*2, maxPollInterval)
+		}
+	}
+}
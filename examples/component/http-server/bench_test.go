@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"go.wasmcloud.dev/component/net/wasihttp"
+	"go.wasmcloud.dev/wadge"
+	"go.wasmcloud.dev/wadge/wadgehttp"
+)
+
+// benchmarkResponse serves a response made of many small writes, as
+// produced by json.Encoder, with the given response buffer size.
+func benchmarkResponse(b *testing.B, bufferSize int) {
+	type item struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	wasihttp.ResponseBufferSize = bufferSize
+	b.Cleanup(func() { wasihttp.ResponseBufferSize = wasihttp.DefaultResponseBufferSize })
+	handle := serve(func(w http.ResponseWriter, r *http.Request) {
+		enc := json.NewEncoder(w)
+		for i := range 1000 {
+			_ = enc.Encode(item{ID: i, Name: "item"})
+		}
+	})
+
+	wadge.RunTest(b, func() {
+		for b.Loop() {
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			if err != nil {
+				b.Fatalf("failed to create new HTTP request: %s", err)
+			}
+			resp, err := wadgehttp.HandleIncomingRequest(handle, req)
+			if err != nil {
+				b.Fatalf("failed to handle incoming HTTP request: %s", err)
+			}
+			n, err := io.Copy(io.Discard, resp.Body)
+			if err != nil {
+				b.Fatalf("failed to read HTTP response body: %s", err)
+			}
+			resp.Body.Close()
+			b.SetBytes(n)
+		}
+	})
+}
+
+// BenchmarkResponse compares writing every call through to the host with
+// the default response buffer. Compare the results with
+// benchstat -col /buffer.
+func BenchmarkResponse(b *testing.B) {
+	for _, size := range []int{0, wasihttp.DefaultResponseBufferSize} {
+		b.Run(fmt.Sprintf("buffer=%d", size), func(b *testing.B) {
+			benchmarkResponse(b, size)
+		})
+	}
+}
@@ -11,6 +11,11 @@ import (
 	go_wasmcloud_dev__component__gen__wasi__http__types "go.wasmcloud.dev/component/gen/wasi/http/types"
 	go_wasmcloud_dev__component__gen__wasi__io__poll "go.wasmcloud.dev/component/gen/wasi/io/poll"
 	go_wasmcloud_dev__component__gen__wasi__io__streams "go.wasmcloud.dev/component/gen/wasi/io/streams"
+	go_wasmcloud_dev__component__gen__wasi__sockets__ip___name___lookup "go.wasmcloud.dev/component/gen/wasi/sockets/ip-name-lookup"
+	go_wasmcloud_dev__component__gen__wasi__sockets__tcp "go.wasmcloud.dev/component/gen/wasi/sockets/tcp"
+	go_wasmcloud_dev__component__gen__wasi__sockets__tcp___create___socket "go.wasmcloud.dev/component/gen/wasi/sockets/tcp-create-socket"
+	go_wasmcloud_dev__component__gen__wasi__sockets__udp "go.wasmcloud.dev/component/gen/wasi/sockets/udp"
+	go_wasmcloud_dev__component__gen__wasi__sockets__udp___create___socket "go.wasmcloud.dev/component/gen/wasi/sockets/udp-create-socket"
 	wadge "go.wasmcloud.dev/wadge"
 	"runtime"
 	"unsafe"
@@ -2051,3 +2056,1411 @@ func wasmimport_Log(level0 uint32, context0 *uint8, context1 uint32, message0 *u
 	}
 	return
 }
+
+//go:linkname wasmimport_InstanceNetwork go.wasmcloud.dev/component/gen/wasi/sockets/instance-network.wasmimport_InstanceNetwork
+func wasmimport_InstanceNetwork() (result0 uint32) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/instance-network@0.2.0", "instance-network", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&result0)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_ResolveAddressStreamResolveNextAddress go.wasmcloud.dev/component/gen/wasi/sockets/ip-name-lookup.wasmimport_ResolveAddressStreamResolveNextAddress
+func wasmimport_ResolveAddressStreamResolveNextAddress(self0 uint32, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__ip___name___lookup.OptionIPAddressShape, go_bytecodealliance_org__cm.Option[go_wasmcloud_dev__component__gen__wasi__sockets__ip___name___lookup.IPAddress], go_wasmcloud_dev__component__gen__wasi__sockets__ip___name___lookup.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/ip-name-lookup@0.2.0", "[method]resolve-address-stream.resolve-next-address", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_ResolveAddressStreamSubscribe go.wasmcloud.dev/component/gen/wasi/sockets/ip-name-lookup.wasmimport_ResolveAddressStreamSubscribe
+func wasmimport_ResolveAddressStreamSubscribe(self0 uint32) (result0 uint32) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/ip-name-lookup@0.2.0", "[method]resolve-address-stream.subscribe", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&result0)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_ResolveAddressStreamResourceDrop go.wasmcloud.dev/component/gen/wasi/sockets/ip-name-lookup.wasmimport_ResolveAddressStreamResourceDrop
+func wasmimport_ResolveAddressStreamResourceDrop(self0 uint32) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/ip-name-lookup@0.2.0", "[resource-drop]resolve-address-stream", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_ResolveAddresses go.wasmcloud.dev/component/gen/wasi/sockets/ip-name-lookup.wasmimport_ResolveAddresses
+func wasmimport_ResolveAddresses(network0 uint32, name0 *uint8, name1 uint32, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__ip___name___lookup.ResolveAddressStream, go_wasmcloud_dev__component__gen__wasi__sockets__ip___name___lookup.ResolveAddressStream, go_wasmcloud_dev__component__gen__wasi__sockets__ip___name___lookup.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/ip-name-lookup@0.2.0", "resolve-addresses", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&network0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(name0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&name1)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_NetworkResourceDrop go.wasmcloud.dev/component/gen/wasi/sockets/network.wasmimport_NetworkResourceDrop
+func wasmimport_NetworkResourceDrop(self0 uint32) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/network@0.2.0", "[resource-drop]network", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketAccept go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketAccept
+func wasmimport_TCPSocketAccept(self0 uint32, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__tcp.TupleTCPSocketInputStreamOutputStreamShape, go_bytecodealliance_org__cm.Tuple3[go_wasmcloud_dev__component__gen__wasi__sockets__tcp.TCPSocket, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.InputStream, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.OutputStream], go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.accept", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketAddressFamily go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketAddressFamily
+func wasmimport_TCPSocketAddressFamily(self0 uint32) (result0 uint32) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.address-family", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&result0)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketFinishBind go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketFinishBind
+func wasmimport_TCPSocketFinishBind(self0 uint32, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode, struct{}, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.finish-bind", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketFinishConnect go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketFinishConnect
+func wasmimport_TCPSocketFinishConnect(self0 uint32, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__tcp.TupleInputStreamOutputStreamShape, go_bytecodealliance_org__cm.Tuple[go_wasmcloud_dev__component__gen__wasi__sockets__tcp.InputStream, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.OutputStream], go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.finish-connect", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketFinishListen go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketFinishListen
+func wasmimport_TCPSocketFinishListen(self0 uint32, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode, struct{}, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.finish-listen", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketHopLimit go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketHopLimit
+func wasmimport_TCPSocketHopLimit(self0 uint32, result *go_bytecodealliance_org__cm.Result[uint8, uint8, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.hop-limit", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketIsListening go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketIsListening
+func wasmimport_TCPSocketIsListening(self0 uint32) (result0 uint32) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.is-listening", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&result0)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketKeepAliveCount go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketKeepAliveCount
+func wasmimport_TCPSocketKeepAliveCount(self0 uint32, result *go_bytecodealliance_org__cm.Result[uint32, uint32, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.keep-alive-count", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketKeepAliveEnabled go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketKeepAliveEnabled
+func wasmimport_TCPSocketKeepAliveEnabled(self0 uint32, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode, bool, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.keep-alive-enabled", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketKeepAliveIdleTime go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketKeepAliveIdleTime
+func wasmimport_TCPSocketKeepAliveIdleTime(self0 uint32, result *go_bytecodealliance_org__cm.Result[uint64, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.Duration, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.keep-alive-idle-time", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketKeepAliveInterval go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketKeepAliveInterval
+func wasmimport_TCPSocketKeepAliveInterval(self0 uint32, result *go_bytecodealliance_org__cm.Result[uint64, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.Duration, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.keep-alive-interval", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketLocalAddress go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketLocalAddress
+func wasmimport_TCPSocketLocalAddress(self0 uint32, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__tcp.IPSocketAddressShape, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.IPSocketAddress, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.local-address", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketReceiveBufferSize go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketReceiveBufferSize
+func wasmimport_TCPSocketReceiveBufferSize(self0 uint32, result *go_bytecodealliance_org__cm.Result[uint64, uint64, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.receive-buffer-size", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketRemoteAddress go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketRemoteAddress
+func wasmimport_TCPSocketRemoteAddress(self0 uint32, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__tcp.IPSocketAddressShape, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.IPSocketAddress, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.remote-address", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketSendBufferSize go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSendBufferSize
+func wasmimport_TCPSocketSendBufferSize(self0 uint32, result *go_bytecodealliance_org__cm.Result[uint64, uint64, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.send-buffer-size", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketSetHopLimit go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSetHopLimit
+func wasmimport_TCPSocketSetHopLimit(self0 uint32, value0 uint32, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode, struct{}, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.set-hop-limit", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&value0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketSetKeepAliveCount go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSetKeepAliveCount
+func wasmimport_TCPSocketSetKeepAliveCount(self0 uint32, value0 uint32, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode, struct{}, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.set-keep-alive-count", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&value0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketSetKeepAliveEnabled go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSetKeepAliveEnabled
+func wasmimport_TCPSocketSetKeepAliveEnabled(self0 uint32, value0 uint32, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode, struct{}, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.set-keep-alive-enabled", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&value0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketSetKeepAliveIdleTime go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSetKeepAliveIdleTime
+func wasmimport_TCPSocketSetKeepAliveIdleTime(self0 uint32, value0 uint64, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode, struct{}, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.set-keep-alive-idle-time", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&value0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketSetKeepAliveInterval go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSetKeepAliveInterval
+func wasmimport_TCPSocketSetKeepAliveInterval(self0 uint32, value0 uint64, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode, struct{}, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.set-keep-alive-interval", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&value0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketSetListenBacklogSize go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSetListenBacklogSize
+func wasmimport_TCPSocketSetListenBacklogSize(self0 uint32, value0 uint64, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode, struct{}, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.set-listen-backlog-size", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&value0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketSetReceiveBufferSize go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSetReceiveBufferSize
+func wasmimport_TCPSocketSetReceiveBufferSize(self0 uint32, value0 uint64, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode, struct{}, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.set-receive-buffer-size", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&value0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketSetSendBufferSize go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSetSendBufferSize
+func wasmimport_TCPSocketSetSendBufferSize(self0 uint32, value0 uint64, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode, struct{}, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.set-send-buffer-size", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&value0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketShutdown go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketShutdown
+func wasmimport_TCPSocketShutdown(self0 uint32, shutdownType0 uint32, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode, struct{}, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.shutdown", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&shutdownType0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketStartBind go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketStartBind
+func wasmimport_TCPSocketStartBind(self0 uint32, network0 uint32, localAddress0 uint32, localAddress1 uint32, localAddress2 uint32, localAddress3 uint32, localAddress4 uint32, localAddress5 uint32, localAddress6 uint32, localAddress7 uint32, localAddress8 uint32, localAddress9 uint32, localAddress10 uint32, localAddress11 uint32, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode, struct{}, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.start-bind", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&network0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&localAddress0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&localAddress1)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&localAddress2)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&localAddress3)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&localAddress4)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&localAddress5)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&localAddress6)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&localAddress7)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&localAddress8)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&localAddress9)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&localAddress10)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&localAddress11)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketStartConnect go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketStartConnect
+func wasmimport_TCPSocketStartConnect(self0 uint32, network0 uint32, remoteAddress0 uint32, remoteAddress1 uint32, remoteAddress2 uint32, remoteAddress3 uint32, remoteAddress4 uint32, remoteAddress5 uint32, remoteAddress6 uint32, remoteAddress7 uint32, remoteAddress8 uint32, remoteAddress9 uint32, remoteAddress10 uint32, remoteAddress11 uint32, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode, struct{}, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.start-connect", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&network0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&remoteAddress0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&remoteAddress1)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&remoteAddress2)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&remoteAddress3)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&remoteAddress4)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&remoteAddress5)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&remoteAddress6)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&remoteAddress7)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&remoteAddress8)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&remoteAddress9)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&remoteAddress10)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&remoteAddress11)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketStartListen go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketStartListen
+func wasmimport_TCPSocketStartListen(self0 uint32, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode, struct{}, go_wasmcloud_dev__component__gen__wasi__sockets__tcp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.start-listen", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketSubscribe go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSubscribe
+func wasmimport_TCPSocketSubscribe(self0 uint32) (result0 uint32) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[method]tcp-socket.subscribe", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&result0)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_TCPSocketResourceDrop go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketResourceDrop
+func wasmimport_TCPSocketResourceDrop(self0 uint32) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp@0.2.0", "[resource-drop]tcp-socket", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_CreateTCPSocket go.wasmcloud.dev/component/gen/wasi/sockets/tcp-create-socket.wasmimport_CreateTCPSocket
+func wasmimport_CreateTCPSocket(addressFamily0 uint32, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__tcp___create___socket.TCPSocket, go_wasmcloud_dev__component__gen__wasi__sockets__tcp___create___socket.TCPSocket, go_wasmcloud_dev__component__gen__wasi__sockets__tcp___create___socket.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/tcp-create-socket@0.2.0", "create-tcp-socket", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&addressFamily0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_IncomingDatagramStreamReceive go.wasmcloud.dev/component/gen/wasi/sockets/udp.wasmimport_IncomingDatagramStreamReceive
+func wasmimport_IncomingDatagramStreamReceive(self0 uint32, maxResults0 uint64, result *go_bytecodealliance_org__cm.Result[go_bytecodealliance_org__cm.List[go_wasmcloud_dev__component__gen__wasi__sockets__udp.IncomingDatagram], go_bytecodealliance_org__cm.List[go_wasmcloud_dev__component__gen__wasi__sockets__udp.IncomingDatagram], go_wasmcloud_dev__component__gen__wasi__sockets__udp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/udp@0.2.0", "[method]incoming-datagram-stream.receive", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&maxResults0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_IncomingDatagramStreamSubscribe go.wasmcloud.dev/component/gen/wasi/sockets/udp.wasmimport_IncomingDatagramStreamSubscribe
+func wasmimport_IncomingDatagramStreamSubscribe(self0 uint32) (result0 uint32) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/udp@0.2.0", "[method]incoming-datagram-stream.subscribe", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&result0)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_OutgoingDatagramStreamCheckSend go.wasmcloud.dev/component/gen/wasi/sockets/udp.wasmimport_OutgoingDatagramStreamCheckSend
+func wasmimport_OutgoingDatagramStreamCheckSend(self0 uint32, result *go_bytecodealliance_org__cm.Result[uint64, uint64, go_wasmcloud_dev__component__gen__wasi__sockets__udp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/udp@0.2.0", "[method]outgoing-datagram-stream.check-send", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_OutgoingDatagramStreamSend go.wasmcloud.dev/component/gen/wasi/sockets/udp.wasmimport_OutgoingDatagramStreamSend
+func wasmimport_OutgoingDatagramStreamSend(self0 uint32, datagrams0 *go_wasmcloud_dev__component__gen__wasi__sockets__udp.OutgoingDatagram, datagrams1 uint32, result *go_bytecodealliance_org__cm.Result[uint64, uint64, go_wasmcloud_dev__component__gen__wasi__sockets__udp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/udp@0.2.0", "[method]outgoing-datagram-stream.send", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(datagrams0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&datagrams1)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_OutgoingDatagramStreamSubscribe go.wasmcloud.dev/component/gen/wasi/sockets/udp.wasmimport_OutgoingDatagramStreamSubscribe
+func wasmimport_OutgoingDatagramStreamSubscribe(self0 uint32) (result0 uint32) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/udp@0.2.0", "[method]outgoing-datagram-stream.subscribe", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&result0)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_UDPSocketAddressFamily go.wasmcloud.dev/component/gen/wasi/sockets/udp.wasmimport_UDPSocketAddressFamily
+func wasmimport_UDPSocketAddressFamily(self0 uint32) (result0 uint32) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/udp@0.2.0", "[method]udp-socket.address-family", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&result0)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_UDPSocketFinishBind go.wasmcloud.dev/component/gen/wasi/sockets/udp.wasmimport_UDPSocketFinishBind
+func wasmimport_UDPSocketFinishBind(self0 uint32, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__udp.ErrorCode, struct{}, go_wasmcloud_dev__component__gen__wasi__sockets__udp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/udp@0.2.0", "[method]udp-socket.finish-bind", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_UDPSocketLocalAddress go.wasmcloud.dev/component/gen/wasi/sockets/udp.wasmimport_UDPSocketLocalAddress
+func wasmimport_UDPSocketLocalAddress(self0 uint32, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__udp.IPSocketAddressShape, go_wasmcloud_dev__component__gen__wasi__sockets__udp.IPSocketAddress, go_wasmcloud_dev__component__gen__wasi__sockets__udp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/udp@0.2.0", "[method]udp-socket.local-address", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_UDPSocketReceiveBufferSize go.wasmcloud.dev/component/gen/wasi/sockets/udp.wasmimport_UDPSocketReceiveBufferSize
+func wasmimport_UDPSocketReceiveBufferSize(self0 uint32, result *go_bytecodealliance_org__cm.Result[uint64, uint64, go_wasmcloud_dev__component__gen__wasi__sockets__udp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/udp@0.2.0", "[method]udp-socket.receive-buffer-size", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_UDPSocketRemoteAddress go.wasmcloud.dev/component/gen/wasi/sockets/udp.wasmimport_UDPSocketRemoteAddress
+func wasmimport_UDPSocketRemoteAddress(self0 uint32, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__udp.IPSocketAddressShape, go_wasmcloud_dev__component__gen__wasi__sockets__udp.IPSocketAddress, go_wasmcloud_dev__component__gen__wasi__sockets__udp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/udp@0.2.0", "[method]udp-socket.remote-address", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_UDPSocketSendBufferSize go.wasmcloud.dev/component/gen/wasi/sockets/udp.wasmimport_UDPSocketSendBufferSize
+func wasmimport_UDPSocketSendBufferSize(self0 uint32, result *go_bytecodealliance_org__cm.Result[uint64, uint64, go_wasmcloud_dev__component__gen__wasi__sockets__udp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/udp@0.2.0", "[method]udp-socket.send-buffer-size", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_UDPSocketSetReceiveBufferSize go.wasmcloud.dev/component/gen/wasi/sockets/udp.wasmimport_UDPSocketSetReceiveBufferSize
+func wasmimport_UDPSocketSetReceiveBufferSize(self0 uint32, value0 uint64, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__udp.ErrorCode, struct{}, go_wasmcloud_dev__component__gen__wasi__sockets__udp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/udp@0.2.0", "[method]udp-socket.set-receive-buffer-size", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&value0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_UDPSocketSetSendBufferSize go.wasmcloud.dev/component/gen/wasi/sockets/udp.wasmimport_UDPSocketSetSendBufferSize
+func wasmimport_UDPSocketSetSendBufferSize(self0 uint32, value0 uint64, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__udp.ErrorCode, struct{}, go_wasmcloud_dev__component__gen__wasi__sockets__udp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/udp@0.2.0", "[method]udp-socket.set-send-buffer-size", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&value0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_UDPSocketSetUnicastHopLimit go.wasmcloud.dev/component/gen/wasi/sockets/udp.wasmimport_UDPSocketSetUnicastHopLimit
+func wasmimport_UDPSocketSetUnicastHopLimit(self0 uint32, value0 uint32, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__udp.ErrorCode, struct{}, go_wasmcloud_dev__component__gen__wasi__sockets__udp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/udp@0.2.0", "[method]udp-socket.set-unicast-hop-limit", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&value0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_UDPSocketStartBind go.wasmcloud.dev/component/gen/wasi/sockets/udp.wasmimport_UDPSocketStartBind
+func wasmimport_UDPSocketStartBind(self0 uint32, network0 uint32, localAddress0 uint32, localAddress1 uint32, localAddress2 uint32, localAddress3 uint32, localAddress4 uint32, localAddress5 uint32, localAddress6 uint32, localAddress7 uint32, localAddress8 uint32, localAddress9 uint32, localAddress10 uint32, localAddress11 uint32, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__udp.ErrorCode, struct{}, go_wasmcloud_dev__component__gen__wasi__sockets__udp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/udp@0.2.0", "[method]udp-socket.start-bind", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&network0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&localAddress0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&localAddress1)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&localAddress2)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&localAddress3)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&localAddress4)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&localAddress5)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&localAddress6)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&localAddress7)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&localAddress8)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&localAddress9)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&localAddress10)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&localAddress11)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_UDPSocketStream go.wasmcloud.dev/component/gen/wasi/sockets/udp.wasmimport_UDPSocketStream
+func wasmimport_UDPSocketStream(self0 uint32, remoteAddress0 uint32, remoteAddress1 uint32, remoteAddress2 uint32, remoteAddress3 uint32, remoteAddress4 uint32, remoteAddress5 uint32, remoteAddress6 uint32, remoteAddress7 uint32, remoteAddress8 uint32, remoteAddress9 uint32, remoteAddress10 uint32, remoteAddress11 uint32, remoteAddress12 uint32, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__udp.TupleIncomingDatagramStreamOutgoingDatagramStreamShape, go_bytecodealliance_org__cm.Tuple[go_wasmcloud_dev__component__gen__wasi__sockets__udp.IncomingDatagramStream, go_wasmcloud_dev__component__gen__wasi__sockets__udp.OutgoingDatagramStream], go_wasmcloud_dev__component__gen__wasi__sockets__udp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/udp@0.2.0", "[method]udp-socket.stream", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&remoteAddress0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&remoteAddress1)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&remoteAddress2)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&remoteAddress3)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&remoteAddress4)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&remoteAddress5)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&remoteAddress6)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&remoteAddress7)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&remoteAddress8)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&remoteAddress9)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&remoteAddress10)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&remoteAddress11)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&remoteAddress12)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_UDPSocketSubscribe go.wasmcloud.dev/component/gen/wasi/sockets/udp.wasmimport_UDPSocketSubscribe
+func wasmimport_UDPSocketSubscribe(self0 uint32) (result0 uint32) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/udp@0.2.0", "[method]udp-socket.subscribe", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&result0)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_UDPSocketUnicastHopLimit go.wasmcloud.dev/component/gen/wasi/sockets/udp.wasmimport_UDPSocketUnicastHopLimit
+func wasmimport_UDPSocketUnicastHopLimit(self0 uint32, result *go_bytecodealliance_org__cm.Result[uint8, uint8, go_wasmcloud_dev__component__gen__wasi__sockets__udp.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/udp@0.2.0", "[method]udp-socket.unicast-hop-limit", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_IncomingDatagramStreamResourceDrop go.wasmcloud.dev/component/gen/wasi/sockets/udp.wasmimport_IncomingDatagramStreamResourceDrop
+func wasmimport_IncomingDatagramStreamResourceDrop(self0 uint32) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/udp@0.2.0", "[resource-drop]incoming-datagram-stream", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_OutgoingDatagramStreamResourceDrop go.wasmcloud.dev/component/gen/wasi/sockets/udp.wasmimport_OutgoingDatagramStreamResourceDrop
+func wasmimport_OutgoingDatagramStreamResourceDrop(self0 uint32) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/udp@0.2.0", "[resource-drop]outgoing-datagram-stream", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_UDPSocketResourceDrop go.wasmcloud.dev/component/gen/wasi/sockets/udp.wasmimport_UDPSocketResourceDrop
+func wasmimport_UDPSocketResourceDrop(self0 uint32) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/udp@0.2.0", "[resource-drop]udp-socket", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&self0)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
+
+//go:linkname wasmimport_CreateUDPSocket go.wasmcloud.dev/component/gen/wasi/sockets/udp-create-socket.wasmimport_CreateUDPSocket
+func wasmimport_CreateUDPSocket(addressFamily0 uint32, result *go_bytecodealliance_org__cm.Result[go_wasmcloud_dev__component__gen__wasi__sockets__udp___create___socket.UDPSocket, go_wasmcloud_dev__component__gen__wasi__sockets__udp___create___socket.UDPSocket, go_wasmcloud_dev__component__gen__wasi__sockets__udp___create___socket.ErrorCode]) {
+	var __p runtime.Pinner
+	defer __p.Unpin()
+	if __err := wadge.WithCurrentInstance(func(__instance *wadge.Instance) error {
+		return __instance.Call("wasi:sockets/udp-create-socket@0.2.0", "create-udp-socket", func() unsafe.Pointer {
+			ptr := unsafe.Pointer(&addressFamily0)
+			__p.Pin(ptr)
+			return ptr
+		}(), func() unsafe.Pointer {
+			ptr := unsafe.Pointer(result)
+			__p.Pin(ptr)
+			return ptr
+		}())
+	}); __err != nil {
+		wadge.CurrentErrorHandler()(__err)
+	}
+	return
+}
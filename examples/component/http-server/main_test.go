@@ -12,6 +12,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	incominghandler "go.wasmcloud.dev/component/gen/wasi/http/incoming-handler"
+	"go.wasmcloud.dev/component/gen/wasi/http/types"
+	"go.wasmcloud.dev/component/net/wasihttp"
 	"go.wasmcloud.dev/wadge"
 	"go.wasmcloud.dev/wadge/wadgehttp"
 )
@@ -60,3 +62,17 @@ func TestIncomingHandler(t *testing.T) {
 		assert.Equal(t, []byte(Index), buf)
 	})
 }
+
+// serve adapts h to the incoming-handler export signature without replacing
+// the handler registered by init.
+func serve(h http.HandlerFunc) func(types.IncomingRequest, types.ResponseOutparam) {
+	return func(request types.IncomingRequest, out types.ResponseOutparam) {
+		r, err := wasihttp.WASItoHTTPRequest(request)
+		if err != nil {
+			panic(err)
+		}
+		w := wasihttp.WASItoHTTPResponseWriter(out)
+		defer w.Close()
+		h(w, r)
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	monotonicclock "go.wasmcloud.dev/component/gen/wasi/clocks/monotonic-clock"
+	poll "go.wasmcloud.dev/component/poll"
+	"go.wasmcloud.dev/wadge"
+)
+
+func TestPollWait(t *testing.T) {
+	wadge.RunTest(t, func() {
+		// Waiters share the reactor, so they finish together rather than in turn
+		start := time.Now()
+		var wg sync.WaitGroup
+		for _, d := range []time.Duration{100 * time.Millisecond, 50 * time.Millisecond, 100 * time.Millisecond} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				p := monotonicclock.SubscribeDuration(monotonicclock.Duration(d))
+				defer p.ResourceDrop()
+				poll.Wait(p)
+				assert.True(t, p.Ready())
+				assert.GreaterOrEqual(t, time.Since(start), d)
+			}()
+		}
+		wg.Wait()
+		assert.Less(t, time.Since(start), 250*time.Millisecond)
+	})
+}
+
+func TestPollWaitTimeout(t *testing.T) {
+	wadge.RunTest(t, func() {
+		p := monotonicclock.SubscribeDuration(monotonicclock.Duration(5 * time.Second))
+		start := time.Now()
+		assert.False(t, poll.WaitTimeout(p, 50*time.Millisecond))
+		assert.Less(t, time.Since(start), time.Second)
+		assert.False(t, poll.WaitTimeout(p, 0))
+		p.ResourceDrop()
+
+		p = monotonicclock.SubscribeDuration(monotonicclock.Duration(20 * time.Millisecond))
+		defer p.ResourceDrop()
+		assert.True(t, poll.WaitTimeout(p, 5*time.Second))
+		assert.True(t, poll.WaitTimeout(p, 0))
+	})
+}
+
+func TestPollWaitContext(t *testing.T) {
+	wadge.RunTest(t, func() {
+		p := monotonicclock.SubscribeDuration(monotonicclock.Duration(5 * time.Second))
+		defer p.ResourceDrop()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.ErrorIs(t, poll.WaitContext(ctx, p), context.Canceled)
+
+		ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		assert.ErrorIs(t, poll.WaitContext(ctx, p), context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), time.Second)
+
+		ctx, cancel = context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+		assert.ErrorIs(t, poll.WaitContext(ctx, p), context.Canceled)
+
+		ready := monotonicclock.SubscribeDuration(monotonicclock.Duration(20 * time.Millisecond))
+		defer ready.ResourceDrop()
+		assert.NoError(t, poll.WaitContext(context.Background(), ready))
+	})
+}
+
+func TestPollWaitContextRace(t *testing.T) {
+	wadge.RunTest(t, func() {
+		// Cancel around the time the pollable becomes ready. Either outcome is
+		// fine, but a nil error must mean the pollable is ready, and the
+		// pollable must be safe to drop as soon as WaitContext returns.
+		for i := range 50 {
+			d := time.Duration(i%5) * time.Millisecond
+			p := monotonicclock.SubscribeDuration(monotonicclock.Duration(d))
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(d, cancel)
+			err := poll.WaitContext(ctx, p)
+			if err == nil {
+				assert.True(t, p.Ready(), "iteration %d", i)
+			} else {
+				assert.ErrorIs(t, err, context.Canceled, "iteration %d", i)
+			}
+			p.ResourceDrop()
+			cancel()
+		}
+
+		// The reactor keeps serving later waiters
+		p := monotonicclock.SubscribeDuration(monotonicclock.Duration(10 * time.Millisecond))
+		defer p.ResourceDrop()
+		assert.True(t, poll.WaitTimeout(p, 5*time.Second))
+	})
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.wasmcloud.dev/component/net/wasisocket"
+	"go.wasmcloud.dev/wadge"
+)
+
+// echo serves a line-based echo protocol on conn until it is closed.
+func echo(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		if _, err := conn.Write([]byte(line)); err != nil {
+			return
+		}
+	}
+}
+
+func TestSocketDial(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go echo(conn)
+		}
+	}()
+
+	wadge.RunTest(t, func() {
+		d := &wasisocket.Dialer{Timeout: 5 * time.Second}
+		conn, err := d.DialContext(context.Background(), "tcp", ln.Addr().String())
+		require.NoError(t, err)
+		defer conn.Close()
+
+		assert.Equal(t, ln.Addr().String(), conn.RemoteAddr().String())
+
+		require.NoError(t, conn.SetDeadline(time.Now().Add(5*time.Second)))
+		_, err = conn.Write([]byte("hello\n"))
+		require.NoError(t, err)
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, "hello\n", line)
+
+		// Nothing more is sent, so the read deadline expires
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(50*time.Millisecond)))
+		_, err = conn.Read(make([]byte, 1))
+		var netErr net.Error
+		if assert.ErrorAs(t, err, &netErr) {
+			assert.True(t, netErr.Timeout())
+		}
+	})
+}
+
+func TestSocketDialRefused(t *testing.T) {
+	// Reserve a port, then close it so nothing is listening
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	ln.Close()
+
+	wadge.RunTest(t, func() {
+		_, err := wasisocket.Dial("tcp", addr)
+		assert.ErrorIs(t, err, syscall.ECONNREFUSED)
+	})
+}
+
+func TestSocketListen(t *testing.T) {
+	wadge.RunTest(t, func() {
+		ln, err := wasisocket.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer ln.Close()
+
+		addr := ln.Addr().String()
+		done := make(chan error, 1)
+		go func() {
+			conn, err := net.DialTimeout("tcp", addr, 5*time.Second)
+			if err != nil {
+				done <- err
+				return
+			}
+			defer conn.Close()
+			if _, err := conn.Write([]byte("hello\n")); err != nil {
+				done <- err
+				return
+			}
+			line, err := bufio.NewReader(conn).ReadString('\n')
+			if err == nil && line != "hello\n" {
+				err = fmt.Errorf("unexpected echo %q", line)
+			}
+			done <- err
+		}()
+
+		conn, err := ln.Accept()
+		require.NoError(t, err)
+		require.NoError(t, conn.SetDeadline(time.Now().Add(5*time.Second)))
+		go echo(conn)
+		require.NoError(t, <-done)
+	})
+}
+
+func TestSocketUDP(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer pc.Close()
+	go func() {
+		buf := make([]byte, 1500)
+		for {
+			n, addr, err := pc.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			pc.WriteTo(buf[:n], addr)
+		}
+	}()
+
+	wadge.RunTest(t, func() {
+		// A dialed socket is connected and echoes come back to it
+		conn, err := wasisocket.Dial("udp", pc.LocalAddr().String())
+		require.NoError(t, err)
+		defer conn.Close()
+		require.NoError(t, conn.SetDeadline(time.Now().Add(5*time.Second)))
+		_, err = conn.Write([]byte("ping"))
+		require.NoError(t, err)
+		buf := make([]byte, 16)
+		n, err := conn.Read(buf)
+		require.NoError(t, err)
+		assert.Equal(t, "ping", string(buf[:n]))
+
+		// An unconnected socket reports the sender of each datagram
+		lc, err := wasisocket.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer lc.Close()
+		require.NoError(t, lc.SetDeadline(time.Now().Add(5*time.Second)))
+		_, err = lc.WriteTo([]byte("pong"), pc.LocalAddr())
+		require.NoError(t, err)
+		n, addr, err := lc.ReadFrom(buf)
+		require.NoError(t, err)
+		assert.Equal(t, "pong", string(buf[:n]))
+		assert.Equal(t, pc.LocalAddr().String(), addr.String())
+	})
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.wasmcloud.dev/component/net/wasihttp"
+	"go.wasmcloud.dev/wadge"
+	"go.wasmcloud.dev/wadge/wadgehttp"
+)
+
+// grpcHandler answers like a gRPC server: it announces its status trailers,
+// writes the message and sets the trailers once done.
+func grpcHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/grpc")
+	w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("\x00\x00\x00\x00\x02hi"))
+	w.Header().Set("Grpc-Status", "0")
+	w.Header().Set("Grpc-Message", "OK")
+	w.Header().Set(http.TrailerPrefix+"Grpc-Status-Details-Bin", "e30")
+	w.Header().Set("X-Late-Header", "ignored")
+}
+
+func TestResponseTrailers(t *testing.T) {
+	wadge.RunTest(t, func() {
+		req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader("\x00\x00\x00\x00\x00"))
+		if err != nil {
+			t.Fatalf("failed to create new HTTP request: %s", err)
+		}
+		resp, err := wadgehttp.HandleIncomingRequest(serve(grpcHandler), req)
+		if err != nil {
+			t.Fatalf("failed to handle incoming HTTP request: %s", err)
+		}
+		defer resp.Body.Close()
+		buf, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("failed to read HTTP response body: %s", err)
+		}
+		assert.Equal(t, []byte("\x00\x00\x00\x00\x02hi"), buf)
+		assert.Empty(t, resp.Header.Get("Grpc-Status"))
+		assert.Equal(t, "0", resp.Trailer.Get("Grpc-Status"))
+		assert.Equal(t, "OK", resp.Trailer.Get("Grpc-Message"))
+		assert.Equal(t, "e30", resp.Trailer.Get("Grpc-Status-Details-Bin"))
+		assert.Empty(t, resp.Trailer.Get("X-Late-Header"))
+	})
+}
+
+func TestRequestTrailers(t *testing.T) {
+	wadge.RunTest(t, func() {
+		var declared, got http.Header
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			declared = r.Trailer.Clone()
+			_, _ = io.Copy(io.Discard, r.Body)
+			got = r.Trailer.Clone()
+		}
+
+		req, err := http.NewRequest(http.MethodPost, "/", io.NopCloser(strings.NewReader("payload")))
+		if err != nil {
+			t.Fatalf("failed to create new HTTP request: %s", err)
+		}
+		req.ContentLength = -1
+		req.Header.Set("Trailer", "Grpc-Timeout")
+		req.Trailer = http.Header{"Grpc-Timeout": {"1S"}}
+		resp, err := wadgehttp.HandleIncomingRequest(serve(handler), req)
+		if err != nil {
+			t.Fatalf("failed to handle incoming HTTP request: %s", err)
+		}
+		resp.Body.Close()
+
+		assert.Contains(t, declared, "Grpc-Timeout")
+		assert.Equal(t, "1S", got.Get("Grpc-Timeout"))
+	})
+}
+
+func TestTransportTrailers(t *testing.T) {
+	var serverTrailer http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		serverTrailer = r.Trailer.Clone()
+		grpcHandler(w, r)
+	}))
+	defer srv.Close()
+
+	wadge.RunTest(t, func() {
+		body := strings.NewReader("\x00\x00\x00\x00\x00")
+		req, err := http.NewRequest(http.MethodPost, srv.URL, io.NopCloser(body))
+		if err != nil {
+			t.Fatalf("failed to create new HTTP request: %s", err)
+		}
+		req.ContentLength = -1
+		req.Trailer = http.Header{"Grpc-Timeout": nil}
+		req.Body = &trailerSettingBody{Reader: body, set: func() { req.Trailer.Set("Grpc-Timeout", "1S") }}
+
+		resp, err := (&wasihttp.Transport{}).RoundTrip(req)
+		if err != nil {
+			t.Fatalf("failed to round trip: %s", err)
+		}
+		defer resp.Body.Close()
+
+		assert.Contains(t, resp.Trailer, "Grpc-Status")
+		_, err = io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("failed to read HTTP response body: %s", err)
+		}
+		assert.Equal(t, "0", resp.Trailer.Get("Grpc-Status"))
+		assert.Equal(t, "OK", resp.Trailer.Get("Grpc-Message"))
+		assert.Equal(t, "1S", serverTrailer.Get("Grpc-Timeout"))
+	})
+}
+
+// trailerSettingBody calls set once the body is fully read, like a client
+// streaming a request and computing its trailers at the end.
+type trailerSettingBody struct {
+	io.Reader
+	set func()
+}
+
+func (b *trailerSettingBody) Read(p []byte) (int, error) {
+	n, err := b.Reader.Read(p)
+	if err == io.EOF {
+		b.set()
+	}
+	return n, err
+}
+
+func (b *trailerSettingBody) Close() error {
+	return nil
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.wasmcloud.dev/component/net/wasihttp"
+	"go.wasmcloud.dev/wadge"
+)
+
+func TestTransportContextDeadline(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	wadge.RunTest(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+		if err != nil {
+			t.Fatalf("failed to create new HTTP request: %s", err)
+		}
+		start := time.Now()
+		_, err = (&wasihttp.Transport{}).RoundTrip(req)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 2*time.Second)
+	})
+}
+
+func TestTransportContextCanceled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	wadge.RunTest(t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+		if err != nil {
+			t.Fatalf("failed to create new HTTP request: %s", err)
+		}
+		_, err = (&wasihttp.Transport{}).RoundTrip(req)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestTransportFirstByteTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	wadge.RunTest(t, func() {
+		req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+		if err != nil {
+			t.Fatalf("failed to create new HTTP request: %s", err)
+		}
+		_, err = (&wasihttp.Transport{FirstByteTimeout: 100 * time.Millisecond}).RoundTrip(req)
+		var netErr net.Error
+		if assert.ErrorAs(t, err, &netErr) {
+			assert.True(t, netErr.Timeout())
+		}
+		var wasiErr *wasihttp.Error
+		assert.ErrorAs(t, err, &wasiErr)
+	})
+}
+
+func TestTransportConnectionRefused(t *testing.T) {
+	// Reserve a port, then close it so nothing is listening
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %s", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	wadge.RunTest(t, func() {
+		req, err := http.NewRequest(http.MethodGet, "http://"+addr, nil)
+		if err != nil {
+			t.Fatalf("failed to create new HTTP request: %s", err)
+		}
+		_, err = (&wasihttp.Transport{}).RoundTrip(req)
+		assert.ErrorIs(t, err, syscall.ECONNREFUSED)
+		var netErr net.Error
+		if assert.ErrorAs(t, err, &netErr) {
+			assert.False(t, netErr.Timeout())
+		}
+	})
+}
+
+func TestTransportDNSError(t *testing.T) {
+	// The host resolves names for the component, so skip unless it can
+	// answer for a reserved name, e.g. when offline
+	if _, err := net.LookupHost("does-not-exist.invalid"); err == nil {
+		t.Skip("host resolver answers for .invalid names")
+	} else if dnsErr, ok := err.(*net.DNSError); !ok || !dnsErr.IsNotFound {
+		t.Skipf("host DNS unavailable: %v", err)
+	}
+
+	wadge.RunTest(t, func() {
+		req, err := http.NewRequest(http.MethodGet, "http://does-not-exist.invalid", nil)
+		if err != nil {
+			t.Fatalf("failed to create new HTTP request: %s", err)
+		}
+		_, err = (&wasihttp.Transport{}).RoundTrip(req)
+		var dnsErr *net.DNSError
+		if assert.ErrorAs(t, err, &dnsErr) {
+			assert.Equal(t, "does-not-exist.invalid", dnsErr.Name)
+		}
+	})
+}
+
+func TestTransportBodyContextCanceled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("partial"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	wadge.RunTest(t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+		if err != nil {
+			t.Fatalf("failed to create new HTTP request: %s", err)
+		}
+		resp, err := (&wasihttp.Transport{}).RoundTrip(req)
+		if err != nil {
+			t.Fatalf("failed to round trip: %s", err)
+		}
+		defer resp.Body.Close()
+
+		buf := make([]byte, len("partial"))
+		_, err = io.ReadFull(resp.Body, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, "partial", string(buf))
+
+		// The server never finishes the body, so only the context ends the read
+		time.AfterFunc(50*time.Millisecond, cancel)
+		_, err = resp.Body.Read(buf)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -3,51 +3,15 @@
 package main
 
 import (
-	"io"
+	"context"
 	"log"
 	"net/http"
-	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
 	"go.wasmcloud.dev/provider"
 	"go.wasmcloud.dev/provider/wrpchttp"
 )
 
-type Server struct {
-	wasiIncomingHandler *http.Client
-}
-
-func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	// NOTE(lxf): net/http doesn't allow 'RequestURI' to be present in outbound requests, so we clear it here.
-	// https://go.dev/src/net/http/client.go
-	r.RequestURI = ""
-
-	resp, err := s.wasiIncomingHandler.Do(r)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer resp.Body.Close()
-
-	for k, vals := range resp.Header {
-		for _, v := range vals {
-			w.Header().Add(k, v)
-		}
-	}
-
-	w.WriteHeader(resp.StatusCode)
-
-	io.Copy(w, resp.Body)
-
-	for k, vals := range resp.Trailer {
-		for _, v := range vals {
-			w.Header().Add(k, v)
-		}
-	}
-}
-
 func main() {
 	// NOTE(lxf): Enable wrpc debugging
 	// lvl := new(slog.LevelVar)
@@ -67,47 +31,27 @@ func serveLocal(w http.ResponseWriter, r *http.Request) {
 }
 
 func run() error {
-	wasmcloudprovider, err := provider.New()
+	// Components are routed by the `path` config of their link
+	router := wrpchttp.NewPathRouter()
+
+	wasmcloudprovider, err := provider.New(
+		provider.SourceLinkPut(router.PutLink),
+		provider.SourceLinkDel(router.DelLink),
+	)
 	if err != nil {
 		return err
 	}
 
-	providerCh := make(chan error, 1)
-	httpCh := make(chan error, 1)
-	signalCh := make(chan os.Signal, 1)
-
-	proxyServer := &Server{
-		wasiIncomingHandler: &http.Client{
-			Transport: wrpchttp.NewIncomingRoundTripper(wasmcloudprovider, wrpchttp.WithSingleTarget("http-http_component")),
-			Timeout:   time.Second * 5,
-		},
-	}
-
-	// Handle control interface operations
-	go func() {
-		err := wasmcloudprovider.Start()
-		providerCh <- err
-	}()
-
-	go func() {
-		mux := http.NewServeMux()
-		mux.Handle("/proxy", proxyServer)
-		mux.Handle("/", http.HandlerFunc(serveLocal))
-		err := http.ListenAndServe(":8080", mux)
-		httpCh <- err
-	}()
-
-	// Shutdown on SIGINT
-	signal.Notify(signalCh, syscall.SIGINT)
+	proxy := wrpchttp.NewProxy(
+		wrpchttp.NewIncomingRoundTripper(wasmcloudprovider, wrpchttp.WithRouter(router)),
+		wrpchttp.WithTimeout(5*time.Second),
+	)
 
-	select {
-	case err = <-providerCh:
-		return err
-	case <-httpCh:
-		wasmcloudprovider.Shutdown()
-	case <-signalCh:
-		wasmcloudprovider.Shutdown()
-	}
+	mux := http.NewServeMux()
+	mux.Handle("/proxy", proxy)
+	mux.Handle("/", http.HandlerFunc(serveLocal))
+	httpServer := &http.Server{Addr: ":8080", Handler: mux}
 
-	return nil
+	// Run until the host shuts us down, the http server fails, or we receive SIGINT/SIGTERM
+	return wasmcloudprovider.Run(context.Background(), provider.HTTPService(httpServer))
 }
@@ -1,3 +1,77 @@
 // Generated by `wit-bindgen-wrpc-go` 0.9.1. DO NOT EDIT!
 // internal package contains wRPC bindings for `internal` world
 package internal
+
+import (
+	exports__wasmcloud__messaging__consumer "go.wasmcloud.dev/provider/internal/exports/wasmcloud/messaging/consumer"
+	exports__wrpc__blobstore__blobstore "go.wasmcloud.dev/provider/internal/exports/wrpc/blobstore/blobstore"
+	exports__wrpc__http__outgoing_handler "go.wasmcloud.dev/provider/internal/exports/wrpc/http/outgoing_handler"
+	exports__wrpc__keyvalue__atomics "go.wasmcloud.dev/provider/internal/exports/wrpc/keyvalue/atomics"
+	exports__wrpc__keyvalue__batch "go.wasmcloud.dev/provider/internal/exports/wrpc/keyvalue/batch"
+	exports__wrpc__keyvalue__store "go.wasmcloud.dev/provider/internal/exports/wrpc/keyvalue/store"
+	wrpc "wrpc.io/go"
+)
+
+func Serve(s wrpc.Server, h0 exports__wrpc__http__outgoing_handler.Handler, h1 exports__wrpc__keyvalue__store.Handler, h2 exports__wrpc__keyvalue__atomics.Handler, h3 exports__wrpc__keyvalue__batch.Handler, h4 exports__wrpc__blobstore__blobstore.Handler, h5 exports__wasmcloud__messaging__consumer.Handler) (stop func() error, err error) {
+	stops := make([]func() error, 0, 6)
+	stop = func() error {
+		for _, stop := range stops {
+			if err := stop(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	stop0, err := exports__wrpc__http__outgoing_handler.ServeInterface(s, h0)
+	if err != nil {
+		return
+	}
+	stops = append(stops, stop0)
+	stop1, err := exports__wrpc__keyvalue__store.ServeInterface(s, h1)
+	if err != nil {
+		return
+	}
+	stops = append(stops, stop1)
+	stop2, err := exports__wrpc__keyvalue__atomics.ServeInterface(s, h2)
+	if err != nil {
+		return
+	}
+	stops = append(stops, stop2)
+	stop3, err := exports__wrpc__keyvalue__batch.ServeInterface(s, h3)
+	if err != nil {
+		return
+	}
+	stops = append(stops, stop3)
+	stop4, err := exports__wrpc__blobstore__blobstore.ServeInterface(s, h4)
+	if err != nil {
+		return
+	}
+	stops = append(stops, stop4)
+	stop5, err := exports__wasmcloud__messaging__consumer.ServeInterface(s, h5)
+	if err != nil {
+		return
+	}
+	stops = append(stops, stop5)
+	stop = func() error {
+		if err := stop0(); err != nil {
+			return err
+		}
+		if err := stop1(); err != nil {
+			return err
+		}
+		if err := stop2(); err != nil {
+			return err
+		}
+		if err := stop3(); err != nil {
+			return err
+		}
+		if err := stop4(); err != nil {
+			return err
+		}
+		if err := stop5(); err != nil {
+			return err
+		}
+		return nil
+	}
+	return
+}
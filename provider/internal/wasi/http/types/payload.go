@@ -0,0 +1,7 @@
+package types
+
+// Payload returns the payload of v as stored by its setters and decoder,
+// a pointer for record cases.
+//
+// The generated getters assert value types and so never match those payloads.
+func (v *ErrorCode) Payload() any { return v.payload }
@@ -1,5 +1,15 @@
 package provider
 
+import (
+	"context"
+
+	wrpcnats "wrpc.io/go/nats"
+)
+
+// SourceIDHeader is the invocation header set by the host to the ID of the
+// calling component.
+const SourceIDHeader = "source-id"
+
 // NOTE(brooksmtownsend): There might be a better way to represent this in Go, please comment
 // or leave an issue if you can think of one. Perhaps I could do the decryption during the
 // unmarshalling process, but I'm not sure if that would be a good idea.
@@ -17,6 +27,12 @@ type linkWithEncryptedSecrets struct {
 	// Serialized & encrypted secrets. Should decrypt + deserialize into map[string]SecretValue
 }
 
+// InterfaceLinkDefinition describes a link between a component and a
+// provider. SourceSecrets and TargetSecrets are only set on links returned
+// by [WasmcloudProvider.DecryptLinkSecrets]. Links stored by the provider
+// and passed to link callbacks have no secrets. Use
+// [WasmcloudProvider.LinkSecrets] to access the secrets of the provider
+// side.
 type InterfaceLinkDefinition struct {
 	SourceID      string                 `json:"source_id,omitempty"`
 	Target        string                 `json:"target,omitempty"`
@@ -29,3 +45,15 @@ type InterfaceLinkDefinition struct {
 	SourceSecrets map[string]SecretValue `json:"source_secrets,omitempty"`
 	TargetSecrets map[string]SecretValue `json:"target_secrets,omitempty"`
 }
+
+// SourceIDFromContext returns the ID of the component that made the
+// invocation served with ctx, reporting false when the host didn't set it.
+// Target link handlers use it to find the link of the caller.
+func SourceIDFromContext(ctx context.Context) (string, bool) {
+	header, ok := wrpcnats.HeaderFromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	id := header.Get(SourceIDHeader)
+	return id, id != ""
+}
@@ -0,0 +1,25 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	nats "github.com/nats-io/nats.go"
+	wrpcnats "wrpc.io/go/nats"
+)
+
+func TestSourceIDFromContext(t *testing.T) {
+	if _, ok := SourceIDFromContext(context.Background()); ok {
+		t.Error("expected no source ID without an invocation header")
+	}
+
+	ctx := wrpcnats.ContextWithHeader(context.Background(), nats.Header{})
+	if _, ok := SourceIDFromContext(ctx); ok {
+		t.Error("expected no source ID without the source ID header")
+	}
+
+	ctx = wrpcnats.ContextWithHeader(context.Background(), nats.Header{SourceIDHeader: {"component"}})
+	if id, ok := SourceIDFromContext(ctx); !ok || id != "component" {
+		t.Errorf("expected source ID component, got %q (%v)", id, ok)
+	}
+}
@@ -30,6 +30,16 @@ func TargetLinkDel(inFunc func(InterfaceLinkDefinition) error) ProviderHandler {
 	}
 }
 
+// SecretsRotated is called when a link is put again with new secrets for the
+// provider. The secrets returned by [WasmcloudProvider.LinkSecrets] are
+// already rotated when it runs.
+func SecretsRotated(inFunc func(InterfaceLinkDefinition) error) ProviderHandler {
+	return func(wp *WasmcloudProvider) error {
+		wp.secretsRotatedFunc = inFunc
+		return nil
+	}
+}
+
 func Shutdown(inFunc func() error) ProviderHandler {
 	return func(wp *WasmcloudProvider) error {
 		wp.shutdownFunc = inFunc
@@ -5,14 +5,14 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"log/slog"
+	"maps"
 	"os"
-	"os/signal"
 	"sync"
-	"syscall"
 	"time"
 
 	nats "github.com/nats-io/nats.go"
@@ -51,11 +51,16 @@ type WasmcloudProvider struct {
 	// They are called after the user provided `shutdownFunc` and nats disconnect.
 	internalShutdownFuncs []func(context.Context) error
 	shutdown              chan struct{}
+	// shutdownOnce guards against running the shutdown sequence twice, e.g.
+	// when the host requests a shutdown while [WasmcloudProvider.Run] is active.
+	shutdownOnce sync.Once
+	shutdownErr  error
 
-	putSourceLinkFunc func(InterfaceLinkDefinition) error
-	putTargetLinkFunc func(InterfaceLinkDefinition) error
-	delSourceLinkFunc func(InterfaceLinkDefinition) error
-	delTargetLinkFunc func(InterfaceLinkDefinition) error
+	putSourceLinkFunc  func(InterfaceLinkDefinition) error
+	putTargetLinkFunc  func(InterfaceLinkDefinition) error
+	delSourceLinkFunc  func(InterfaceLinkDefinition) error
+	delTargetLinkFunc  func(InterfaceLinkDefinition) error
+	secretsRotatedFunc func(InterfaceLinkDefinition) error
 
 	lock sync.Mutex
 	// Links from the provider to other components, aka where the provider is the
@@ -64,6 +69,13 @@ type WasmcloudProvider struct {
 	// Links from other components to the provider, aka where the provider is the
 	// target of the link. Indexed by the component ID of the source
 	targetLinks map[string]InterfaceLinkDefinition
+
+	// secretsLock guards linkSecrets, so that link callbacks holding lock can
+	// look up secrets.
+	secretsLock sync.Mutex
+	// Secrets of the provider side of each link, indexed by link ID then by
+	// secret name
+	linkSecrets map[string]map[string]*Secret
 }
 
 func New(options ...ProviderHandler) (*WasmcloudProvider, error) {
@@ -216,9 +228,6 @@ func NewWithHostDataSource(source io.Reader, options ...ProviderHandler) (*Wasmc
 	prefix := fmt.Sprintf("%s.%s", hostData.LatticeRPCPrefix, hostData.ProviderKey)
 	wrpc := wrpcnats.NewClient(nc, wrpcnats.WithPrefix(prefix), wrpcnats.WithGroup(prefix))
 
-	signalCh := make(chan os.Signal, 1)
-	signal.Notify(signalCh, syscall.SIGINT)
-
 	ctx, cancel := context.WithCancel(context.Background())
 	provider := &WasmcloudProvider{
 		ID:        hostData.ProviderKey,
@@ -242,13 +251,15 @@ func NewWithHostDataSource(source io.Reader, options ...ProviderHandler) (*Wasmc
 		internalShutdownFuncs: internalShutdownFuncs,
 		shutdown:              make(chan struct{}),
 
-		putSourceLinkFunc: func(InterfaceLinkDefinition) error { return nil },
-		putTargetLinkFunc: func(InterfaceLinkDefinition) error { return nil },
-		delSourceLinkFunc: func(InterfaceLinkDefinition) error { return nil },
-		delTargetLinkFunc: func(InterfaceLinkDefinition) error { return nil },
+		putSourceLinkFunc:  func(InterfaceLinkDefinition) error { return nil },
+		putTargetLinkFunc:  func(InterfaceLinkDefinition) error { return nil },
+		delSourceLinkFunc:  func(InterfaceLinkDefinition) error { return nil },
+		delTargetLinkFunc:  func(InterfaceLinkDefinition) error { return nil },
+		secretsRotatedFunc: func(InterfaceLinkDefinition) error { return nil },
 
 		sourceLinks: make(map[string]InterfaceLinkDefinition, len(sourceLinks)),
 		targetLinks: make(map[string]InterfaceLinkDefinition, len(targetLinks)),
+		linkSecrets: make(map[string]map[string]*Secret),
 	}
 
 	for _, opt := range options {
@@ -321,27 +332,49 @@ func (wp *WasmcloudProvider) Start() error {
 }
 
 func (wp *WasmcloudProvider) Shutdown() error {
-	err := wp.shutdownFunc()
-	if err != nil {
-		wp.cancel()
-		return err
+	return wp.shutdownOnceWith(nil)
+}
+
+// shutdownOnceWith runs the shutdown sequence unless it already ran. beforeDrain,
+// if not nil, is called while the NATS connection is still usable, or right
+// away when another caller has already shut the provider down.
+func (wp *WasmcloudProvider) shutdownOnceWith(beforeDrain func()) error {
+	ran := false
+	wp.shutdownOnce.Do(func() {
+		ran = true
+		wp.shutdownErr = wp.shutdownProvider(beforeDrain)
+	})
+	if !ran && beforeDrain != nil {
+		beforeDrain()
+	}
+	return wp.shutdownErr
+}
+
+// shutdownProvider calls the user shutdown function, drains NATS and flushes
+// the observability exporters. Every step runs even if an earlier one failed.
+func (wp *WasmcloudProvider) shutdownProvider(beforeDrain func()) error {
+	defer wp.cancel()
+
+	var errs []error
+	if err := wp.shutdownFunc(); err != nil {
+		errs = append(errs, err)
 	}
 
-	err = wp.cleanupNatsSubscriptions()
-	if err != nil {
-		wp.cancel()
-		return err
+	if beforeDrain != nil {
+		beforeDrain()
+	}
+
+	if err := wp.cleanupNatsSubscriptions(); err != nil {
+		errs = append(errs, err)
 	}
 
 	for _, errFunc := range wp.internalShutdownFuncs {
 		if err := errFunc(wp.context); err != nil {
-			wp.cancel()
-			return err
+			errs = append(errs, err)
 		}
 	}
 
-	wp.cancel()
-	return nil
+	return errors.Join(errs...)
 }
 
 func (wp *WasmcloudProvider) subToNats() error {
@@ -428,24 +461,19 @@ func (wp *WasmcloudProvider) subToNats() error {
 	// ------------------ Subscribe to Shutdown topic ------------------
 	shutdown, err := wp.natsConnection.Subscribe(wp.Topics.LatticeShutdown,
 		func(m *nats.Msg) {
-			err := wp.shutdownFunc()
+			// The host waits for this reply, so it is sent even when the
+			// provider was already shut down by [WasmcloudProvider.Run].
+			err := wp.shutdownOnceWith(func() {
+				err := m.Respond([]byte("provider shutdown handled successfully"))
+				if err != nil {
+					// NOTE: This is a log message because we don't want to stop the shutdown process
+					wp.Logger.Error("ERROR: provider shutdown failed to respond: " + err.Error())
+				}
+			})
 			if err != nil {
 				// TODO(#10): handle this better?
-				wp.Logger.Error("ERROR: provider shutdown function failed: " + err.Error())
-			}
-
-			err = m.Respond([]byte("provider shutdown handled successfully"))
-			if err != nil {
-				// NOTE: This is a log message because we don't want to stop the shutdown process
-				wp.Logger.Error("ERROR: provider shutdown failed to respond: " + err.Error())
+				wp.Logger.Error("ERROR: provider shutdown failed: " + err.Error())
 			}
-
-			err = wp.cleanupNatsSubscriptions()
-			if err != nil {
-				wp.Logger.Error("ERROR: provider shutdown failed to drain connection: " + err.Error())
-			}
-
-			wp.cancel()
 		})
 	if err != nil {
 		wp.Logger.Error("LatticeShutdown", slog.Any("error", err))
@@ -499,71 +527,88 @@ func (wp *WasmcloudProvider) DecryptLinkSecrets(h linkWithEncryptedSecrets) (Int
 }
 
 func (wp *WasmcloudProvider) putLink(l InterfaceLinkDefinition) error {
-	// Ignore duplicate links
+	// Past this point the secrets only live in the holders from LinkSecrets
+	defer wipeLinkSecrets(l)
+
+	// Duplicate links are only checked for rotated secrets
 	if wp.isLinked(l.SourceID, l.Target) {
-		wp.Logger.Info("ignoring duplicate link", "link", l)
-		return nil
+		return wp.rotateLinkSecrets(l)
 	}
 
 	wp.lock.Lock()
 	defer wp.lock.Unlock()
 	if l.SourceID == wp.ID {
-		err := wp.putSourceLinkFunc(l)
+		wp.storeLinkSecrets(l)
+		link := withoutSecrets(l)
+		err := wp.putSourceLinkFunc(link)
 		if err != nil {
+			wp.clearLinkSecrets(l)
 			return err
 		}
 
-		wp.sourceLinks[l.Target] = l
+		wp.sourceLinks[l.Target] = link
 	} else if l.Target == wp.ID {
-		err := wp.putTargetLinkFunc(l)
+		wp.storeLinkSecrets(l)
+		link := withoutSecrets(l)
+		err := wp.putTargetLinkFunc(link)
 		if err != nil {
+			wp.clearLinkSecrets(l)
 			return err
 		}
 
-		wp.targetLinks[l.SourceID] = l
+		wp.targetLinks[l.SourceID] = link
 	} else {
-		wp.Logger.Info("received link that isn't for this provider, ignoring", "link", l)
+		wp.Logger.Info("received link that isn't for this provider, ignoring", "link", withoutSecrets(l))
 	}
 	return nil
 }
 
 func (wp *WasmcloudProvider) updateProviderLinkMap(l InterfaceLinkDefinition) error {
+	defer wipeLinkSecrets(l)
+
 	// Ignore duplicate links
 	if wp.isLinked(l.SourceID, l.Target) {
-		wp.Logger.Info("ignoring duplicate link", "link", l)
+		wp.Logger.Info("ignoring duplicate link", "link", withoutSecrets(l))
 		return nil
 	}
 	wp.lock.Lock()
 	defer wp.lock.Unlock()
 	if l.SourceID == wp.ID {
-		wp.sourceLinks[l.Target] = l
+		wp.storeLinkSecrets(l)
+		wp.sourceLinks[l.Target] = withoutSecrets(l)
 	} else if l.Target == wp.ID {
-		wp.targetLinks[l.SourceID] = l
+		wp.storeLinkSecrets(l)
+		wp.targetLinks[l.SourceID] = withoutSecrets(l)
 	} else {
-		wp.Logger.Info("received link that isn't for this provider, ignoring", "link", l)
+		wp.Logger.Info("received link that isn't for this provider, ignoring", "link", withoutSecrets(l))
 	}
 	return nil
 }
 
 func (wp *WasmcloudProvider) deleteLink(l InterfaceLinkDefinition) error {
+	defer wipeLinkSecrets(l)
+	link := withoutSecrets(l)
+
 	wp.lock.Lock()
 	defer wp.lock.Unlock()
 	if l.SourceID == wp.ID {
-		err := wp.delSourceLinkFunc(l)
+		err := wp.delSourceLinkFunc(link)
 		if err != nil {
 			return err
 		}
 
 		delete(wp.sourceLinks, l.Target)
+		wp.clearLinkSecrets(l)
 	} else if l.Target == wp.ID {
-		err := wp.delTargetLinkFunc(l)
+		err := wp.delTargetLinkFunc(link)
 		if err != nil {
 			return err
 		}
 
 		delete(wp.targetLinks, l.SourceID)
+		wp.clearLinkSecrets(l)
 	} else {
-		wp.Logger.Info("received link delete that isn't for this provider, ignoring", "link", l)
+		wp.Logger.Info("received link delete that isn't for this provider, ignoring", "link", link)
 	}
 
 	return nil
@@ -581,3 +626,123 @@ func (wp *WasmcloudProvider) isLinked(sourceID string, target string) bool {
 	}
 	return false
 }
+
+// LinkSecrets returns the secrets of the provider side of a link, indexed by
+// name. They are rotated in place when the link is put again with new
+// secrets, and cleared once the link is deleted.
+func (wp *WasmcloudProvider) LinkSecrets(l InterfaceLinkDefinition) map[string]*Secret {
+	wp.secretsLock.Lock()
+	defer wp.secretsLock.Unlock()
+	return maps.Clone(wp.linkSecrets[linkID(l)])
+}
+
+// providerSecrets returns the secrets meant for the provider side of a link.
+func (wp *WasmcloudProvider) providerSecrets(l InterfaceLinkDefinition) map[string]SecretValue {
+	if l.SourceID == wp.ID {
+		return l.SourceSecrets
+	}
+	return l.TargetSecrets
+}
+
+func (wp *WasmcloudProvider) storeLinkSecrets(l InterfaceLinkDefinition) {
+	secrets := make(map[string]*Secret)
+	for name, v := range wp.providerSecrets(l) {
+		secrets[name] = newSecretFromValue(v)
+	}
+
+	wp.secretsLock.Lock()
+	defer wp.secretsLock.Unlock()
+	for _, secret := range wp.linkSecrets[linkID(l)] {
+		secret.Clear()
+	}
+	wp.linkSecrets[linkID(l)] = secrets
+}
+
+func (wp *WasmcloudProvider) clearLinkSecrets(l InterfaceLinkDefinition) {
+	wp.secretsLock.Lock()
+	defer wp.secretsLock.Unlock()
+	for _, secret := range wp.linkSecrets[linkID(l)] {
+		secret.Clear()
+	}
+	delete(wp.linkSecrets, linkID(l))
+}
+
+// rotateLinkSecrets updates the secrets of a link put again, wiping the old
+// ones, and notifies the provider. Links put again with the same secrets are
+// ignored.
+func (wp *WasmcloudProvider) rotateLinkSecrets(l InterfaceLinkDefinition) error {
+	wp.lock.Lock()
+	links, key := wp.targetLinks, l.SourceID
+	if l.SourceID == wp.ID {
+		links, key = wp.sourceLinks, l.Target
+	}
+	values := wp.providerSecrets(l)
+
+	wp.secretsLock.Lock()
+	secrets := wp.linkSecrets[linkID(l)]
+	if _, ok := links[key]; !ok || secretsMatch(secrets, values) {
+		wp.secretsLock.Unlock()
+		wp.lock.Unlock()
+		wp.Logger.Info("ignoring duplicate link", "link", withoutSecrets(l))
+		return nil
+	}
+
+	if secrets == nil {
+		secrets = make(map[string]*Secret)
+		wp.linkSecrets[linkID(l)] = secrets
+	}
+	for name, secret := range secrets {
+		if _, ok := values[name]; !ok {
+			secret.Clear()
+			delete(secrets, name)
+		}
+	}
+	for name, v := range values {
+		if secret, ok := secrets[name]; ok {
+			rotated := newSecretFromValue(v)
+			secret.Rotate(rotated.kind, rotated.value)
+			rotated.Clear()
+		} else {
+			secrets[name] = newSecretFromValue(v)
+		}
+	}
+	wp.secretsLock.Unlock()
+
+	link := withoutSecrets(l)
+	links[key] = link
+	wp.lock.Unlock()
+
+	wp.Logger.Info("rotated link secrets", "source", l.SourceID, "target", l.Target)
+	return wp.secretsRotatedFunc(link)
+}
+
+// secretsMatch reports whether secrets hold exactly values.
+func secretsMatch(secrets map[string]*Secret, values map[string]SecretValue) bool {
+	if len(secrets) != len(values) {
+		return false
+	}
+	for name, v := range values {
+		secret, ok := secrets[name]
+		if !ok || !secret.matches(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// withoutSecrets returns a copy of l without its secrets, as stored by the
+// provider and passed to link callbacks.
+func withoutSecrets(l InterfaceLinkDefinition) InterfaceLinkDefinition {
+	l.SourceSecrets = nil
+	l.TargetSecrets = nil
+	return l
+}
+
+func wipeLinkSecrets(l InterfaceLinkDefinition) {
+	wipeSecretValues(l.SourceSecrets)
+	wipeSecretValues(l.TargetSecrets)
+}
+
+func linkID(l InterfaceLinkDefinition) string {
+	return l.SourceID + "/" + l.Target
+}
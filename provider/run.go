@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+const (
+	serviceStopTimeout = 30 * time.Second
+)
+
+// Service is a long-running component of a provider, such as an HTTP server,
+// whose lifecycle is managed by [WasmcloudProvider.Run].
+type Service interface {
+	// Start runs the service and blocks until it stops. Returning an error
+	// triggers the shutdown of the whole provider.
+	Start(ctx context.Context) error
+	// Stop gracefully stops the service, causing Start to return. It must be
+	// safe to call after Start has already returned.
+	Stop(ctx context.Context) error
+}
+
+type serviceFuncs struct {
+	start func(context.Context) error
+	stop  func(context.Context) error
+}
+
+func (s serviceFuncs) Start(ctx context.Context) error { return s.start(ctx) }
+func (s serviceFuncs) Stop(ctx context.Context) error  { return s.stop(ctx) }
+
+// NewService builds a [Service] from a start and stop function pair.
+func NewService(start func(context.Context) error, stop func(context.Context) error) Service {
+	return serviceFuncs{start: start, stop: stop}
+}
+
+// HTTPService adapts an [http.Server] to a [Service]. The server listens on
+// its configured address and is stopped with [http.Server.Shutdown].
+func HTTPService(srv *http.Server) Service {
+	return NewService(
+		func(context.Context) error {
+			if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+		srv.Shutdown,
+	)
+}
+
+type runResult struct {
+	// index of the service in the services slice, or -1 for the provider itself
+	index int
+	err   error
+}
+
+// Run starts the provider and the given services concurrently and blocks until
+// one of them fails, the host requests a shutdown, the process receives SIGINT
+// or SIGTERM, or ctx is cancelled.
+//
+// Services are then stopped in reverse order, followed by the provider itself.
+// All errors encountered along the way are returned joined together.
+func (wp *WasmcloudProvider) Run(ctx context.Context, services ...Service) error {
+	ctx, stopSignals := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+
+	results := make(chan runResult, len(services)+1)
+	go func() {
+		results <- runResult{index: -1, err: wp.Start()}
+	}()
+	for i, svc := range services {
+		go func() {
+			results <- runResult{index: i, err: svc.Start(ctx)}
+		}()
+	}
+
+	var errs []error
+	record := func(res runResult) {
+		if res.err == nil {
+			return
+		}
+		if res.index < 0 {
+			errs = append(errs, fmt.Errorf("provider: %w", res.err))
+		} else {
+			errs = append(errs, fmt.Errorf("service %d: %w", res.index, res.err))
+		}
+	}
+
+	pending := len(services) + 1
+	for running := true; running; {
+		select {
+		case <-ctx.Done():
+			wp.Logger.Info("provider received stop signal", "id", wp.ID)
+			running = false
+		case res := <-results:
+			pending--
+			record(res)
+			// A service returning cleanly is not a reason to stop the others,
+			// but the provider returning means the host asked us to shut down.
+			running = res.err == nil && res.index >= 0
+		}
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), serviceStopTimeout)
+	defer cancel()
+
+	for i := len(services) - 1; i >= 0; i-- {
+		if err := services[i].Stop(stopCtx); err != nil {
+			errs = append(errs, fmt.Errorf("service %d: stop: %w", i, err))
+		}
+	}
+
+	if err := wp.Shutdown(); err != nil {
+		errs = append(errs, fmt.Errorf("provider: shutdown: %w", err))
+	}
+
+	for ; pending > 0; pending-- {
+		select {
+		case res := <-results:
+			record(res)
+		case <-stopCtx.Done():
+			errs = append(errs, fmt.Errorf("waiting for %d services to stop: %w", pending, stopCtx.Err()))
+			return errors.Join(errs...)
+		}
+	}
+
+	return errors.Join(errs...)
+}
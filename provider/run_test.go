@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	nats "github.com/nats-io/nats.go"
+)
+
+func startNats(t *testing.T) *server.Server {
+	t.Helper()
+	s, err := server.NewServer(&server.Options{
+		Port:   server.RANDOM_PORT,
+		NoSigs: true,
+		NoLog:  true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create nats server: %v", err)
+	}
+	s.Start()
+	if !s.ReadyForConnections(5 * time.Second) {
+		s.Shutdown()
+		t.Fatalf("nats server did not start")
+	}
+	t.Cleanup(func() {
+		s.Shutdown()
+		s.WaitForShutdown()
+	})
+	return s
+}
+
+// newTestProvider returns a provider connected to s, with flushed set once
+// its internal shutdown functions have run.
+func newTestProvider(t *testing.T, s *server.Server, flushed *atomic.Bool, options ...ProviderHandler) *WasmcloudProvider {
+	t.Helper()
+	hostData, err := json.Marshal(HostData{
+		HostID:           "host",
+		LatticeRPCPrefix: "default",
+		LatticeRPCURL:    s.ClientURL(),
+		ProviderKey:      "provider",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := strings.NewReader(base64.StdEncoding.EncodeToString(hostData) + "\n")
+	wp, err := NewWithHostDataSource(source, options...)
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+	wp.internalShutdownFuncs = append(wp.internalShutdownFuncs, func(context.Context) error {
+		flushed.Store(true)
+		return nil
+	})
+	return wp
+}
+
+func TestRunHostShutdown(t *testing.T) {
+	s := startNats(t)
+
+	var flushed, shutdownCalled, stopped atomic.Bool
+	wp := newTestProvider(t, s, &flushed, Shutdown(func() error {
+		shutdownCalled.Store(true)
+		return nil
+	}))
+
+	svcDone := make(chan struct{})
+	svc := NewService(
+		func(context.Context) error {
+			<-svcDone
+			return nil
+		},
+		func(context.Context) error {
+			stopped.Store(true)
+			close(svcDone)
+			return nil
+		},
+	)
+
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- wp.Run(context.Background(), svc)
+	}()
+
+	nc, err := nats.Connect(s.ClientURL())
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer nc.Close()
+
+	// The provider subscribes once Start runs, so retry until it answers.
+	var reply *nats.Msg
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		reply, err = nc.Request(wp.Topics.LatticeShutdown, nil, 100*time.Millisecond)
+		if err == nil || time.Now().After(deadline) {
+			break
+		}
+	}
+	if err != nil {
+		t.Fatalf("shutdown request failed: %v", err)
+	}
+	if want, got := "provider shutdown handled successfully", string(reply.Data); want != got {
+		t.Errorf("expected reply %q, got %q", want, got)
+	}
+
+	select {
+	case err := <-runErr:
+		if err != nil {
+			t.Errorf("unexpected Run error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after host shutdown")
+	}
+
+	if !shutdownCalled.Load() {
+		t.Error("expected the shutdown handler to be called")
+	}
+	if !stopped.Load() {
+		t.Error("expected the service to be stopped")
+	}
+	if !flushed.Load() {
+		t.Error("expected internal shutdown functions to run")
+	}
+}
+
+func TestShutdownRespondsAfterShutdown(t *testing.T) {
+	s := startNats(t)
+
+	var flushed atomic.Bool
+	wp := newTestProvider(t, s, &flushed)
+	if err := wp.Shutdown(); err != nil {
+		t.Fatalf("unexpected shutdown error: %v", err)
+	}
+	if !flushed.Load() {
+		t.Error("expected internal shutdown functions to run")
+	}
+
+	responded := false
+	if err := wp.shutdownOnceWith(func() { responded = true }); err != nil {
+		t.Errorf("unexpected shutdown error: %v", err)
+	}
+	if !responded {
+		t.Error("expected the reply to be attempted after an earlier shutdown")
+	}
+}
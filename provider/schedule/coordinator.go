@@ -0,0 +1,58 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// Coordinator decides which provider instance fires a given tick.
+type Coordinator interface {
+	// Claim returns true if the caller won the tick identified by key. Every
+	// instance racing for the same tick uses the same key.
+	Claim(ctx context.Context, key string) (bool, error)
+}
+
+// KeyValueCoordinator claims ticks by creating keys in a JetStream key-value
+// bucket. Creation only succeeds for the first instance; configure a TTL on
+// the bucket to expire old claims.
+type KeyValueCoordinator struct {
+	kv jetstream.KeyValue
+}
+
+var _ Coordinator = (*KeyValueCoordinator)(nil)
+
+func NewKeyValueCoordinator(kv jetstream.KeyValue) *KeyValueCoordinator {
+	return &KeyValueCoordinator{kv: kv}
+}
+
+func (c *KeyValueCoordinator) Claim(ctx context.Context, key string) (bool, error) {
+	_, err := c.kv.Create(ctx, key, nil)
+	if errors.Is(err, jetstream.ErrKeyExists) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func tickKey(tick Tick) string {
+	return sanitizeKey(linkKey(tick.Link)) + "." + strconv.FormatInt(tick.Time.Unix(), 10)
+}
+
+// sanitizeKey replaces characters not allowed in NATS key-value keys.
+func sanitizeKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9',
+			r == '-', r == '_', r == '=':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+}
@@ -0,0 +1,283 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule describes when ticks happen.
+type Schedule interface {
+	// Next returns the first activation time strictly after t, or the zero
+	// time if there is none.
+	Next(t time.Time) time.Time
+}
+
+// Every returns a Schedule that fires every d, aligned to the Unix epoch so
+// that all provider instances agree on tick times. Ticks have a resolution of
+// one second: d is truncated to whole seconds, and anything shorter than a
+// second fires every second.
+func Every(d time.Duration) Schedule {
+	if d < time.Second {
+		d = time.Second
+	}
+	return intervalSchedule(d.Truncate(time.Second))
+}
+
+type intervalSchedule time.Duration
+
+func (s intervalSchedule) Next(t time.Time) time.Time {
+	step := int64(time.Duration(s) / time.Second)
+	sec := t.Unix()
+	// floor division, so times before the epoch align the same way
+	n := sec / step
+	if sec%step < 0 {
+		n--
+	}
+	return time.Unix((n+1)*step, 0).In(t.Location())
+}
+
+// parseInterval parses a duration for an interval schedule, rejecting
+// durations [Every] would have to round.
+func parseInterval(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidSchedule, err)
+	}
+	if d < time.Second || d%time.Second != 0 {
+		return 0, fmt.Errorf("%w: interval %s must be a positive whole number of seconds", ErrInvalidSchedule, d)
+	}
+	return d, nil
+}
+
+type cronSchedule struct {
+	second, minute, hour, dom, month, dow uint64
+	location                              *time.Location
+}
+
+type bounds struct {
+	min, max uint
+	names    map[string]uint
+}
+
+var (
+	secondBounds = bounds{0, 59, nil}
+	minuteBounds = bounds{0, 59, nil}
+	hourBounds   = bounds{0, 23, nil}
+	domBounds    = bounds{1, 31, nil}
+	monthBounds  = bounds{1, 12, map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dowBounds = bounds{0, 6, map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// starBit marks a field that was specified as `*` or `?`. It matters for the
+// day-of-month/day-of-week rule: when both are restricted, either may match.
+const starBit = 1 << 63
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// ParseCron parses a cron expression in the given location. It accepts the
+// standard five fields (minute, hour, day of month, month, day of week), an
+// optional leading seconds field, the `@hourly`-style descriptors and
+// `@every <duration>`.
+func ParseCron(spec string, loc *time.Location) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, fmt.Errorf("%w: empty cron expression", ErrInvalidSchedule)
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	if strings.HasPrefix(spec, "@every ") {
+		d, err := parseInterval(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil {
+			return nil, err
+		}
+		return Every(d), nil
+	}
+	if strings.HasPrefix(spec, "@") {
+		expanded, ok := descriptors[strings.ToLower(spec)]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown descriptor %q", ErrInvalidSchedule, spec)
+		}
+		spec = expanded
+	}
+
+	fields := strings.Fields(spec)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("%w: expected 5 or 6 fields, got %d in %q", ErrInvalidSchedule, len(fields), spec)
+	}
+
+	s := &cronSchedule{location: loc}
+	var err error
+	for i, f := range []struct {
+		dst *uint64
+		b   bounds
+	}{
+		{&s.second, secondBounds},
+		{&s.minute, minuteBounds},
+		{&s.hour, hourBounds},
+		{&s.dom, domBounds},
+		{&s.month, monthBounds},
+		{&s.dow, dowBounds},
+	} {
+		if *f.dst, err = parseField(fields[i], f.b); err != nil {
+			return nil, fmt.Errorf("%w: field %d of %q: %w", ErrInvalidSchedule, i, spec, err)
+		}
+	}
+	return s, nil
+}
+
+func parseField(field string, b bounds) (uint64, error) {
+	var v uint64
+	for _, expr := range strings.Split(field, ",") {
+		r, err := parseRange(expr, b)
+		if err != nil {
+			return 0, err
+		}
+		v |= r
+	}
+	return v, nil
+}
+
+func parseRange(expr string, b bounds) (uint64, error) {
+	rangeAndStep := strings.SplitN(expr, "/", 2)
+	lowAndHigh := strings.SplitN(rangeAndStep[0], "-", 2)
+
+	var start, end, step uint = 0, 0, 1
+	var extra uint64
+	switch {
+	case lowAndHigh[0] == "*" || lowAndHigh[0] == "?":
+		start, end = b.min, b.max
+		extra = starBit
+	default:
+		var err error
+		if start, err = parseValue(lowAndHigh[0], b); err != nil {
+			return 0, err
+		}
+		end = start
+		if len(lowAndHigh) == 2 {
+			if end, err = parseValue(lowAndHigh[1], b); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	if len(rangeAndStep) == 2 {
+		n, err := strconv.ParseUint(rangeAndStep[1], 10, 8)
+		if err != nil || n == 0 {
+			return 0, fmt.Errorf("invalid step %q", rangeAndStep[1])
+		}
+		step = uint(n)
+		// `N/step` means "from N to the end of the range"
+		if len(lowAndHigh) == 1 && extra == 0 {
+			end = b.max
+		}
+		if step > 1 {
+			extra = 0
+		}
+	}
+
+	if start < b.min || end > b.max || start > end {
+		return 0, fmt.Errorf("value range %q out of bounds [%d, %d]", expr, b.min, b.max)
+	}
+
+	var v uint64
+	for i := start; i <= end; i += step {
+		v |= 1 << i
+	}
+	return v | extra, nil
+}
+
+func parseValue(s string, b bounds) (uint, error) {
+	if v, ok := b.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	n, err := strconv.ParseUint(s, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	// Accept 7 as an alias for Sunday
+	if b.max == dowBounds.max && n == 7 {
+		n = 0
+	}
+	return uint(n), nil
+}
+
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.dom&starBit != 0 || s.dow&starBit != 0 {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	origLocation := t.Location()
+	t = t.In(s.location)
+	t = t.Add(time.Second - time.Duration(t.Nanosecond())).Truncate(time.Second)
+
+	// Give up if no match is found within five years, e.g. "0 0 30 2 *".
+	yearLimit := t.Year() + 5
+
+WRAP:
+	if t.Year() > yearLimit {
+		return time.Time{}
+	}
+
+	for s.month&(1<<uint(t.Month())) == 0 {
+		t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+		if t.Month() == time.January {
+			goto WRAP
+		}
+	}
+
+	for !s.dayMatches(t) {
+		t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+		if t.Day() == 1 {
+			goto WRAP
+		}
+	}
+
+	for s.hour&(1<<uint(t.Hour())) == 0 {
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.location)
+		if t.Hour() == 0 {
+			goto WRAP
+		}
+	}
+
+	for s.minute&(1<<uint(t.Minute())) == 0 {
+		t = t.Truncate(time.Minute).Add(time.Minute)
+		if t.Minute() == 0 {
+			goto WRAP
+		}
+	}
+
+	for s.second&(1<<uint(t.Second())) == 0 {
+		t = t.Truncate(time.Second).Add(time.Second)
+		if t.Second() == 0 {
+			goto WRAP
+		}
+	}
+
+	return t.In(origLocation)
+}
@@ -0,0 +1,122 @@
+package schedule
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseCronNext(t *testing.T) {
+	from := time.Date(2024, time.January, 31, 10, 17, 30, 0, time.UTC)
+
+	tt := map[string]struct {
+		spec string
+		want time.Time
+	}{
+		"every minute":       {"* * * * *", time.Date(2024, time.January, 31, 10, 18, 0, 0, time.UTC)},
+		"step minutes":       {"*/15 * * * *", time.Date(2024, time.January, 31, 10, 30, 0, 0, time.UTC)},
+		"with seconds":       {"*/10 * * * * *", time.Date(2024, time.January, 31, 10, 17, 40, 0, time.UTC)},
+		"next day":           {"0 9 * * *", time.Date(2024, time.February, 1, 9, 0, 0, 0, time.UTC)},
+		"leap day":           {"0 0 29 feb *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		"weekday names":      {"30 8 * * mon-fri", time.Date(2024, time.February, 1, 8, 30, 0, 0, time.UTC)},
+		"sunday as seven":    {"0 0 * * 7", time.Date(2024, time.February, 4, 0, 0, 0, 0, time.UTC)},
+		"dom or dow":         {"0 0 15 * sat", time.Date(2024, time.February, 3, 0, 0, 0, 0, time.UTC)},
+		"list and range":     {"5,20-22 11 * * *", time.Date(2024, time.January, 31, 11, 5, 0, 0, time.UTC)},
+		"hourly descriptor":  {"@hourly", time.Date(2024, time.January, 31, 11, 0, 0, 0, time.UTC)},
+		"monthly descriptor": {"@monthly", time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		"every descriptor":   {"@every 1h", time.Date(2024, time.January, 31, 11, 0, 0, 0, time.UTC)},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			sched, err := ParseCron(tc.spec, time.UTC)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if got := sched.Next(from); !got.Equal(tc.want) {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseCronLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	sched, err := ParseCron("0 9 * * *", loc)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	from := time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)
+	if want, got := time.Date(2024, time.January, 31, 7, 0, 0, 0, time.UTC), sched.Next(from); !got.Equal(want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
+func TestParseCronInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"@sometimes",
+		"@every never",
+		"@every 500ms",
+		"@every 1500ms",
+	} {
+		t.Run(spec, func(t *testing.T) {
+			if _, err := ParseCron(spec, time.UTC); !errors.Is(err, ErrInvalidSchedule) {
+				t.Errorf("expected ErrInvalidSchedule, got %v", err)
+			}
+		})
+	}
+}
+
+func TestParseCronImpossible(t *testing.T) {
+	sched, err := ParseCron("0 0 30 2 *", time.UTC)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if got := sched.Next(time.Now()); !got.IsZero() {
+		t.Errorf("expected no activation, got %v", got)
+	}
+}
+
+func TestEveryIsAligned(t *testing.T) {
+	sched := Every(time.Minute)
+	a := sched.Next(time.Date(2024, time.January, 31, 10, 17, 1, 0, time.UTC))
+	b := sched.Next(time.Date(2024, time.January, 31, 10, 17, 59, 0, time.UTC))
+	if !a.Equal(b) {
+		t.Errorf("expected instances to agree on tick time, got %v and %v", a, b)
+	}
+}
+
+func TestEveryAlignsToUnixEpoch(t *testing.T) {
+	// 7s doesn't divide the offset between Go's zero time and the epoch
+	sched := Every(7 * time.Second)
+	for _, sec := range []int64{0, 1, 6, 7, 1706696221, -1, -7, -8} {
+		got := sched.Next(time.Unix(sec, 500))
+		if got.Unix()%7 != 0 {
+			t.Errorf("Next(%d) = %d, not aligned to the epoch", sec, got.Unix())
+		}
+		if !got.After(time.Unix(sec, 500)) || got.Sub(time.Unix(sec, 500)) > 7*time.Second {
+			t.Errorf("Next(%d) = %d, expected the following tick", sec, got.Unix())
+		}
+	}
+	if got, want := sched.Next(time.Unix(14, 0)), time.Unix(21, 0); !got.Equal(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestEveryClampsToSecond(t *testing.T) {
+	start := time.Unix(100, 0)
+	for _, d := range []time.Duration{0, time.Millisecond, 1500 * time.Millisecond} {
+		if got, want := Every(d).Next(start), time.Unix(101, 0); !got.Equal(want) {
+			t.Errorf("Every(%v): expected %v, got %v", d, want, got)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+// Package schedule implements a reusable scheduler for providers that invoke
+// linked components on a timer.
+//
+// Each link carries its schedule in the link's target config, either as a
+// cron expression under [CronConfigKey] or as a Go duration under
+// [IntervalConfigKey]. Wire the scheduler into the provider link callbacks:
+//
+//	scheduler := schedule.New(wasmcloudprovider, invoke)
+//	wasmcloudprovider, err := provider.New(
+//		provider.SourceLinkPut(scheduler.PutLink),
+//		provider.SourceLinkDel(scheduler.DelLink),
+//		provider.Shutdown(scheduler.Shutdown),
+//	)
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"go.wasmcloud.dev/provider"
+	wrpc "wrpc.io/go"
+	wrpcnats "wrpc.io/go/nats"
+)
+
+const (
+	// CronConfigKey is the link config key holding a cron expression.
+	CronConfigKey = "cron"
+	// IntervalConfigKey is the link config key holding a Go duration, e.g. "30s".
+	IntervalConfigKey = "interval"
+	// TimezoneConfigKey is the optional link config key holding an IANA
+	// timezone name used to evaluate cron expressions. Defaults to UTC.
+	TimezoneConfigKey = "timezone"
+)
+
+var (
+	ErrInvalidSchedule = errors.New("invalid schedule")
+	ErrNoSchedule      = errors.New("no schedule in link config")
+)
+
+type NatsClientCreator interface {
+	OutgoingRpcClient(target string) *wrpcnats.Client
+}
+
+// Tick describes a single scheduled activation of a link.
+type Tick struct {
+	Link provider.InterfaceLinkDefinition
+	// Time is the time the tick was scheduled for, not the time it fired.
+	Time time.Time
+}
+
+// InvokeFunc is called for every tick with an invoker targeting the linked
+// component. It is typically a thin wrapper around a generated wRPC binding.
+type InvokeFunc func(ctx context.Context, client wrpc.Invoker, tick Tick) error
+
+type Option func(*Scheduler)
+
+// WithCoordinator makes the scheduler claim each tick through c before firing
+// it, so that only one of several provider instances invokes the component.
+func WithCoordinator(c Coordinator) Option {
+	return func(s *Scheduler) {
+		s.coordinator = c
+	}
+}
+
+// WithTimeout bounds the duration of each invocation.
+func WithTimeout(d time.Duration) Option {
+	return func(s *Scheduler) {
+		s.timeout = d
+	}
+}
+
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *Scheduler) {
+		s.logger = logger
+	}
+}
+
+// Scheduler invokes linked components according to the schedule found in
+// their link config.
+type Scheduler struct {
+	natsCreator NatsClientCreator
+	invoke      InvokeFunc
+	coordinator Coordinator
+	timeout     time.Duration
+	logger      *slog.Logger
+
+	lock sync.Mutex
+	jobs map[string]*job
+}
+
+type job struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func New(nc NatsClientCreator, invoke InvokeFunc, opts ...Option) *Scheduler {
+	s := &Scheduler{
+		natsCreator: nc,
+		invoke:      invoke,
+		timeout:     30 * time.Second,
+		logger:      slog.Default(),
+		jobs:        make(map[string]*job),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// ParseSchedule reads the schedule from a link config map.
+func ParseSchedule(config map[string]string) (Schedule, error) {
+	loc := time.UTC
+	if tz, ok := config[TimezoneConfigKey]; ok && tz != "" {
+		var err error
+		if loc, err = time.LoadLocation(tz); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidSchedule, err)
+		}
+	}
+
+	cron, hasCron := config[CronConfigKey]
+	interval, hasInterval := config[IntervalConfigKey]
+	switch {
+	case hasCron && hasInterval:
+		return nil, fmt.Errorf("%w: only one of %q and %q may be set", ErrInvalidSchedule, CronConfigKey, IntervalConfigKey)
+	case hasCron:
+		return ParseCron(cron, loc)
+	case hasInterval:
+		d, err := parseInterval(interval)
+		if err != nil {
+			return nil, err
+		}
+		return Every(d), nil
+	default:
+		return nil, ErrNoSchedule
+	}
+}
+
+// PutLink starts, or restarts with the new config, the schedule of a link.
+func (s *Scheduler) PutLink(link provider.InterfaceLinkDefinition) error {
+	sched, err := ParseSchedule(link.TargetConfig)
+	if err != nil {
+		return err
+	}
+
+	key := linkKey(link)
+	ctx, cancel := context.WithCancel(context.Background())
+	j := &job{cancel: cancel, done: make(chan struct{})}
+
+	s.lock.Lock()
+	old := s.jobs[key]
+	s.jobs[key] = j
+	s.lock.Unlock()
+
+	if old != nil {
+		old.stop()
+	}
+
+	go func() {
+		defer close(j.done)
+		s.run(ctx, link, sched)
+	}()
+	s.logger.Info("scheduled link", "target", link.Target, "name", link.Name)
+	return nil
+}
+
+// DelLink stops the schedule of a link.
+func (s *Scheduler) DelLink(link provider.InterfaceLinkDefinition) error {
+	key := linkKey(link)
+
+	s.lock.Lock()
+	j := s.jobs[key]
+	delete(s.jobs, key)
+	s.lock.Unlock()
+
+	if j != nil {
+		j.stop()
+		s.logger.Info("unscheduled link", "target", link.Target, "name", link.Name)
+	}
+	return nil
+}
+
+// Shutdown stops all schedules and waits for in-flight invocations to return.
+func (s *Scheduler) Shutdown() error {
+	s.lock.Lock()
+	jobs := s.jobs
+	s.jobs = make(map[string]*job)
+	s.lock.Unlock()
+
+	for _, j := range jobs {
+		j.stop()
+	}
+	return nil
+}
+
+func (j *job) stop() {
+	j.cancel()
+	<-j.done
+}
+
+func (s *Scheduler) run(ctx context.Context, link provider.InterfaceLinkDefinition, sched Schedule) {
+	for {
+		next := sched.Next(time.Now())
+		if next.IsZero() {
+			s.logger.Warn("schedule has no further activations", "target", link.Target, "name", link.Name)
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		// NOTE: ticks are fired sequentially per link; a tick that comes due
+		// while the previous invocation is still running is skipped.
+		s.fire(ctx, Tick{Link: link, Time: next})
+	}
+}
+
+func (s *Scheduler) fire(ctx context.Context, tick Tick) {
+	if s.coordinator != nil {
+		claimed, err := s.coordinator.Claim(ctx, tickKey(tick))
+		if err != nil {
+			s.logger.Error("failed to claim tick", "target", tick.Link.Target, "name", tick.Link.Name, slog.Any("error", err))
+			return
+		}
+		if !claimed {
+			s.logger.Debug("tick claimed by another instance", "target", tick.Link.Target, "name", tick.Link.Name)
+			return
+		}
+	}
+
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	client := s.natsCreator.OutgoingRpcClient(tick.Link.Target)
+	if err := s.invoke(ctx, client, tick); err != nil {
+		s.logger.Error("scheduled invocation failed", "target", tick.Link.Target, "name", tick.Link.Name, slog.Any("error", err))
+	}
+}
+
+func linkKey(link provider.InterfaceLinkDefinition) string {
+	return link.Target + "/" + link.Name
+}
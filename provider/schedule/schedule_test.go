@@ -0,0 +1,154 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.wasmcloud.dev/provider"
+	wrpc "wrpc.io/go"
+	wrpcnats "wrpc.io/go/nats"
+)
+
+type fakeNatsCreator struct{}
+
+func (fakeNatsCreator) OutgoingRpcClient(string) *wrpcnats.Client {
+	return nil
+}
+
+type memoryCoordinator struct {
+	lock    sync.Mutex
+	claimed map[string]bool
+}
+
+func (c *memoryCoordinator) Claim(_ context.Context, key string) (bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.claimed[key] {
+		return false, nil
+	}
+	c.claimed[key] = true
+	return true, nil
+}
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func TestParseSchedule(t *testing.T) {
+	if _, err := ParseSchedule(map[string]string{}); !errors.Is(err, ErrNoSchedule) {
+		t.Errorf("expected ErrNoSchedule, got %v", err)
+	}
+
+	for name, config := range map[string]map[string]string{
+		"both":         {CronConfigKey: "* * * * *", IntervalConfigKey: "1s"},
+		"bad interval": {IntervalConfigKey: "soon"},
+		"negative":     {IntervalConfigKey: "-1s"},
+		"sub-second":   {IntervalConfigKey: "250ms"},
+		"bad timezone": {CronConfigKey: "* * * * *", TimezoneConfigKey: "Mars/Olympus_Mons"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseSchedule(config); !errors.Is(err, ErrInvalidSchedule) {
+				t.Errorf("expected ErrInvalidSchedule, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSchedulerLinkLifecycle(t *testing.T) {
+	var calls atomic.Int32
+	invoke := func(_ context.Context, _ wrpc.Invoker, tick Tick) error {
+		if want, got := "component", tick.Link.Target; want != got {
+			t.Errorf("expected target %s, got %s", want, got)
+		}
+		calls.Add(1)
+		return nil
+	}
+
+	s := New(fakeNatsCreator{}, invoke, WithLogger(discardLogger))
+	link := provider.InterfaceLinkDefinition{
+		Target:       "component",
+		Name:         "default",
+		TargetConfig: map[string]string{IntervalConfigKey: "1s"},
+	}
+
+	if err := s.PutLink(link); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	// Updating the link replaces the running schedule
+	if err := s.PutLink(link); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	deadline := time.Now().Add(3 * time.Second)
+	for calls.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if calls.Load() == 0 {
+		t.Fatal("expected at least one invocation")
+	}
+
+	if err := s.DelLink(link); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	after := calls.Load()
+	time.Sleep(1500 * time.Millisecond)
+	if got := calls.Load(); got != after {
+		t.Errorf("expected no invocations after link deletion, got %d more", got-after)
+	}
+
+	if err := s.Shutdown(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestSchedulerCoordination(t *testing.T) {
+	coordinator := &memoryCoordinator{claimed: map[string]bool{}}
+	var calls atomic.Int32
+	invoke := func(context.Context, wrpc.Invoker, Tick) error {
+		calls.Add(1)
+		return nil
+	}
+
+	link := provider.InterfaceLinkDefinition{
+		Target:       "component",
+		TargetConfig: map[string]string{IntervalConfigKey: "1s"},
+	}
+
+	// Two instances of the same provider race for the same ticks
+	instances := []*Scheduler{
+		New(fakeNatsCreator{}, invoke, WithCoordinator(coordinator), WithLogger(discardLogger)),
+		New(fakeNatsCreator{}, invoke, WithCoordinator(coordinator), WithLogger(discardLogger)),
+	}
+	for _, s := range instances {
+		if err := s.PutLink(link); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	time.Sleep(2500 * time.Millisecond)
+	for _, s := range instances {
+		_ = s.Shutdown()
+	}
+
+	coordinator.lock.Lock()
+	claimed := len(coordinator.claimed)
+	coordinator.lock.Unlock()
+
+	if want, got := int32(claimed), calls.Load(); want != got {
+		t.Errorf("expected one invocation per tick (%d), got %d", want, got)
+	}
+}
+
+func TestTickKey(t *testing.T) {
+	tick := Tick{
+		Link: provider.InterfaceLinkDefinition{Target: "my component", Name: "default"},
+		Time: time.Unix(1700000000, 0),
+	}
+	if want, got := "my_component_default.1700000000", tickKey(tick); want != got {
+		t.Errorf("want %s, got %s", want, got)
+	}
+}
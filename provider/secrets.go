@@ -1,8 +1,12 @@
 package provider
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"sync"
 
 	"github.com/nats-io/nkeys"
 )
@@ -33,32 +37,115 @@ func (s SecretBytesValue) Reveal() []byte {
 	return s.value
 }
 
+// SecretKind discriminates the value held by a [SecretValue].
+type SecretKind string
+
+const (
+	SecretKindString SecretKind = "String"
+	SecretKindBytes  SecretKind = "Bytes"
+)
+
 type SecretValue struct {
+	Kind   SecretKind
 	String SecretStringValue
 	Bytes  SecretBytesValue
 }
 
+func NewStringSecret(value string) SecretValue {
+	return SecretValue{Kind: SecretKindString, String: SecretStringValue{value: value}}
+}
+
+func NewBytesSecret(value []byte) SecretValue {
+	return SecretValue{Kind: SecretKindBytes, Bytes: SecretBytesValue{value: value}}
+}
+
+type jsonSecretValue struct {
+	Kind  SecretKind      `json:"kind"`
+	Value json.RawMessage `json:"value"`
+}
+
 // Secret values are serialized as either a String or Bytes value, e.g.
 // {"kind": "String", "value": "my secret"} or {"kind": "Bytes", "value": [1, 2, 3]}
 func (s *SecretValue) UnmarshalJSON(data []byte) error {
-	var jsonSecret map[string]interface{}
-	err := json.Unmarshal(data, &jsonSecret)
-	if err != nil {
+	var jsonSecret jsonSecretValue
+	if err := json.Unmarshal(data, &jsonSecret); err != nil {
 		return err
 	}
+	if len(jsonSecret.Value) == 0 || string(jsonSecret.Value) == "null" {
+		return fmt.Errorf("missing value for secret of kind %q", jsonSecret.Kind)
+	}
 
-	switch jsonSecret["kind"] {
-	case "String":
-		s.String = SecretStringValue{value: jsonSecret["value"].(string)}
-	case "Bytes":
-		s.Bytes = SecretBytesValue{value: jsonSecret["value"].([]byte)}
+	switch jsonSecret.Kind {
+	case SecretKindString:
+		var value string
+		if err := json.Unmarshal(jsonSecret.Value, &value); err != nil {
+			return fmt.Errorf("invalid String secret value: %w", err)
+		}
+		*s = NewStringSecret(value)
+	case SecretKindBytes:
+		// Bytes are sent as an array of numbers, which encoding/json decodes
+		// element-wise into a []byte, rejecting values out of the byte range.
+		// A JSON string would be decoded as base64, so it is rejected upfront.
+		if jsonSecret.Value[0] != '[' {
+			return errors.New("invalid Bytes secret value: expected an array of bytes")
+		}
+		var value []byte
+		if err := json.Unmarshal(jsonSecret.Value, &value); err != nil {
+			return fmt.Errorf("invalid Bytes secret value: %w", err)
+		}
+		if value == nil {
+			value = []byte{}
+		}
+		*s = NewBytesSecret(value)
 	default:
-		return fmt.Errorf("invalid secret kind: %s", jsonSecret["kind"])
+		return fmt.Errorf("invalid secret kind: %q", jsonSecret.Kind)
 	}
 
 	return nil
 }
 
+// MarshalJSON serializes the secret kind with a redacted value, so links
+// and secrets can be logged or encoded without leaking them. Use
+// [SecretValue.RevealJSON] for the format read by
+// [SecretValue.UnmarshalJSON].
+func (s SecretValue) MarshalJSON() ([]byte, error) {
+	var value string
+	switch s.Kind {
+	case SecretKindString:
+		value = s.String.String()
+	case SecretKindBytes:
+		value = s.Bytes.String()
+	default:
+		return nil, fmt.Errorf("invalid secret kind: %q", s.Kind)
+	}
+	return json.Marshal(jsonSecret{s.Kind, value})
+}
+
+// RevealJSON serializes the secret in the format read by
+// [SecretValue.UnmarshalJSON], revealing its value.
+func (s SecretValue) RevealJSON() ([]byte, error) {
+	var value any
+	switch s.Kind {
+	case SecretKindString:
+		value = s.String.value
+	case SecretKindBytes:
+		// Avoid the base64 encoding of []byte
+		values := make([]int, len(s.Bytes.value))
+		for i, b := range s.Bytes.value {
+			values[i] = int(b)
+		}
+		value = values
+	default:
+		return nil, fmt.Errorf("invalid secret kind: %q", s.Kind)
+	}
+	return json.Marshal(jsonSecret{s.Kind, value})
+}
+
+type jsonSecret struct {
+	Kind  SecretKind `json:"kind"`
+	Value any        `json:"value"`
+}
+
 func (s *SecretStringValue) UnmarshalJSON(data []byte) error {
 	var stringValue string
 	err := json.Unmarshal(data, &stringValue)
@@ -78,9 +165,102 @@ func DecryptSecrets(encryptedBytes *[]byte, xkey nkeys.KeyPair, sender string) (
 			return sourceSecrets, err
 		}
 		err = json.Unmarshal(sourceSecretBytes, &sourceSecrets)
+		// The decoded values are copies, wipe the plaintext
+		clear(sourceSecretBytes)
 		if err != nil {
 			return sourceSecrets, err
 		}
 	}
 	return sourceSecrets, nil
 }
+
+var ErrSecretCleared = errors.New("secret has been cleared")
+
+// Secret holds sensitive material in a buffer that is wiped once the secret
+// is rotated or its link deleted. The material is only reachable through
+// [Secret.Use], so it never outlives the callback.
+type Secret struct {
+	lock  sync.RWMutex
+	kind  SecretKind
+	value []byte
+}
+
+// NewSecret copies value into a new secret.
+func NewSecret(kind SecretKind, value []byte) *Secret {
+	return &Secret{kind: kind, value: bytes.Clone(value)}
+}
+
+func newSecretFromValue(v SecretValue) *Secret {
+	if v.Kind == SecretKindBytes {
+		return NewSecret(v.Kind, v.Bytes.value)
+	}
+	return &Secret{kind: SecretKindString, value: []byte(v.String.value)}
+}
+
+func (s *Secret) Kind() SecretKind {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.kind
+}
+
+// Use calls fn with the secret material. The slice must not be retained or
+// modified, it is wiped as soon as the secret is rotated or cleared, which
+// waits for fn to return.
+func (s *Secret) Use(fn func([]byte) error) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.value == nil {
+		return ErrSecretCleared
+	}
+	return fn(s.value)
+}
+
+// Rotate replaces the secret material, wiping the previous one.
+func (s *Secret) Rotate(kind SecretKind, value []byte) {
+	value = bytes.Clone(value)
+	if value == nil {
+		value = []byte{}
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	clear(s.value)
+	s.kind, s.value = kind, value
+}
+
+// Clear wipes the secret material. Later calls to [Secret.Use] fail with
+// [ErrSecretCleared].
+func (s *Secret) Clear() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	clear(s.value)
+	s.value = nil
+}
+
+// matches reports whether the secret holds the value of v.
+func (s *Secret) matches(v SecretValue) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.value == nil || s.kind != v.Kind {
+		return false
+	}
+	if v.Kind == SecretKindBytes {
+		return bytes.Equal(s.value, v.Bytes.value)
+	}
+	return string(s.value) == v.String.value
+}
+
+func (s *Secret) String() string {
+	return "redacted(secret)"
+}
+
+func (s *Secret) LogValue() slog.Value {
+	return slog.StringValue(s.String())
+}
+
+// wipeSecretValues clears the bytes of secrets. String secrets are immutable
+// and are left to the garbage collector.
+func wipeSecretValues(secrets map[string]SecretValue) {
+	for _, v := range secrets {
+		clear(v.Bytes.value)
+	}
+}
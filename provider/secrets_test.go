@@ -1,8 +1,16 @@
 package provider
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
 	"testing"
+	"testing/quick"
+
+	"github.com/nats-io/nkeys"
 )
 
 func TestUnmarshalJson(t *testing.T) {
@@ -44,3 +52,265 @@ func TestUnmarshalJsonMap(t *testing.T) {
 		t.Errorf("Unexpected value. Got: %s, Expected: %s", secret["foobar"].String.Reveal(), expectedValue)
 	}
 }
+
+func TestUnmarshalJsonBytes(t *testing.T) {
+	secret := &SecretValue{}
+	if err := json.Unmarshal([]byte(`{"kind": "Bytes", "value": [0, 1, 255]}`), secret); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+	if secret.Kind != SecretKindBytes || !bytes.Equal(secret.Bytes.Reveal(), []byte{0, 1, 255}) {
+		t.Errorf("Unexpected value. Got: %v %v", secret.Kind, secret.Bytes.Reveal())
+	}
+
+	for _, invalid := range []string{
+		`{"kind": "Bytes", "value": [256]}`,
+		`{"kind": "Bytes", "value": [-1]}`,
+		`{"kind": "Bytes", "value": "AQI="}`,
+		`{"kind": "Bytes"}`,
+		`{"kind": "String", "value": [1]}`,
+		`{"kind": "String", "value": null}`,
+		`{"kind": "Other", "value": "x"}`,
+	} {
+		if err := json.Unmarshal([]byte(invalid), &SecretValue{}); err == nil {
+			t.Errorf("Expected %s to fail", invalid)
+		}
+	}
+}
+
+func TestMarshalJsonSecret(t *testing.T) {
+	secrets := map[string]SecretValue{
+		"bytes":  NewBytesSecret([]byte{1, 2, 3}),
+		"string": NewStringSecret("value"),
+	}
+	data, err := json.Marshal(secrets)
+	if err != nil {
+		t.Fatalf("Failed to marshal JSON: %v", err)
+	}
+	expected := `{"bytes":{"kind":"Bytes","value":"redacted(bytes)"},"string":{"kind":"String","value":"redacted(string)"}}`
+	if string(data) != expected {
+		t.Errorf("Unexpected JSON. Got: %s, Expected: %s", data, expected)
+	}
+
+	data, err = revealSecrets(secrets)
+	if err != nil {
+		t.Fatalf("Failed to reveal JSON: %v", err)
+	}
+	expected = `{"bytes":{"kind":"Bytes","value":[1,2,3]},"string":{"kind":"String","value":"value"}}`
+	if string(data) != expected {
+		t.Errorf("Unexpected JSON. Got: %s, Expected: %s", data, expected)
+	}
+
+	if _, err := json.Marshal(SecretValue{}); err == nil {
+		t.Errorf("Expected secret without kind to fail")
+	}
+	if _, err := (SecretValue{}).RevealJSON(); err == nil {
+		t.Errorf("Expected secret without kind to fail")
+	}
+}
+
+func TestLogLinkSecrets(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("link", "link", InterfaceLinkDefinition{
+		SourceID:      "component",
+		Target:        "provider",
+		SourceSecrets: map[string]SecretValue{"password": NewStringSecret("hunter2")},
+		TargetSecrets: map[string]SecretValue{"key": NewBytesSecret([]byte("topsecret"))},
+	})
+	if out := buf.String(); strings.Contains(out, "hunter2") || strings.Contains(out, "topsecret") || strings.Contains(out, "[116") {
+		t.Errorf("expected secrets to be redacted, got %s", out)
+	}
+}
+
+// revealSecrets encodes secrets as sent by the host.
+func revealSecrets(secrets map[string]SecretValue) ([]byte, error) {
+	values := make(map[string]json.RawMessage, len(secrets))
+	for name, secret := range secrets {
+		data, err := secret.RevealJSON()
+		if err != nil {
+			return nil, err
+		}
+		values[name] = data
+	}
+	return json.Marshal(values)
+}
+
+// TestDecryptSecretsRoundTrip checks that any secret sealed by a sender is
+// decrypted and decoded to the same value.
+func TestDecryptSecretsRoundTrip(t *testing.T) {
+	sender, err := nkeys.CreateCurveKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	senderKey, _ := sender.PublicKey()
+	recipient, err := nkeys.CreateCurveKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	recipientKey, _ := recipient.PublicKey()
+
+	roundTrip := func(stringValues map[string]string, bytesValues map[string][]byte) bool {
+		secrets := make(map[string]SecretValue)
+		for k, v := range stringValues {
+			secrets["s"+k] = NewStringSecret(v)
+		}
+		for k, v := range bytesValues {
+			secrets["b"+k] = NewBytesSecret(v)
+		}
+		data, err := revealSecrets(secrets)
+		if err != nil {
+			t.Logf("marshal: %v", err)
+			return false
+		}
+		sealed, err := sender.Seal(data, recipientKey)
+		if err != nil {
+			t.Logf("seal: %v", err)
+			return false
+		}
+
+		decrypted, err := DecryptSecrets(&sealed, recipient, senderKey)
+		if err != nil {
+			t.Logf("decrypt: %v", err)
+			return false
+		}
+		if len(decrypted) != len(secrets) {
+			return false
+		}
+		for k, v := range stringValues {
+			got := decrypted["s"+k]
+			if got.Kind != SecretKindString || got.String.Reveal() != v {
+				return false
+			}
+		}
+		for k, v := range bytesValues {
+			got := decrypted["b"+k]
+			if got.Kind != SecretKindBytes || !bytes.Equal(got.Bytes.Reveal(), v) || got.Bytes.Reveal() == nil {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(roundTrip, nil); err != nil {
+		t.Error(err)
+	}
+
+	if secrets, err := DecryptSecrets(nil, recipient, senderKey); err != nil || len(secrets) != 0 {
+		t.Errorf("Expected no secrets, got %v, %v", secrets, err)
+	}
+	sealed, _ := sender.Seal([]byte(`{}`), recipientKey)
+	if _, err := DecryptSecrets(&sealed, recipient, recipientKey); err == nil {
+		t.Errorf("Expected secrets from another sender to fail")
+	}
+}
+
+func TestSecret(t *testing.T) {
+	secret := NewSecret(SecretKindBytes, []byte{1, 2, 3})
+	var seen []byte
+	if err := secret.Use(func(b []byte) error {
+		seen = b
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if fmt.Sprint(secret) != "redacted(secret)" {
+		t.Errorf("expected secret to be redacted, got %s", secret)
+	}
+
+	secret.Rotate(SecretKindString, []byte("new"))
+	if !bytes.Equal(seen, []byte{0, 0, 0}) {
+		t.Errorf("expected rotation to wipe the old value, got %v", seen)
+	}
+	_ = secret.Use(func(b []byte) error {
+		seen = b
+		if string(b) != "new" || secret.kind != SecretKindString {
+			t.Errorf("unexpected rotated value %q", b)
+		}
+		return nil
+	})
+
+	secret.Clear()
+	if !bytes.Equal(seen, []byte{0, 0, 0}) {
+		t.Errorf("expected clear to wipe the value, got %q", seen)
+	}
+	if err := secret.Use(func([]byte) error { return nil }); !errors.Is(err, ErrSecretCleared) {
+		t.Errorf("expected cleared secret to fail, got %v", err)
+	}
+}
+
+func TestLinkSecretsRotation(t *testing.T) {
+	var put, rotated []InterfaceLinkDefinition
+	wp := &WasmcloudProvider{
+		ID:                 "provider",
+		Logger:             slog.New(slog.DiscardHandler),
+		putTargetLinkFunc:  func(l InterfaceLinkDefinition) error { put = append(put, l); return nil },
+		delTargetLinkFunc:  func(InterfaceLinkDefinition) error { return nil },
+		secretsRotatedFunc: func(l InterfaceLinkDefinition) error { rotated = append(rotated, l); return nil },
+		sourceLinks:        make(map[string]InterfaceLinkDefinition),
+		targetLinks:        make(map[string]InterfaceLinkDefinition),
+		linkSecrets:        make(map[string]map[string]*Secret),
+	}
+	link := func(secrets map[string]SecretValue) InterfaceLinkDefinition {
+		return InterfaceLinkDefinition{SourceID: "component", Target: "provider", TargetSecrets: secrets}
+	}
+	reveal := func(s *Secret) (value string) {
+		_ = s.Use(func(b []byte) error {
+			value = string(b)
+			return nil
+		})
+		return value
+	}
+
+	first := link(map[string]SecretValue{"token": NewBytesSecret([]byte("one")), "user": NewStringSecret("me")})
+	if err := wp.putLink(first); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	secrets := wp.LinkSecrets(first)
+	token := secrets["token"]
+	if len(secrets) != 2 || reveal(token) != "one" || reveal(secrets["user"]) != "me" {
+		t.Fatalf("unexpected secrets %v", secrets)
+	}
+
+	// Same secrets, nothing to rotate
+	_ = wp.putLink(link(map[string]SecretValue{"token": NewBytesSecret([]byte("one")), "user": NewStringSecret("me")}))
+	if len(rotated) != 0 {
+		t.Errorf("expected duplicate link to be ignored")
+	}
+
+	second := link(map[string]SecretValue{"token": NewBytesSecret([]byte("two"))})
+	if err := wp.putLink(second); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(rotated) != 1 {
+		t.Fatalf("expected a rotation event, got %d", len(rotated))
+	}
+	if reveal(token) != "two" {
+		t.Errorf("expected secret to be rotated in place, got %q", reveal(token))
+	}
+	if !bytes.Equal(first.TargetSecrets["token"].Bytes.Reveal(), []byte{0, 0, 0}) {
+		t.Errorf("expected put link secrets to be wiped")
+	}
+	if !bytes.Equal(second.TargetSecrets["token"].Bytes.Reveal(), []byte{0, 0, 0}) {
+		t.Errorf("expected rotated link secrets to be wiped")
+	}
+	for _, l := range append(append(put, rotated...), wp.targetLinks["component"]) {
+		if l.SourceSecrets != nil || l.TargetSecrets != nil {
+			t.Errorf("expected links passed to callbacks and stored to have no secrets, got %v", l)
+		}
+	}
+	if _, ok := wp.LinkSecrets(second)["user"]; ok {
+		t.Errorf("expected removed secret to be dropped")
+	}
+	if err := secrets["user"].Use(func([]byte) error { return nil }); !errors.Is(err, ErrSecretCleared) {
+		t.Errorf("expected removed secret to be cleared, got %v", err)
+	}
+
+	if err := wp.deleteLink(second); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := token.Use(func([]byte) error { return nil }); !errors.Is(err, ErrSecretCleared) {
+		t.Errorf("expected deleted link secrets to be cleared, got %v", err)
+	}
+	if len(wp.LinkSecrets(second)) != 0 {
+		t.Errorf("expected no secrets for deleted link")
+	}
+}
@@ -0,0 +1,497 @@
+// Package wrpcblobstore serves `wrpc:blobstore` from a pluggable
+// [BlobBackend], so blobstore providers only implement storage.
+//
+//	h := wrpcblobstore.NewHandler(backend)
+//	p, _ := provider.New(
+//		provider.TargetLinkPut(h.PutLink),
+//		provider.TargetLinkDel(h.DelLink),
+//	)
+//	stop, _ := h.Serve(p.RPCClient)
+package wrpcblobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"sync"
+	"time"
+
+	"go.wasmcloud.dev/provider"
+	"go.wasmcloud.dev/provider/internal/exports/wrpc/blobstore/blobstore"
+	wrpctypes "go.wasmcloud.dev/provider/internal/wrpc/blobstore/types"
+
+	wrpc "wrpc.io/go"
+)
+
+const (
+	// listChunkSize is the number of object names sent per stream chunk.
+	listChunkSize = 256
+)
+
+var (
+	ErrContainerNotFound = errors.New("container not found")
+	ErrContainerExists   = errors.New("container already exists")
+	ErrObjectNotFound    = errors.New("object not found")
+	ErrInvalidName       = errors.New("invalid name")
+	ErrInvalidRange      = errors.New("invalid range")
+	// ErrAccessDenied is returned to components that aren't linked to the provider.
+	ErrAccessDenied = errors.New("access denied")
+)
+
+// ObjectID identifies an object within a container.
+type ObjectID struct {
+	Container string
+	Object    string
+}
+
+type ContainerInfo struct {
+	CreatedAt time.Time
+}
+
+type ObjectInfo struct {
+	CreatedAt time.Time
+	Size      int64
+}
+
+// BlobBackend stores objects in containers. Implementations must be safe for
+// concurrent use and return the errors of this package where they apply.
+type BlobBackend interface {
+	// CreateContainer fails with [ErrContainerExists] if the container exists.
+	CreateContainer(ctx context.Context, container string) error
+	// DeleteContainer deletes the container and every object within it.
+	DeleteContainer(ctx context.Context, container string) error
+	ContainerInfo(ctx context.Context, container string) (*ContainerInfo, error)
+	// ListObjects yields the names of the objects in the container, in a
+	// stable order so that listings can be paginated by offset.
+	ListObjects(ctx context.Context, container string) iter.Seq2[string, error]
+
+	ObjectInfo(ctx context.Context, id ObjectID) (*ObjectInfo, error)
+	// ReadObject returns up to length bytes of the object, starting at offset.
+	// A negative length reads until the end of the object.
+	ReadObject(ctx context.Context, id ObjectID, offset, length int64) (io.ReadCloser, error)
+	// WriteObject creates or replaces the object with the content of data.
+	// Readers never observe a partially written object.
+	WriteObject(ctx context.Context, id ObjectID, data io.Reader) error
+	// DeleteObject deletes the object. Deleting a missing object is not an error.
+	DeleteObject(ctx context.Context, id ObjectID) error
+}
+
+// ObjectCopier is implemented by backends able to copy objects without
+// streaming them through the provider.
+type ObjectCopier interface {
+	CopyObject(ctx context.Context, src, dest ObjectID) error
+}
+
+// ObjectMover is implemented by backends able to move objects atomically.
+type ObjectMover interface {
+	MoveObject(ctx context.Context, src, dest ObjectID) error
+}
+
+// Handler serves `wrpc:blobstore/blobstore` from a [BlobBackend]. Reads,
+// writes and listings are streamed, with failures reported through the
+// future returned alongside each stream.
+//
+// Components only get access once linked, see [Handler.PutLink].
+type Handler struct {
+	backend BlobBackend
+
+	lock sync.RWMutex
+	// linked components, indexed by the component ID
+	links map[string]struct{}
+}
+
+var _ blobstore.Handler = (*Handler)(nil)
+
+func NewHandler(backend BlobBackend) *Handler {
+	return &Handler{
+		backend: backend,
+		links:   make(map[string]struct{}),
+	}
+}
+
+// Serve exports `wrpc:blobstore/blobstore` on s, usually the provider's RPCClient.
+func (h *Handler) Serve(s wrpc.Server) (stop func() error, err error) {
+	return blobstore.ServeInterface(s, h)
+}
+
+// PutLink grants the source component of link access to the backend.
+func (h *Handler) PutLink(link provider.InterfaceLinkDefinition) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.links[link.SourceID] = struct{}{}
+	return nil
+}
+
+// DelLink revokes access for the source component of link.
+func (h *Handler) DelLink(link provider.InterfaceLinkDefinition) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	delete(h.links, link.SourceID)
+	return nil
+}
+
+func (h *Handler) authorize(ctx context.Context) error {
+	sourceID, ok := provider.SourceIDFromContext(ctx)
+	if !ok {
+		return ErrAccessDenied
+	}
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	if _, ok := h.links[sourceID]; !ok {
+		return ErrAccessDenied
+	}
+	return nil
+}
+
+func (h *Handler) ClearContainer(ctx context.Context, name string) (*wrpc.Result[struct{}, string], error) {
+	if err := h.authorize(ctx); err != nil {
+		return fail[struct{}](err), nil
+	}
+	if _, err := h.backend.ContainerInfo(ctx, name); err != nil {
+		return fail[struct{}](err), nil
+	}
+	// Collect first, deleting while iterating could skip objects
+	var objects []string
+	for object, err := range h.backend.ListObjects(ctx, name) {
+		if err != nil {
+			return fail[struct{}](err), nil
+		}
+		objects = append(objects, object)
+	}
+	return done(h.deleteObjects(ctx, name, objects)), nil
+}
+
+func (h *Handler) ContainerExists(ctx context.Context, name string) (*wrpc.Result[bool, string], error) {
+	if err := h.authorize(ctx); err != nil {
+		return fail[bool](err), nil
+	}
+	_, err := h.backend.ContainerInfo(ctx, name)
+	switch {
+	case errors.Is(err, ErrContainerNotFound):
+		return wrpc.Ok[string](false), nil
+	case err != nil:
+		return fail[bool](err), nil
+	}
+	return wrpc.Ok[string](true), nil
+}
+
+func (h *Handler) CreateContainer(ctx context.Context, name string) (*wrpc.Result[struct{}, string], error) {
+	if err := h.authorize(ctx); err != nil {
+		return fail[struct{}](err), nil
+	}
+	return done(h.backend.CreateContainer(ctx, name)), nil
+}
+
+func (h *Handler) DeleteContainer(ctx context.Context, name string) (*wrpc.Result[struct{}, string], error) {
+	if err := h.authorize(ctx); err != nil {
+		return fail[struct{}](err), nil
+	}
+	return done(h.backend.DeleteContainer(ctx, name)), nil
+}
+
+func (h *Handler) GetContainerInfo(ctx context.Context, name string) (*wrpc.Result[blobstore.ContainerMetadata, string], error) {
+	if err := h.authorize(ctx); err != nil {
+		return fail[blobstore.ContainerMetadata](err), nil
+	}
+	info, err := h.backend.ContainerInfo(ctx, name)
+	if err != nil {
+		return fail[blobstore.ContainerMetadata](err), nil
+	}
+	return wrpc.Ok[string](blobstore.ContainerMetadata{CreatedAt: unixSeconds(info.CreatedAt)}), nil
+}
+
+func (h *Handler) ListContainerObjects(ctx context.Context, name string, limit *uint64, offset *uint64) (*wrpc.Result[wrpc.Tuple2[wrpc.Receiver[[]string], wrpc.Receiver[*wrpc.Result[struct{}, string]]], string], error) {
+	type listing = wrpc.Tuple2[wrpc.Receiver[[]string], wrpc.Receiver[*wrpc.Result[struct{}, string]]]
+	if err := h.authorize(ctx); err != nil {
+		return fail[listing](err), nil
+	}
+	if _, err := h.backend.ContainerInfo(ctx, name); err != nil {
+		return fail[listing](err), nil
+	}
+
+	names := h.backend.ListObjects(context.WithoutCancel(ctx), name)
+	if offset != nil {
+		names = skip(names, *offset)
+	}
+	if limit != nil {
+		names = take(names, *limit)
+	}
+	res := newResult()
+	return wrpc.Ok[string](listing{V0: newNameReceiver(names, res), V1: res}), nil
+}
+
+func (h *Handler) CopyObject(ctx context.Context, src *wrpctypes.ObjectId, dest *wrpctypes.ObjectId) (*wrpc.Result[struct{}, string], error) {
+	if err := h.authorize(ctx); err != nil {
+		return fail[struct{}](err), nil
+	}
+	return done(h.copyObject(ctx, objectID(src), objectID(dest))), nil
+}
+
+func (h *Handler) copyObject(ctx context.Context, src, dest ObjectID) error {
+	if c, ok := h.backend.(ObjectCopier); ok {
+		return c.CopyObject(ctx, src, dest)
+	}
+	r, err := h.backend.ReadObject(ctx, src, 0, -1)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	return h.backend.WriteObject(ctx, dest, r)
+}
+
+func (h *Handler) DeleteObject(ctx context.Context, id *wrpctypes.ObjectId) (*wrpc.Result[struct{}, string], error) {
+	if err := h.authorize(ctx); err != nil {
+		return fail[struct{}](err), nil
+	}
+	return done(h.backend.DeleteObject(ctx, objectID(id))), nil
+}
+
+func (h *Handler) DeleteObjects(ctx context.Context, container string, objects []string) (*wrpc.Result[struct{}, string], error) {
+	if err := h.authorize(ctx); err != nil {
+		return fail[struct{}](err), nil
+	}
+	return done(h.deleteObjects(ctx, container, objects)), nil
+}
+
+func (h *Handler) deleteObjects(ctx context.Context, container string, objects []string) error {
+	for _, object := range objects {
+		if err := h.backend.DeleteObject(ctx, ObjectID{Container: container, Object: object}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *Handler) GetContainerData(ctx context.Context, id *wrpctypes.ObjectId, start uint64, end uint64) (*wrpc.Result[wrpc.Tuple2[io.ReadCloser, wrpc.Receiver[*wrpc.Result[struct{}, string]]], string], error) {
+	type data = wrpc.Tuple2[io.ReadCloser, wrpc.Receiver[*wrpc.Result[struct{}, string]]]
+	if err := h.authorize(ctx); err != nil {
+		return fail[data](err), nil
+	}
+	if end < start || start > uint64(1<<63-1) {
+		return fail[data](fmt.Errorf("%w: %d-%d", ErrInvalidRange, start, end)), nil
+	}
+	// `end` is inclusive, reading past the end of the object stops at its end
+	length := int64(-1)
+	if end-start < uint64(1<<63-1) {
+		length = int64(end-start) + 1
+	}
+
+	r, err := h.backend.ReadObject(context.WithoutCancel(ctx), objectID(id), int64(start), length)
+	if err != nil {
+		return fail[data](err), nil
+	}
+	res := newResult()
+	return wrpc.Ok[string](data{V0: &resultReader{r: r, res: res}, V1: res}), nil
+}
+
+func (h *Handler) GetObjectInfo(ctx context.Context, id *wrpctypes.ObjectId) (*wrpc.Result[blobstore.ObjectMetadata, string], error) {
+	if err := h.authorize(ctx); err != nil {
+		return fail[blobstore.ObjectMetadata](err), nil
+	}
+	info, err := h.backend.ObjectInfo(ctx, objectID(id))
+	if err != nil {
+		return fail[blobstore.ObjectMetadata](err), nil
+	}
+	return wrpc.Ok[string](blobstore.ObjectMetadata{
+		CreatedAt: unixSeconds(info.CreatedAt),
+		Size:      uint64(info.Size),
+	}), nil
+}
+
+func (h *Handler) HasObject(ctx context.Context, id *wrpctypes.ObjectId) (*wrpc.Result[bool, string], error) {
+	if err := h.authorize(ctx); err != nil {
+		return fail[bool](err), nil
+	}
+	_, err := h.backend.ObjectInfo(ctx, objectID(id))
+	switch {
+	case errors.Is(err, ErrObjectNotFound):
+		return wrpc.Ok[string](false), nil
+	case err != nil:
+		return fail[bool](err), nil
+	}
+	return wrpc.Ok[string](true), nil
+}
+
+func (h *Handler) MoveObject(ctx context.Context, src *wrpctypes.ObjectId, dest *wrpctypes.ObjectId) (*wrpc.Result[struct{}, string], error) {
+	if err := h.authorize(ctx); err != nil {
+		return fail[struct{}](err), nil
+	}
+	from, to := objectID(src), objectID(dest)
+	if m, ok := h.backend.(ObjectMover); ok {
+		return done(m.MoveObject(ctx, from, to)), nil
+	}
+	if from == to {
+		return done(nil), nil
+	}
+	if err := h.copyObject(ctx, from, to); err != nil {
+		return fail[struct{}](err), nil
+	}
+	return done(h.backend.DeleteObject(ctx, from)), nil
+}
+
+func (h *Handler) WriteContainerData(ctx context.Context, id *wrpctypes.ObjectId, data io.ReadCloser) (*wrpc.Result[wrpc.Receiver[*wrpc.Result[struct{}, string]], string], error) {
+	if err := h.authorize(ctx); err != nil {
+		data.Close()
+		return fail[wrpc.Receiver[*wrpc.Result[struct{}, string]]](err), nil
+	}
+	oid := objectID(id)
+	// Fail early rather than after the component streamed the whole object
+	if _, err := h.backend.ContainerInfo(ctx, oid.Container); err != nil {
+		data.Close()
+		return fail[wrpc.Receiver[*wrpc.Result[struct{}, string]]](err), nil
+	}
+
+	// The data is streamed after the invocation returns
+	res := newResult()
+	go func() {
+		defer data.Close()
+		res.resolve(h.backend.WriteObject(context.WithoutCancel(ctx), oid, data))
+	}()
+	return wrpc.Ok[string](wrpc.Receiver[*wrpc.Result[struct{}, string]](res)), nil
+}
+
+func objectID(id *wrpctypes.ObjectId) ObjectID {
+	return ObjectID{Container: id.Container, Object: id.Object}
+}
+
+func unixSeconds(t time.Time) uint64 {
+	if t.IsZero() || t.Unix() < 0 {
+		return 0
+	}
+	return uint64(t.Unix())
+}
+
+func fail[T any](err error) *wrpc.Result[T, string] {
+	return wrpc.Err[T](err.Error())
+}
+
+func done(err error) *wrpc.Result[struct{}, string] {
+	if err != nil {
+		return fail[struct{}](err)
+	}
+	return wrpc.Ok[string](struct{}{})
+}
+
+// result is a `future<result<_, string>>` resolved once a streamed operation
+// completes.
+type result struct {
+	once sync.Once
+	done chan struct{}
+	err  error
+}
+
+func newResult() *result {
+	return &result{done: make(chan struct{})}
+}
+
+func (r *result) resolve(err error) {
+	r.once.Do(func() {
+		r.err = err
+		close(r.done)
+	})
+}
+
+func (r *result) Receive() (*wrpc.Result[struct{}, string], error) {
+	<-r.done
+	return done(r.err), nil
+}
+
+func (r *result) Close() error { return nil }
+
+// resultReader ends the stream cleanly on read errors, reporting them through
+// res instead so the component can tell a truncated object from a complete one.
+type resultReader struct {
+	r   io.ReadCloser
+	res *result
+}
+
+func (r *resultReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if err == io.EOF {
+		r.res.resolve(nil)
+	} else if err != nil {
+		r.res.resolve(err)
+		err = io.EOF
+	}
+	return n, err
+}
+
+func (r *resultReader) Close() error {
+	r.res.resolve(errors.New("stream closed before the end of the object"))
+	return r.r.Close()
+}
+
+// nameReceiver streams object names in chunks, reporting iteration errors
+// through res.
+type nameReceiver struct {
+	next func() (string, error, bool)
+	stop func()
+	res  *result
+}
+
+func newNameReceiver(names iter.Seq2[string, error], res *result) *nameReceiver {
+	next, stop := iter.Pull2(names)
+	return &nameReceiver{next: next, stop: stop, res: res}
+}
+
+func (r *nameReceiver) Receive() ([]string, error) {
+	var chunk []string
+	for len(chunk) < listChunkSize {
+		name, err, ok := r.next()
+		if !ok {
+			r.res.resolve(nil)
+			break
+		}
+		if err != nil {
+			r.res.resolve(err)
+			break
+		}
+		chunk = append(chunk, name)
+	}
+	if len(chunk) == 0 {
+		return nil, io.EOF
+	}
+	return chunk, nil
+}
+
+func (r *nameReceiver) Close() error {
+	r.stop()
+	r.res.resolve(errors.New("stream closed before the end of the listing"))
+	return nil
+}
+
+func skip(seq iter.Seq2[string, error], n uint64) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		var i uint64
+		for name, err := range seq {
+			if err == nil && i < n {
+				i++
+				continue
+			}
+			if !yield(name, err) {
+				return
+			}
+		}
+	}
+}
+
+func take(seq iter.Seq2[string, error], n uint64) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		if n == 0 {
+			return
+		}
+		var i uint64
+		for name, err := range seq {
+			if !yield(name, err) {
+				return
+			}
+			if err == nil {
+				if i++; i >= n {
+					return
+				}
+			}
+		}
+	}
+}
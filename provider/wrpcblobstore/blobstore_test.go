@@ -0,0 +1,223 @@
+package wrpcblobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"go.wasmcloud.dev/provider"
+	wrpctypes "go.wasmcloud.dev/provider/internal/wrpc/blobstore/types"
+	wrpc "wrpc.io/go"
+	wrpcnats "wrpc.io/go/nats"
+)
+
+func sourceContext(source string) context.Context {
+	return wrpcnats.ContextWithHeader(context.Background(), nats.Header{provider.SourceIDHeader: {source}})
+}
+
+func linkedHandler(t *testing.T) *Handler {
+	t.Helper()
+	h := NewHandler(newFilesystemBackend(t))
+	if err := h.PutLink(provider.InterfaceLinkDefinition{SourceID: "component"}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if res, _ := h.CreateContainer(sourceContext("component"), "container"); res.Err != nil {
+		t.Fatalf("unexpected error %s", *res.Err)
+	}
+	return h
+}
+
+func receiveAll[T any](t *testing.T, r wrpc.Receiver[[]T]) []T {
+	t.Helper()
+	var all []T
+	for {
+		chunk, err := r.Receive()
+		if err == io.EOF {
+			return all
+		}
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		all = append(all, chunk...)
+	}
+}
+
+func TestHandlerAccess(t *testing.T) {
+	h := linkedHandler(t)
+
+	for name, ctx := range map[string]context.Context{
+		"no header":        context.Background(),
+		"unlinked":         sourceContext("stranger"),
+		"linked component": sourceContext("component"),
+	} {
+		res, _ := h.ContainerExists(ctx, "container")
+		denied := res.Err != nil && *res.Err == ErrAccessDenied.Error()
+		if want := name != "linked component"; want != denied {
+			t.Errorf("%s: expected denied %v, got %v", name, want, res)
+		}
+	}
+
+	_ = h.DelLink(provider.InterfaceLinkDefinition{SourceID: "component"})
+	if res, _ := h.ContainerExists(sourceContext("component"), "container"); res.Err == nil {
+		t.Errorf("expected access to be revoked")
+	}
+}
+
+func TestHandlerData(t *testing.T) {
+	h := linkedHandler(t)
+	ctx := sourceContext("component")
+	id := &wrpctypes.ObjectId{Container: "container", Object: "object"}
+
+	write, _ := h.WriteContainerData(ctx, id, io.NopCloser(strings.NewReader("hello world")))
+	if write.Err != nil {
+		t.Fatalf("unexpected error %s", *write.Err)
+	}
+	if res, _ := (*write.Ok).Receive(); res.Err != nil {
+		t.Fatalf("unexpected error %s", *res.Err)
+	}
+
+	tt := map[string]struct {
+		start, end uint64
+		want       string
+	}{
+		"range":       {start: 6, end: 8, want: "wor"},
+		"past end":    {start: 6, end: 1 << 40, want: "world"},
+		"whole":       {start: 0, end: 1<<64 - 1, want: "hello world"},
+		"single byte": {start: 0, end: 0, want: "h"},
+	}
+	for name, tc := range tt {
+		read, _ := h.GetContainerData(ctx, id, tc.start, tc.end)
+		if read.Err != nil {
+			t.Fatalf("%s: unexpected error %s", name, *read.Err)
+		}
+		data, err := io.ReadAll(read.Ok.V0)
+		if err != nil || string(data) != tc.want {
+			t.Errorf("%s: expected %q, got %q, %v", name, tc.want, data, err)
+		}
+		if res, _ := read.Ok.V1.Receive(); res.Err != nil {
+			t.Errorf("%s: unexpected error %s", name, *res.Err)
+		}
+	}
+
+	if read, _ := h.GetContainerData(ctx, id, 2, 1); read.Err == nil {
+		t.Errorf("expected inverted range to fail")
+	}
+
+	missing := &wrpctypes.ObjectId{Container: "missing", Object: "object"}
+	write, _ = h.WriteContainerData(ctx, missing, io.NopCloser(strings.NewReader("data")))
+	if write.Err == nil || *write.Err != ErrContainerNotFound.Error() {
+		t.Errorf("expected write to a missing container to fail, got %v", write)
+	}
+}
+
+type failingBackend struct {
+	BlobBackend
+}
+
+func (failingBackend) ReadObject(context.Context, ObjectID, int64, int64) (io.ReadCloser, error) {
+	return io.NopCloser(io.MultiReader(strings.NewReader("partial"), failingReader{})), nil
+}
+
+func TestHandlerReadFailure(t *testing.T) {
+	h := linkedHandler(t)
+	h.backend = failingBackend{h.backend}
+
+	read, _ := h.GetContainerData(sourceContext("component"), &wrpctypes.ObjectId{Container: "container", Object: "o"}, 0, 100)
+	data, err := io.ReadAll(read.Ok.V0)
+	if err != nil || string(data) != "partial" {
+		t.Errorf("expected stream to end cleanly, got %q, %v", data, err)
+	}
+	if res, _ := read.Ok.V1.Receive(); res.Err == nil || *res.Err != "broken" {
+		t.Errorf("expected read error to be reported, got %v", res)
+	}
+}
+
+func TestHandlerObjects(t *testing.T) {
+	h := linkedHandler(t)
+	ctx := sourceContext("component")
+
+	var names []string
+	for i := range 600 {
+		name := strings.Repeat("x", i%3+1) + string(rune('a'+i%26)) + strings.Repeat("y", i/26)
+		names = append(names, name)
+		if err := h.backend.WriteObject(ctx, ObjectID{Container: "container", Object: name}, strings.NewReader(name)); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+	slices.Sort(names)
+	names = slices.Compact(names)
+
+	list := func(limit, offset *uint64) []string {
+		res, _ := h.ListContainerObjects(ctx, "container", limit, offset)
+		if res.Err != nil {
+			t.Fatalf("unexpected error %s", *res.Err)
+		}
+		got := receiveAll(t, res.Ok.V0)
+		if done, _ := res.Ok.V1.Receive(); done.Err != nil {
+			t.Fatalf("unexpected error %s", *done.Err)
+		}
+		return got
+	}
+	ptr := func(v uint64) *uint64 { return &v }
+
+	if got := list(nil, nil); !slices.Equal(got, names) {
+		t.Errorf("expected all %d objects, got %d", len(names), len(got))
+	}
+	if got := list(ptr(10), ptr(5)); !slices.Equal(got, names[5:15]) {
+		t.Errorf("expected %v, got %v", names[5:15], got)
+	}
+	if got := list(ptr(0), nil); len(got) != 0 {
+		t.Errorf("expected empty page, got %v", got)
+	}
+	if got := list(nil, ptr(uint64(len(names)))); len(got) != 0 {
+		t.Errorf("expected empty page, got %v", got)
+	}
+
+	src := &wrpctypes.ObjectId{Container: "container", Object: names[0]}
+	dest := &wrpctypes.ObjectId{Container: "other", Object: "copy"}
+	if res, _ := h.CopyObject(ctx, src, dest); res.Err == nil || *res.Err != ErrContainerNotFound.Error() {
+		t.Errorf("expected copy to a missing container to fail, got %v", res)
+	}
+	_, _ = h.CreateContainer(ctx, "other")
+	if res, _ := h.CopyObject(ctx, src, dest); res.Err != nil {
+		t.Fatalf("unexpected error %s", *res.Err)
+	}
+	if res, _ := h.HasObject(ctx, src); res.Ok == nil || !*res.Ok {
+		t.Errorf("expected copy to keep the source, got %v", res)
+	}
+	if res, _ := h.MoveObject(ctx, dest, src); res.Err != nil {
+		t.Fatalf("unexpected error %s", *res.Err)
+	}
+	if res, _ := h.HasObject(ctx, dest); res.Ok == nil || *res.Ok {
+		t.Errorf("expected move to remove the source, got %v", res)
+	}
+	if res, _ := h.GetObjectInfo(ctx, src); res.Err != nil || res.Ok.Size != uint64(len(names[0])) {
+		t.Errorf("expected moved object info, got %v", res)
+	}
+
+	if res, _ := h.DeleteObjects(ctx, "container", names[:2]); res.Err != nil {
+		t.Fatalf("unexpected error %s", *res.Err)
+	}
+	if got := list(nil, nil); !slices.Equal(got, names[2:]) {
+		t.Errorf("expected deleted objects to be gone")
+	}
+	if res, _ := h.ClearContainer(ctx, "container"); res.Err != nil {
+		t.Fatalf("unexpected error %s", *res.Err)
+	}
+	if got := list(nil, nil); len(got) != 0 {
+		t.Errorf("expected cleared container to be empty, got %d objects", len(got))
+	}
+	if res, _ := h.ContainerExists(ctx, "container"); res.Ok == nil || !*res.Ok {
+		t.Errorf("expected cleared container to still exist, got %v", res)
+	}
+	if res, _ := h.ClearContainer(ctx, "missing"); res.Err == nil {
+		t.Errorf("expected clearing a missing container to fail")
+	}
+	if _, err := h.backend.ContainerInfo(ctx, "missing"); !errors.Is(err, ErrContainerNotFound) {
+		t.Errorf("expected missing container, got %v", err)
+	}
+}
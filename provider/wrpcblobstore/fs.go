@@ -0,0 +1,260 @@
+package wrpcblobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"iter"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// fsUploadDir holds objects being written, escaped names never start with a dot.
+const fsUploadDir = ".uploads"
+
+// FilesystemBackend is a [BlobBackend] storing containers as directories and
+// objects as files under a root directory. Names are escaped, so objects may
+// contain slashes and can never resolve outside of their container.
+//
+// Objects are written to a temporary file first and renamed once complete.
+// Filesystems don't portably record creation times, the modification time is
+// reported instead.
+type FilesystemBackend struct {
+	root string
+}
+
+var (
+	_ BlobBackend = (*FilesystemBackend)(nil)
+	_ ObjectMover = (*FilesystemBackend)(nil)
+)
+
+// NewFilesystemBackend stores containers in root, creating it if needed.
+func NewFilesystemBackend(root string) (*FilesystemBackend, error) {
+	if err := os.MkdirAll(filepath.Join(root, fsUploadDir), 0o700); err != nil {
+		return nil, err
+	}
+	return &FilesystemBackend{root: root}, nil
+}
+
+func (f *FilesystemBackend) CreateContainer(_ context.Context, container string) error {
+	dir, err := f.containerPath(container)
+	if err != nil {
+		return err
+	}
+	if err := os.Mkdir(dir, 0o700); err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return ErrContainerExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (f *FilesystemBackend) DeleteContainer(_ context.Context, container string) error {
+	dir, err := f.containerPath(container)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return containerError(err)
+	}
+	return os.RemoveAll(dir)
+}
+
+func (f *FilesystemBackend) ContainerInfo(_ context.Context, container string) (*ContainerInfo, error) {
+	dir, err := f.containerPath(container)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := os.Stat(dir)
+	if err != nil {
+		return nil, containerError(err)
+	}
+	return &ContainerInfo{CreatedAt: fi.ModTime()}, nil
+}
+
+func (f *FilesystemBackend) ListObjects(_ context.Context, container string) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		dir, err := f.containerPath(container)
+		if err != nil {
+			yield("", err)
+			return
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			yield("", containerError(err))
+			return
+		}
+		// Entries are sorted by escaped name, list by object name instead
+		names := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			name, err := url.PathUnescape(entry.Name())
+			if err != nil || !entry.Type().IsRegular() {
+				continue
+			}
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			if !yield(name, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (f *FilesystemBackend) ObjectInfo(_ context.Context, id ObjectID) (*ObjectInfo, error) {
+	path, err := f.objectPath(id)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, f.objectError(id, err)
+	}
+	return &ObjectInfo{CreatedAt: fi.ModTime(), Size: fi.Size()}, nil
+}
+
+func (f *FilesystemBackend) ReadObject(_ context.Context, id ObjectID, offset, length int64) (io.ReadCloser, error) {
+	if offset < 0 {
+		return nil, ErrInvalidRange
+	}
+	path, err := f.objectPath(id)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, f.objectError(id, err)
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if length < 0 {
+		return file, nil
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(file, length), file}, nil
+}
+
+func (f *FilesystemBackend) WriteObject(_ context.Context, id ObjectID, data io.Reader) error {
+	path, err := f.objectPath(id)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Dir(path)); err != nil {
+		return containerError(err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Join(f.root, fsUploadDir), "object-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return containerError(err)
+	}
+	return nil
+}
+
+func (f *FilesystemBackend) DeleteObject(_ context.Context, id ObjectID) error {
+	path, err := f.objectPath(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// MoveObject renames the object file, so it is atomic within a filesystem.
+func (f *FilesystemBackend) MoveObject(_ context.Context, src, dest ObjectID) error {
+	from, err := f.objectPath(src)
+	if err != nil {
+		return err
+	}
+	to, err := f.objectPath(dest)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Dir(to)); err != nil {
+		return containerError(err)
+	}
+	if err := os.Rename(from, to); err != nil {
+		return f.objectError(src, err)
+	}
+	return nil
+}
+
+func (f *FilesystemBackend) containerPath(container string) (string, error) {
+	name, err := escapeName(container)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(f.root, name), nil
+}
+
+func (f *FilesystemBackend) objectPath(id ObjectID) (string, error) {
+	dir, err := f.containerPath(id.Container)
+	if err != nil {
+		return "", err
+	}
+	name, err := escapeName(id.Object)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// objectError maps a missing file to [ErrObjectNotFound], or to
+// [ErrContainerNotFound] if the whole container is missing.
+func (f *FilesystemBackend) objectError(id ObjectID, err error) error {
+	if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if dir, perr := f.containerPath(id.Container); perr == nil {
+		if _, serr := os.Stat(dir); errors.Is(serr, fs.ErrNotExist) {
+			return ErrContainerNotFound
+		}
+	}
+	return ErrObjectNotFound
+}
+
+func containerError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrContainerNotFound
+	}
+	return err
+}
+
+// escapeName maps name to a single path element. A leading dot is escaped
+// too, so names can't refer to the parent directory or to hidden files.
+func escapeName(name string) (string, error) {
+	if name == "" {
+		return "", ErrInvalidName
+	}
+	escaped := url.PathEscape(name)
+	if rest, ok := strings.CutPrefix(escaped, "."); ok {
+		escaped = "%2E" + rest
+	}
+	return escaped, nil
+}
@@ -0,0 +1,166 @@
+package wrpcblobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func newFilesystemBackend(t *testing.T) *FilesystemBackend {
+	t.Helper()
+	b, err := NewFilesystemBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	return b
+}
+
+func readObject(t *testing.T, b BlobBackend, id ObjectID, offset, length int64) string {
+	t.Helper()
+	r, err := b.ReadObject(context.Background(), id, offset, length)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	return string(data)
+}
+
+func listObjects(t *testing.T, b BlobBackend, container string) []string {
+	t.Helper()
+	var names []string
+	for name, err := range b.ListObjects(context.Background(), container) {
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestFilesystemBackend(t *testing.T) {
+	ctx := context.Background()
+	b := newFilesystemBackend(t)
+	id := ObjectID{Container: "photos", Object: "2024/cat.jpg"}
+
+	if err := b.WriteObject(ctx, id, strings.NewReader("meow")); !errors.Is(err, ErrContainerNotFound) {
+		t.Errorf("expected write to missing container to fail, got %v", err)
+	}
+	if err := b.CreateContainer(ctx, "photos"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := b.CreateContainer(ctx, "photos"); !errors.Is(err, ErrContainerExists) {
+		t.Errorf("expected duplicate container to fail, got %v", err)
+	}
+
+	if _, err := b.ObjectInfo(ctx, id); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("expected missing object, got %v", err)
+	}
+	if err := b.WriteObject(ctx, id, strings.NewReader("meow")); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	info, err := b.ObjectInfo(ctx, id)
+	if err != nil || info.Size != 4 {
+		t.Errorf("expected 4 byte object, got %v, %v", info, err)
+	}
+	if got := readObject(t, b, id, 1, 2); got != "eo" {
+		t.Errorf("expected range to read %q, got %q", "eo", got)
+	}
+	if got := readObject(t, b, id, 2, -1); got != "ow" {
+		t.Errorf("expected tail to read %q, got %q", "ow", got)
+	}
+
+	dest := ObjectID{Container: "photos", Object: "cat.jpg"}
+	if err := b.MoveObject(ctx, id, dest); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if got := listObjects(t, b, "photos"); !slices.Equal(got, []string{"cat.jpg"}) {
+		t.Errorf("expected moved object to be listed, got %v", got)
+	}
+	if err := b.DeleteObject(ctx, dest); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := b.DeleteObject(ctx, dest); err != nil {
+		t.Errorf("expected deleting a missing object to succeed, got %v", err)
+	}
+
+	if err := b.DeleteContainer(ctx, "photos"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, err := b.ContainerInfo(ctx, "photos"); !errors.Is(err, ErrContainerNotFound) {
+		t.Errorf("expected deleted container to be missing, got %v", err)
+	}
+	if _, err := b.ObjectInfo(ctx, dest); !errors.Is(err, ErrContainerNotFound) {
+		t.Errorf("expected missing container, got %v", err)
+	}
+}
+
+func TestFilesystemBackendNames(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	b, err := NewFilesystemBackend(filepath.Join(root, "blobs"))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	for _, name := range []string{"..", ".", ".uploads", "../escape"} {
+		if err := b.CreateContainer(ctx, name); err != nil {
+			t.Errorf("%q: unexpected error %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(root, "escape")); err == nil {
+		t.Errorf("expected container to stay within the root")
+	}
+	if err := b.CreateContainer(ctx, ""); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("expected empty name to be rejected, got %v", err)
+	}
+
+	objects := []string{"b", "a/b", "a", "..", "ä"}
+	for _, object := range objects {
+		if err := b.WriteObject(ctx, ObjectID{Container: "..", Object: object}, strings.NewReader(object)); err != nil {
+			t.Fatalf("%q: unexpected error %v", object, err)
+		}
+	}
+	slices.Sort(objects)
+	if got := listObjects(t, b, ".."); !slices.Equal(got, objects) {
+		t.Errorf("expected %v, got %v", objects, got)
+	}
+	if got := readObject(t, b, ObjectID{Container: "..", Object: "a/b"}, 0, -1); got != "a/b" {
+		t.Errorf("expected %q, got %q", "a/b", got)
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("broken") }
+
+func TestFilesystemBackendFailedWrite(t *testing.T) {
+	ctx := context.Background()
+	b := newFilesystemBackend(t)
+	id := ObjectID{Container: "c", Object: "o"}
+	if err := b.CreateContainer(ctx, "c"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := b.WriteObject(ctx, id, strings.NewReader("old")); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := b.WriteObject(ctx, id, io.MultiReader(strings.NewReader("new"), failingReader{})); err == nil {
+		t.Fatalf("expected write to fail")
+	}
+	if got := readObject(t, b, id, 0, -1); got != "old" {
+		t.Errorf("expected failed write to leave the object untouched, got %q", got)
+	}
+	uploads, _ := os.ReadDir(filepath.Join(b.root, fsUploadDir))
+	if len(uploads) != 0 {
+		t.Errorf("expected temporary files to be removed, got %v", uploads)
+	}
+}
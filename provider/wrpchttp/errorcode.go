@@ -0,0 +1,371 @@
+package wrpchttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	wasitypes "go.wasmcloud.dev/provider/internal/wasi/http/types"
+	wrpctypes "go.wasmcloud.dev/provider/internal/wrpc/http/types"
+)
+
+// ErrorCode is a `wasi:http` error code, usable as a sentinel with [errors.Is].
+// Details carried by some codes are available through [Error] with [errors.As].
+type ErrorCode uint8
+
+// These mirror the cases of the `wasi:http/types` `error-code` variant.
+const (
+	ErrDNSTimeout ErrorCode = iota
+	ErrDNSError
+	ErrDestinationNotFound
+	ErrDestinationUnavailable
+	ErrDestinationIPProhibited
+	ErrDestinationIPUnroutable
+	ErrConnectionRefused
+	ErrConnectionTerminated
+	ErrConnectionTimeout
+	ErrConnectionReadTimeout
+	ErrConnectionWriteTimeout
+	ErrConnectionLimitReached
+	ErrTLSProtocolError
+	ErrTLSCertificateError
+	ErrTLSAlertReceived
+	ErrHTTPRequestDenied
+	ErrHTTPRequestLengthRequired
+	ErrHTTPRequestBodySize
+	ErrHTTPRequestMethodInvalid
+	ErrHTTPRequestURIInvalid
+	ErrHTTPRequestURITooLong
+	ErrHTTPRequestHeaderSectionSize
+	ErrHTTPRequestHeaderSize
+	ErrHTTPRequestTrailerSectionSize
+	ErrHTTPRequestTrailerSize
+	ErrHTTPResponseIncomplete
+	ErrHTTPResponseHeaderSectionSize
+	ErrHTTPResponseHeaderSize
+	ErrHTTPResponseBodySize
+	ErrHTTPResponseTrailerSectionSize
+	ErrHTTPResponseTrailerSize
+	ErrHTTPResponseTransferCoding
+	ErrHTTPResponseContentCoding
+	ErrHTTPResponseTimeout
+	ErrHTTPUpgradeFailed
+	ErrHTTPProtocolError
+	ErrLoopDetected
+	ErrConfigurationError
+	ErrInternalError
+)
+
+var errorCodeNames = [...]string{
+	ErrDNSTimeout:                     "DNS-timeout",
+	ErrDNSError:                       "DNS-error",
+	ErrDestinationNotFound:            "destination-not-found",
+	ErrDestinationUnavailable:         "destination-unavailable",
+	ErrDestinationIPProhibited:        "destination-IP-prohibited",
+	ErrDestinationIPUnroutable:        "destination-IP-unroutable",
+	ErrConnectionRefused:              "connection-refused",
+	ErrConnectionTerminated:           "connection-terminated",
+	ErrConnectionTimeout:              "connection-timeout",
+	ErrConnectionReadTimeout:          "connection-read-timeout",
+	ErrConnectionWriteTimeout:         "connection-write-timeout",
+	ErrConnectionLimitReached:         "connection-limit-reached",
+	ErrTLSProtocolError:               "TLS-protocol-error",
+	ErrTLSCertificateError:            "TLS-certificate-error",
+	ErrTLSAlertReceived:               "TLS-alert-received",
+	ErrHTTPRequestDenied:              "HTTP-request-denied",
+	ErrHTTPRequestLengthRequired:      "HTTP-request-length-required",
+	ErrHTTPRequestBodySize:            "HTTP-request-body-size",
+	ErrHTTPRequestMethodInvalid:       "HTTP-request-method-invalid",
+	ErrHTTPRequestURIInvalid:          "HTTP-request-URI-invalid",
+	ErrHTTPRequestURITooLong:          "HTTP-request-URI-too-long",
+	ErrHTTPRequestHeaderSectionSize:   "HTTP-request-header-section-size",
+	ErrHTTPRequestHeaderSize:          "HTTP-request-header-size",
+	ErrHTTPRequestTrailerSectionSize:  "HTTP-request-trailer-section-size",
+	ErrHTTPRequestTrailerSize:         "HTTP-request-trailer-size",
+	ErrHTTPResponseIncomplete:         "HTTP-response-incomplete",
+	ErrHTTPResponseHeaderSectionSize:  "HTTP-response-header-section-size",
+	ErrHTTPResponseHeaderSize:         "HTTP-response-header-size",
+	ErrHTTPResponseBodySize:           "HTTP-response-body-size",
+	ErrHTTPResponseTrailerSectionSize: "HTTP-response-trailer-section-size",
+	ErrHTTPResponseTrailerSize:        "HTTP-response-trailer-size",
+	ErrHTTPResponseTransferCoding:     "HTTP-response-transfer-coding",
+	ErrHTTPResponseContentCoding:      "HTTP-response-content-coding",
+	ErrHTTPResponseTimeout:            "HTTP-response-timeout",
+	ErrHTTPUpgradeFailed:              "HTTP-upgrade-failed",
+	ErrHTTPProtocolError:              "HTTP-protocol-error",
+	ErrLoopDetected:                   "loop-detected",
+	ErrConfigurationError:             "configuration-error",
+	ErrInternalError:                  "internal-error",
+}
+
+func (c ErrorCode) Error() string {
+	if int(c) < len(errorCodeNames) {
+		return errorCodeNames[c]
+	}
+	return fmt.Sprintf("unknown error code %d", uint8(c))
+}
+
+// Timeout reports whether c is one of the timeout codes.
+func (c ErrorCode) Timeout() bool {
+	switch c {
+	case ErrDNSTimeout, ErrConnectionTimeout, ErrConnectionReadTimeout, ErrConnectionWriteTimeout, ErrHTTPResponseTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// HTTPStatus returns the status a proxy answers with when a request fails with c.
+func (c ErrorCode) HTTPStatus() int {
+	switch c {
+	case ErrDNSTimeout, ErrConnectionTimeout, ErrConnectionReadTimeout, ErrConnectionWriteTimeout, ErrHTTPResponseTimeout:
+		return http.StatusGatewayTimeout
+	case ErrDestinationUnavailable, ErrConnectionRefused, ErrConnectionLimitReached:
+		return http.StatusServiceUnavailable
+	case ErrHTTPRequestDenied:
+		return http.StatusForbidden
+	case ErrHTTPRequestLengthRequired:
+		return http.StatusLengthRequired
+	case ErrHTTPRequestBodySize:
+		return http.StatusRequestEntityTooLarge
+	case ErrHTTPRequestMethodInvalid:
+		return http.StatusMethodNotAllowed
+	case ErrHTTPRequestURIInvalid:
+		return http.StatusBadRequest
+	case ErrHTTPRequestURITooLong:
+		return http.StatusRequestURITooLong
+	case ErrHTTPRequestHeaderSectionSize, ErrHTTPRequestHeaderSize:
+		return http.StatusRequestHeaderFieldsTooLarge
+	case ErrLoopDetected:
+		return http.StatusLoopDetected
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+// Error is a `wasi:http` error code along with its details. It matches its
+// [ErrorCode] with [errors.Is].
+type Error struct {
+	Code ErrorCode
+
+	// Rcode and InfoCode detail [ErrDNSError].
+	Rcode    *string
+	InfoCode *uint16
+	// AlertID and AlertMessage detail [ErrTLSAlertReceived].
+	AlertID      *uint8
+	AlertMessage *string
+	// FieldName details header and trailer size codes.
+	FieldName *string
+	// Size details body, section and field size codes.
+	Size *uint64
+	// Message details transfer coding, content coding and internal errors.
+	Message *string
+}
+
+func (e *Error) Error() string {
+	var details []string
+	add := func(name string, v any) {
+		details = append(details, fmt.Sprintf("%s=%v", name, v))
+	}
+	if e.Rcode != nil {
+		add("rcode", *e.Rcode)
+	}
+	if e.InfoCode != nil {
+		add("info-code", *e.InfoCode)
+	}
+	if e.AlertID != nil {
+		add("alert-id", *e.AlertID)
+	}
+	if e.AlertMessage != nil {
+		add("alert-message", *e.AlertMessage)
+	}
+	if e.FieldName != nil {
+		add("field-name", *e.FieldName)
+	}
+	if e.Size != nil {
+		add("size", *e.Size)
+	}
+	if e.Message != nil {
+		details = append(details, *e.Message)
+	}
+	if len(details) == 0 {
+		return e.Code.Error()
+	}
+	return e.Code.Error() + ": " + strings.Join(details, ", ")
+}
+
+func (e *Error) Unwrap() error { return e.Code }
+
+func (e *Error) Timeout() bool { return e.Code.Timeout() }
+
+// FromErrorCode converts a `wasi:http` error code to an [*Error].
+func FromErrorCode(code *wrpctypes.ErrorCode) *Error {
+	e := &Error{Code: ErrorCode(code.Discriminant())}
+
+	switch payload := code.Payload().(type) {
+	case *wasitypes.DnsErrorPayload:
+		if payload != nil {
+			e.Rcode, e.InfoCode = payload.Rcode, payload.InfoCode
+		}
+	case *wasitypes.TlsAlertReceivedPayload:
+		if payload != nil {
+			e.AlertID, e.AlertMessage = payload.AlertId, payload.AlertMessage
+		}
+	case *wasitypes.FieldSizePayload:
+		if payload == nil {
+			break
+		}
+		e.FieldName = payload.FieldName
+		if payload.FieldSize != nil {
+			size := uint64(*payload.FieldSize)
+			e.Size = &size
+		}
+	case *uint32:
+		if payload != nil {
+			size := uint64(*payload)
+			e.Size = &size
+		}
+	case *uint64:
+		e.Size = payload
+	case *string:
+		e.Message = payload
+	}
+	return e
+}
+
+// ErrorCode converts e back to a `wasi:http` error code.
+func (e *Error) ErrorCode() *wrpctypes.ErrorCode {
+	size32 := func() *uint32 {
+		if e.Size == nil {
+			return nil
+		}
+		size := uint32(min(*e.Size, uint64(^uint32(0))))
+		return &size
+	}
+	fieldSize := func() *wasitypes.FieldSizePayload {
+		return &wasitypes.FieldSizePayload{FieldName: e.FieldName, FieldSize: size32()}
+	}
+
+	switch e.Code {
+	case ErrDNSTimeout:
+		return wasitypes.NewErrorCodeDnsTimeout()
+	case ErrDNSError:
+		return wasitypes.NewErrorCodeDnsError(&wasitypes.DnsErrorPayload{Rcode: e.Rcode, InfoCode: e.InfoCode})
+	case ErrDestinationNotFound:
+		return wasitypes.NewErrorCodeDestinationNotFound()
+	case ErrDestinationUnavailable:
+		return wasitypes.NewErrorCodeDestinationUnavailable()
+	case ErrDestinationIPProhibited:
+		return wasitypes.NewErrorCodeDestinationIpProhibited()
+	case ErrDestinationIPUnroutable:
+		return wasitypes.NewErrorCodeDestinationIpUnroutable()
+	case ErrConnectionRefused:
+		return wasitypes.NewErrorCodeConnectionRefused()
+	case ErrConnectionTerminated:
+		return wasitypes.NewErrorCodeConnectionTerminated()
+	case ErrConnectionTimeout:
+		return wasitypes.NewErrorCodeConnectionTimeout()
+	case ErrConnectionReadTimeout:
+		return wasitypes.NewErrorCodeConnectionReadTimeout()
+	case ErrConnectionWriteTimeout:
+		return wasitypes.NewErrorCodeConnectionWriteTimeout()
+	case ErrConnectionLimitReached:
+		return wasitypes.NewErrorCodeConnectionLimitReached()
+	case ErrTLSProtocolError:
+		return wasitypes.NewErrorCodeTlsProtocolError()
+	case ErrTLSCertificateError:
+		return wasitypes.NewErrorCodeTlsCertificateError()
+	case ErrTLSAlertReceived:
+		return wasitypes.NewErrorCodeTlsAlertReceived(&wasitypes.TlsAlertReceivedPayload{AlertId: e.AlertID, AlertMessage: e.AlertMessage})
+	case ErrHTTPRequestDenied:
+		return wasitypes.NewErrorCodeHttpRequestDenied()
+	case ErrHTTPRequestLengthRequired:
+		return wasitypes.NewErrorCodeHttpRequestLengthRequired()
+	case ErrHTTPRequestBodySize:
+		return wasitypes.NewErrorCodeHttpRequestBodySize(e.Size)
+	case ErrHTTPRequestMethodInvalid:
+		return wasitypes.NewErrorCodeHttpRequestMethodInvalid()
+	case ErrHTTPRequestURIInvalid:
+		return wasitypes.NewErrorCodeHttpRequestUriInvalid()
+	case ErrHTTPRequestURITooLong:
+		return wasitypes.NewErrorCodeHttpRequestUriTooLong()
+	case ErrHTTPRequestHeaderSectionSize:
+		return wasitypes.NewErrorCodeHttpRequestHeaderSectionSize(size32())
+	case ErrHTTPRequestHeaderSize:
+		if e.FieldName == nil && e.Size == nil {
+			return wasitypes.NewErrorCodeHttpRequestHeaderSize(nil)
+		}
+		return wasitypes.NewErrorCodeHttpRequestHeaderSize(fieldSize())
+	case ErrHTTPRequestTrailerSectionSize:
+		return wasitypes.NewErrorCodeHttpRequestTrailerSectionSize(size32())
+	case ErrHTTPRequestTrailerSize:
+		return wasitypes.NewErrorCodeHttpRequestTrailerSize(fieldSize())
+	case ErrHTTPResponseIncomplete:
+		return wasitypes.NewErrorCodeHttpResponseIncomplete()
+	case ErrHTTPResponseHeaderSectionSize:
+		return wasitypes.NewErrorCodeHttpResponseHeaderSectionSize(size32())
+	case ErrHTTPResponseHeaderSize:
+		return wasitypes.NewErrorCodeHttpResponseHeaderSize(fieldSize())
+	case ErrHTTPResponseBodySize:
+		return wasitypes.NewErrorCodeHttpResponseBodySize(e.Size)
+	case ErrHTTPResponseTrailerSectionSize:
+		return wasitypes.NewErrorCodeHttpResponseTrailerSectionSize(size32())
+	case ErrHTTPResponseTrailerSize:
+		return wasitypes.NewErrorCodeHttpResponseTrailerSize(fieldSize())
+	case ErrHTTPResponseTransferCoding:
+		return wasitypes.NewErrorCodeHttpResponseTransferCoding(e.Message)
+	case ErrHTTPResponseContentCoding:
+		return wasitypes.NewErrorCodeHttpResponseContentCoding(e.Message)
+	case ErrHTTPResponseTimeout:
+		return wasitypes.NewErrorCodeHttpResponseTimeout()
+	case ErrHTTPUpgradeFailed:
+		return wasitypes.NewErrorCodeHttpUpgradeFailed()
+	case ErrHTTPProtocolError:
+		return wasitypes.NewErrorCodeHttpProtocolError()
+	case ErrLoopDetected:
+		return wasitypes.NewErrorCodeLoopDetected()
+	case ErrConfigurationError:
+		return wasitypes.NewErrorCodeConfigurationError()
+	default:
+		return wasitypes.NewErrorCodeInternalError(e.Message)
+	}
+}
+
+// ToErrorCode converts any error to the closest `wasi:http` error code.
+// [*Error] and [ErrorCode] convert exactly, transport errors such as DNS or
+// TLS failures are mapped to their case and anything else becomes
+// `internal-error`.
+func ToErrorCode(err error) *wrpctypes.ErrorCode {
+	var (
+		e    *Error
+		code ErrorCode
+	)
+	switch {
+	case errors.As(err, &e):
+		return e.ErrorCode()
+	case errors.As(err, &code):
+		return (&Error{Code: code}).ErrorCode()
+	default:
+		return errorCodeFromTransport(err)
+	}
+}
+
+// StatusForError returns the HTTP status code to answer with when
+// [IncomingRoundTripper.RoundTrip] fails with err.
+func StatusForError(err error) int {
+	var code ErrorCode
+	switch {
+	case errors.Is(err, ErrNoRoute):
+		return http.StatusNotFound
+	case errors.Is(err, ErrNoLinks), errors.Is(err, ErrNoTarget):
+		return http.StatusServiceUnavailable
+	case errors.As(err, &code):
+		return code.HTTPStatus()
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusBadGateway
+	}
+}
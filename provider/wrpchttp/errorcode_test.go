@@ -0,0 +1,116 @@
+package wrpchttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	wasitypes "go.wasmcloud.dev/provider/internal/wasi/http/types"
+)
+
+func TestErrorCodeRoundtrip(t *testing.T) {
+	for code := ErrDNSTimeout; code <= ErrInternalError; code++ {
+		wasiCode := (&Error{Code: code}).ErrorCode()
+		if want, got := uint8(code), uint8(wasiCode.Discriminant()); want != got {
+			t.Errorf("%s: expected discriminant %d, got %d", code, want, got)
+		}
+		if want, got := wasiCode.String(), code.Error(); want != got {
+			t.Errorf("expected name %s, got %s", want, got)
+		}
+		if got := FromErrorCode(wasiCode); got.Code != code {
+			t.Errorf("%s: expected code back, got %s", code, got.Code)
+		}
+	}
+}
+
+func TestErrorCodePayloads(t *testing.T) {
+	rcode := "NXDOMAIN"
+	e := FromErrorCode(wasitypes.NewErrorCodeDnsError(&wasitypes.DnsErrorPayload{Rcode: &rcode}))
+	if e.Rcode == nil || *e.Rcode != rcode {
+		t.Errorf("expected rcode %s, got %v", rcode, e.Rcode)
+	}
+	if want, got := "DNS-error: rcode=NXDOMAIN", e.Error(); want != got {
+		t.Errorf("expected message %q, got %q", want, got)
+	}
+
+	name, size := "x-big", uint32(9000)
+	e = FromErrorCode(wasitypes.NewErrorCodeHttpResponseTrailerSize(&wasitypes.FieldSizePayload{FieldName: &name, FieldSize: &size}))
+	if e.FieldName == nil || *e.FieldName != name || e.Size == nil || *e.Size != uint64(size) {
+		t.Errorf("expected field size payload, got %+v", e)
+	}
+	back := e.ErrorCode()
+	if got := FromErrorCode(back); *got.Size != uint64(size) || *got.FieldName != name {
+		t.Errorf("expected payload to survive roundtrip, got %+v", got)
+	}
+
+	msg := "boom"
+	e = FromErrorCode(wasitypes.NewErrorCodeInternalError(&msg))
+	if e.Message == nil || *e.Message != msg {
+		t.Errorf("expected message %s, got %v", msg, e.Message)
+	}
+}
+
+func TestErrorIsAs(t *testing.T) {
+	err := fmt.Errorf("%w: %w", ErrRPC, FromErrorCode(wasitypes.NewErrorCodeConnectionTimeout()))
+
+	if !errors.Is(err, ErrRPC) {
+		t.Error("expected ErrRPC")
+	}
+	if !errors.Is(err, ErrConnectionTimeout) {
+		t.Error("expected ErrConnectionTimeout")
+	}
+	if errors.Is(err, ErrDNSTimeout) {
+		t.Error("unexpected ErrDNSTimeout")
+	}
+
+	var e *Error
+	if !errors.As(err, &e) || e.Code != ErrConnectionTimeout {
+		t.Errorf("expected *Error, got %v", e)
+	}
+	var netErr interface{ Timeout() bool }
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Error("expected timeout error")
+	}
+}
+
+func TestToErrorCode(t *testing.T) {
+	tt := map[string]struct {
+		err  error
+		want wasitypes.ErrorCodeDiscriminant
+	}{
+		"typed":     {err: &Error{Code: ErrHTTPRequestDenied}, want: wasitypes.ErrorCodeHttpRequestDenied},
+		"sentinel":  {err: fmt.Errorf("wrapped: %w", ErrLoopDetected), want: wasitypes.ErrorCodeLoopDetected},
+		"transport": {err: &net.DNSError{IsTimeout: true}, want: wasitypes.ErrorCodeDnsTimeout},
+		"other":     {err: errors.New("boom"), want: wasitypes.ErrorCodeInternalError},
+	}
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			if got := ToErrorCode(tc.err).Discriminant(); tc.want != got {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestStatusForError(t *testing.T) {
+	for err, want := range map[error]int{
+		ErrNoRoute:                     http.StatusNotFound,
+		ErrNoLinks:                     http.StatusServiceUnavailable,
+		ErrNoTarget:                    http.StatusServiceUnavailable,
+		ErrRPC:                         http.StatusBadGateway,
+		context.DeadlineExceeded:       http.StatusGatewayTimeout,
+		ErrHTTPResponseTimeout:         http.StatusGatewayTimeout,
+		ErrConnectionLimitReached:      http.StatusServiceUnavailable,
+		ErrHTTPRequestBodySize:         http.StatusRequestEntityTooLarge,
+		ErrHTTPProtocolError:           http.StatusBadGateway,
+		&Error{Code: ErrDNSTimeout}:    http.StatusGatewayTimeout,
+		&Error{Code: ErrInternalError}: http.StatusBadGateway,
+	} {
+		if got := StatusForError(fmt.Errorf("%w: %w", ErrRPC, err)); want != got {
+			t.Errorf("%v: expected status %d, got %d", err, want, got)
+		}
+	}
+}
@@ -2,23 +2,30 @@ package wrpchttp
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	wasitypes "go.wasmcloud.dev/provider/internal/wasi/http/types"
 	"go.wasmcloud.dev/provider/internal/wrpc/http/incoming_handler"
 	wrpctypes "go.wasmcloud.dev/provider/internal/wrpc/http/types"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 	wrpc "wrpc.io/go"
 )
 
 type IncomingRoundTripper struct {
-	director    func(*http.Request) string
+	route       func(*http.Request) (string, error)
+	split       *Split
 	natsCreator NatsClientCreator
 	invoker     func(context.Context, wrpc.Invoker, *wrpctypes.Request) (*wrpc.Result[incoming_handler.Response, incoming_handler.ErrorCode], <-chan error, error)
+	meter       metric.MeterProvider
+	metrics     *targetMetrics
 }
 
 var _ http.RoundTripper = (*IncomingRoundTripper)(nil)
@@ -27,7 +34,12 @@ type IncomingHandlerOption func(*IncomingRoundTripper)
 
 func WithDirector(director func(*http.Request) string) IncomingHandlerOption {
 	return func(p *IncomingRoundTripper) {
-		p.director = director
+		p.route = func(r *http.Request) (string, error) {
+			if target := director(r); target != "" {
+				return target, nil
+			}
+			return "", ErrNoTarget
+		}
 	}
 }
 
@@ -37,24 +49,91 @@ func WithSingleTarget(target string) IncomingHandlerOption {
 	})
 }
 
+// WithMeterProvider sets the provider of the per-target request metrics.
+// Defaults to the global meter provider.
+func WithMeterProvider(meter metric.MeterProvider) IncomingHandlerOption {
+	return func(p *IncomingRoundTripper) {
+		p.meter = meter
+	}
+}
+
 func NewIncomingRoundTripper(nc NatsClientCreator, opts ...IncomingHandlerOption) *IncomingRoundTripper {
 	p := &IncomingRoundTripper{
+		route: func(*http.Request) (string, error) {
+			return "", ErrNoTarget
+		},
 		natsCreator: nc,
 		invoker:     incoming_handler.Handle,
+		meter:       otel.GetMeterProvider(),
 	}
 	for _, opt := range opts {
 		opt(p)
 	}
+	p.metrics = newTargetMetrics(p.meter)
 	return p
 }
 
 func (p *IncomingRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
-	target := p.director(r)
-	if target == "" {
-		return nil, ErrNoTarget
+	if p.split == nil {
+		target, err := p.route(r)
+		if err != nil {
+			return nil, err
+		}
+		resp, _, err := p.roundTripTarget(r, r.Body, target)
+		return resp, err
+	}
+
+	body := &readTracker{ReadCloser: r.Body}
+	if r.Body == nil || r.Body == http.NoBody {
+		body = nil
+	}
+
+	var errs []error
+	for i, target := range p.split.order(r) {
+		var attemptBody io.ReadCloser = http.NoBody
+		if body != nil {
+			attemptBody = body
+		}
+		if i > 0 {
+			// The component may have run before the transport failed
+			if !p.split.retryIdempotent || !isIdempotent(r) {
+				break
+			}
+			if body != nil && body.read.Load() {
+				// The previous attempt consumed the body, replay it
+				if r.GetBody == nil {
+					break
+				}
+				fresh, err := r.GetBody()
+				if err != nil {
+					errs = append(errs, err)
+					break
+				}
+				attemptBody = fresh
+			}
+		}
+
+		resp, transportErr, err := p.roundTripTarget(r, attemptBody, target)
+		if !transportErr {
+			return resp, err
+		}
+		errs = append(errs, fmt.Errorf("target %s: %w", target, err))
 	}
+	return nil, errors.Join(errs...)
+}
+
+// roundTripTarget sends r to target. transportErr reports whether the
+// invocation itself failed, in which case another target may be tried.
+func (p *IncomingRoundTripper) roundTripTarget(r *http.Request, body io.ReadCloser, target string) (resp *http.Response, transportErr bool, err error) {
+	start := time.Now()
+	defer func() {
+		p.metrics.record(r.Context(), target, time.Since(start), err)
+	}()
 
-	outgoingBodyTrailer := HTTPBodyToWrpc(r.Body, r.Trailer)
+	if body == nil {
+		body = http.NoBody
+	}
+	outgoingBodyTrailer := HTTPBodyToWrpc(body, r.Trailer)
 	pathWithQuery := r.URL.Path
 	if r.URL.RawQuery != "" {
 		pathWithQuery += "?" + r.URL.RawQuery
@@ -72,43 +151,81 @@ func (p *IncomingRoundTripper) RoundTrip(r *http.Request) (*http.Response, error
 	wrpcClient := p.natsCreator.OutgoingRpcClient(target)
 	wresp, errCh, err := p.invoker(r.Context(), wrpcClient, wreq)
 	if err != nil {
-		return nil, err
+		return nil, true, err
 	}
 
 	if wresp.Err != nil {
-		return nil, fmt.Errorf("%w: %s", ErrRPC, wresp.Err)
+		return nil, false, fmt.Errorf("%w: %w", ErrRPC, FromErrorCode(wresp.Err))
 	}
 
 	respBody, trailers := WrpcBodyToHTTP(wresp.Ok.Body, wresp.Ok.Trailers)
+	// NOTE: Request parameters, such as the request body, may still be
+	// streaming. Return the response right away and surface late invocation
+	// errors through the response body.
+	respBody.writeErrs = collectErrors(r.Context(), errCh)
 
-	resp := &http.Response{
+	resp = &http.Response{
 		StatusCode: int(wresp.Ok.Status),
-		Header:     make(http.Header),
+		Header:     WrpcHeaderToHTTP(wresp.Ok.Headers),
 		Request:    r,
 		Body:       respBody,
 		Trailer:    trailers,
 	}
 
-	for _, hdr := range wresp.Ok.Headers {
-		for _, hdrVal := range hdr.V1 {
-			resp.Header.Add(hdr.V0, string(hdrVal))
+	return resp, false, nil
+}
+
+// invocationErrors collects the errors of an invocation in the background.
+type invocationErrors struct {
+	ctx  context.Context
+	done chan struct{}
+	lock sync.Mutex
+	errs []error
+}
+
+// collectErrors drains errCh in the background. Waits for the invocation to
+// complete are abandoned once ctx is done.
+func collectErrors(ctx context.Context, errCh <-chan error) *invocationErrors {
+	e := &invocationErrors{ctx: ctx, done: make(chan struct{})}
+	go func() {
+		defer close(e.done)
+		for err := range errCh {
+			e.lock.Lock()
+			e.errs = append(e.errs, err)
+			e.lock.Unlock()
 		}
-	}
+	}()
+	return e
+}
 
-	errList := []error{}
-	for err := range errCh {
-		errList = append(errList, err)
+// Err returns the errors received so far, if any.
+func (e *invocationErrors) Err() error {
+	if e == nil {
+		return nil
 	}
-
-	if len(errList) > 0 {
-		return nil, fmt.Errorf("%w: %v", ErrRPC, errList)
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if len(e.errs) == 0 {
+		return nil
 	}
+	return fmt.Errorf("%w: %w", ErrRPC, errors.Join(e.errs...))
+}
 
-	return resp, nil
+// Wait blocks until the invocation completes, then returns its errors.
+func (e *invocationErrors) Wait() error {
+	if e == nil {
+		return nil
+	}
+	select {
+	case <-e.done:
+	case <-e.ctx.Done():
+	}
+	return e.Err()
 }
 
 type wrpcIncomingBody struct {
 	body           io.Reader
+	writeErrs      *invocationErrors
 	trailer        http.Header
 	trailerRx      wrpc.Receiver[[]*wrpc.Tuple2[string, [][]byte]]
 	trailerOnce    sync.Once
@@ -116,7 +233,14 @@ type wrpcIncomingBody struct {
 }
 
 func (r *wrpcIncomingBody) Close() error {
-	return nil
+	var closeErr error
+	if closer, ok := r.body.(io.Closer); ok {
+		closeErr = closer.Close()
+	}
+	if err := r.writeErrs.Wait(); err != nil {
+		return err
+	}
+	return closeErr
 }
 
 func (r *wrpcIncomingBody) readTrailerOnce() {
@@ -135,16 +259,25 @@ func (r *wrpcIncomingBody) readTrailerOnce() {
 }
 
 func (r *wrpcIncomingBody) Read(b []byte) (int, error) {
+	if err := r.writeErrs.Err(); err != nil {
+		return 0, err
+	}
 	n, err := r.body.Read(b)
 	if err == io.EOF {
 		r.readTrailerOnce()
+		if err := r.writeErrs.Wait(); err != nil {
+			return n, err
+		}
 	}
 	return n, err
 }
 
 type wrpcOutgoingBody struct {
-	body        io.ReadCloser
-	trailer     http.Header
+	body    io.ReadCloser
+	trailer http.Header
+	// trailerFunc, when set, is used instead of trailer to look up trailers
+	// that are only known once the body has been consumed.
+	trailerFunc func() http.Header
 	bodyIsDone  chan struct{}
 	trailerOnce sync.Once
 }
@@ -159,7 +292,11 @@ func (r *wrpcOutgoingBody) Read(b []byte) (int, error) {
 
 func (r *wrpcOutgoingBody) Receive() ([]*wrpc.Tuple2[string, [][]byte], error) {
 	<-r.bodyIsDone
-	trailers := HTTPHeaderToWrpc(r.trailer)
+	trailer := r.trailer
+	if r.trailerFunc != nil {
+		trailer = r.trailerFunc()
+	}
+	trailers := HTTPHeaderToWrpc(trailer)
 	return trailers, nil
 }
 
@@ -246,3 +383,57 @@ func HTTPHeaderToWrpc(header http.Header) []*wrpc.Tuple2[string, [][]uint8] {
 
 	return wasiHeader
 }
+
+//nolint:revive
+func WrpcMethodToHTTP(method *wrpctypes.Method) string {
+	switch method.Discriminant() {
+	case wasitypes.MethodConnect:
+		return http.MethodConnect
+	case wasitypes.MethodGet:
+		return http.MethodGet
+	case wasitypes.MethodHead:
+		return http.MethodHead
+	case wasitypes.MethodPost:
+		return http.MethodPost
+	case wasitypes.MethodPut:
+		return http.MethodPut
+	case wasitypes.MethodPatch:
+		return http.MethodPatch
+	case wasitypes.MethodDelete:
+		return http.MethodDelete
+	case wasitypes.MethodOptions:
+		return http.MethodOptions
+	case wasitypes.MethodTrace:
+		return http.MethodTrace
+	default:
+		other, _ := method.GetOther()
+		return other
+	}
+}
+
+//nolint:revive
+func WrpcSchemeToHTTP(scheme *wrpctypes.Scheme) string {
+	if scheme == nil {
+		return "http"
+	}
+	switch scheme.Discriminant() {
+	case wasitypes.SchemeHttp:
+		return "http"
+	case wasitypes.SchemeHttps:
+		return "https"
+	default:
+		other, _ := scheme.GetOther()
+		return other
+	}
+}
+
+//nolint:revive
+func WrpcHeaderToHTTP(fields []*wrpc.Tuple2[string, [][]uint8]) http.Header {
+	header := make(http.Header, len(fields))
+	for _, hdr := range fields {
+		for _, hdrVal := range hdr.V1 {
+			header.Add(hdr.V0, string(hdrVal))
+		}
+	}
+	return header
+}
@@ -3,9 +3,12 @@ package wrpchttp
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	wasitypes "go.wasmcloud.dev/provider/internal/wasi/http/types"
 	"go.wasmcloud.dev/provider/internal/wrpc/http/incoming_handler"
@@ -197,3 +200,112 @@ func TestRoundtrip(t *testing.T) {
 		t.Errorf("expected body.Close() to return %v, got %v", want, got)
 	}
 }
+
+func TestRoundtripStreaming(t *testing.T) {
+	pr, pw := io.Pipe()
+	errCh := make(chan error)
+
+	fakeNc := fakeNatsCreator{
+		OutgoingRpcClientFunc: func(string) *wrpcnats.Client { return nil },
+	}
+	fakeInvoker := func(context.Context, wrpc.Invoker, *wrpctypes.Request) (*wrpc.Result[incoming_handler.Response, incoming_handler.ErrorCode], <-chan error, error) {
+		// The invocation is still running: the body is produced slowly and
+		// errCh stays open.
+		resp := wrpctypes.Response{
+			Status:   http.StatusOK,
+			Headers:  HTTPHeaderToWrpc(http.Header{"Content-Type": []string{"text/event-stream"}}),
+			Body:     pr,
+			Trailers: fakeReceiver{headers: http.Header{}},
+		}
+		return wrpc.Ok[incoming_handler.ErrorCode](resp), errCh, nil
+	}
+
+	roundTripper := NewIncomingRoundTripper(fakeNc, WithSingleTarget("component_id"))
+	roundTripper.invoker = fakeInvoker
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com/events", nil)
+	done := make(chan struct{})
+	var resp *http.Response
+	go func() {
+		defer close(done)
+		var err error
+		resp, err = roundTripper.RoundTrip(req)
+		if err != nil {
+			t.Errorf("unexpected error %v", err)
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected headers before the invocation completes")
+	}
+	if resp == nil {
+		t.FailNow()
+	}
+	if want, got := "text/event-stream", resp.Header.Get("Content-Type"); want != got {
+		t.Errorf("expected content type %s, got %s", want, got)
+	}
+
+	buf := make([]byte, 64)
+	for _, chunk := range []string{"data: one\n\n", "data: two\n\n"} {
+		go func() {
+			_, _ = pw.Write([]byte(chunk))
+		}()
+		n, err := resp.Body.Read(buf)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if want, got := chunk, string(buf[:n]); want != got {
+			t.Errorf("expected chunk %q, got %q", want, got)
+		}
+	}
+
+	// A late invocation error surfaces through the body
+	errCh <- errors.New("request body write failed")
+	close(errCh)
+
+	// Close waits for the invocation, so the error can't be missed
+	if err := resp.Body.Close(); !errors.Is(err, ErrRPC) {
+		t.Errorf("expected ErrRPC from Close, got %v", err)
+	}
+	if _, err := pw.Write([]byte("data: three\n\n")); !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("expected Close to close the component body, got %v", err)
+	}
+}
+
+func TestRoundtripLateInvocationError(t *testing.T) {
+	fakeNc := fakeNatsCreator{
+		OutgoingRpcClientFunc: func(string) *wrpcnats.Client { return nil },
+	}
+	errCh := make(chan error, 1)
+	fakeInvoker := func(context.Context, wrpc.Invoker, *wrpctypes.Request) (*wrpc.Result[incoming_handler.Response, incoming_handler.ErrorCode], <-chan error, error) {
+		resp := wrpctypes.Response{
+			Status:   http.StatusOK,
+			Body:     strings.NewReader("done"),
+			Trailers: fakeReceiver{headers: http.Header{}},
+		}
+		// The invocation fails only after the response body is complete
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			errCh <- errors.New("request body write failed")
+			close(errCh)
+		}()
+		return wrpc.Ok[incoming_handler.ErrorCode](resp), errCh, nil
+	}
+
+	roundTripper := NewIncomingRoundTripper(fakeNc, WithSingleTarget("component_id"))
+	roundTripper.invoker = fakeInvoker
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+	resp, err := roundTripper.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, err := io.ReadAll(resp.Body); !errors.Is(err, ErrRPC) {
+		t.Errorf("expected ErrRPC at EOF, got %v", err)
+	}
+	if err := resp.Body.Close(); !errors.Is(err, ErrRPC) {
+		t.Errorf("expected ErrRPC from Close, got %v", err)
+	}
+}
@@ -0,0 +1,440 @@
+package wrpchttp
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.wasmcloud.dev/provider"
+	"go.wasmcloud.dev/provider/internal/exports/wrpc/http/outgoing_handler"
+	wasitypes "go.wasmcloud.dev/provider/internal/wasi/http/types"
+	wrpctypes "go.wasmcloud.dev/provider/internal/wrpc/http/types"
+
+	wrpc "wrpc.io/go"
+)
+
+const (
+	// AllowedHostsConfigKey is the link config key holding a comma separated
+	// list of hosts a component may send requests to. Entries may carry a port
+	// ("api.example.com:443") or a leading wildcard ("*.example.com"), and
+	// "*" allows every host. An absent or empty list denies every request.
+	AllowedHostsConfigKey = "allowed_hosts"
+)
+
+var (
+	errConnectTimeout      = errors.New("connect timeout")
+	errFirstByteTimeout    = errors.New("first byte timeout")
+	errBetweenBytesTimeout = errors.New("between bytes timeout")
+)
+
+// hopHeaders are connection-specific and must not be forwarded.
+// https://www.rfc-editor.org/rfc/rfc9110#section-7.6.1
+var hopHeaders = []string{
+	"Connection",
+	"Proxy-Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// OutgoingHandler serves `wrpc:http/outgoing-handler`, performing the requests
+// of linked components with an [http.Client].
+type OutgoingHandler struct {
+	client *http.Client
+
+	lock sync.RWMutex
+	// allowed hosts per linked component, indexed by the component ID
+	allowlists map[string][]string
+}
+
+var _ outgoing_handler.Handler = (*OutgoingHandler)(nil)
+
+type OutgoingHandlerOption func(*OutgoingHandler)
+
+// WithHTTPClient sets the client used to perform requests. The default client
+// does not follow redirects, leaving that decision to the component.
+func WithHTTPClient(client *http.Client) OutgoingHandlerOption {
+	return func(h *OutgoingHandler) {
+		h.client = client
+	}
+}
+
+func NewOutgoingHandler(opts ...OutgoingHandlerOption) *OutgoingHandler {
+	h := &OutgoingHandler{
+		client: &http.Client{
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		allowlists: make(map[string][]string),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Serve exports `wrpc:http/outgoing-handler` on s, usually the provider's RPCClient.
+func (h *OutgoingHandler) Serve(s wrpc.Server) (stop func() error, err error) {
+	return outgoing_handler.ServeInterface(s, h)
+}
+
+// PutLink allows the source component of link to send requests, restricted to
+// the hosts listed under [AllowedHostsConfigKey].
+func (h *OutgoingHandler) PutLink(link provider.InterfaceLinkDefinition) error {
+	var hosts []string
+	for _, host := range strings.Split(link.TargetConfig[AllowedHostsConfigKey], ",") {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.allowlists[link.SourceID] = hosts
+	return nil
+}
+
+// DelLink revokes access for the source component of link.
+func (h *OutgoingHandler) DelLink(link provider.InterfaceLinkDefinition) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	delete(h.allowlists, link.SourceID)
+	return nil
+}
+
+func (h *OutgoingHandler) allowed(ctx context.Context, u *url.URL) bool {
+	sourceID, ok := provider.SourceIDFromContext(ctx)
+	if !ok {
+		return false
+	}
+
+	h.lock.RLock()
+	hosts, linked := h.allowlists[sourceID]
+	h.lock.RUnlock()
+	if !linked {
+		return false
+	}
+
+	return hostAllowed(hosts, u)
+}
+
+func hostAllowed(hosts []string, u *url.URL) bool {
+	host := strings.ToLower(u.Hostname())
+	port := u.Port()
+	if port == "" {
+		switch u.Scheme {
+		case "https":
+			port = "443"
+		case "http":
+			port = "80"
+		}
+	}
+
+	for _, pattern := range hosts {
+		patternHost, patternPort := pattern, ""
+		if h, p, err := net.SplitHostPort(pattern); err == nil {
+			patternHost, patternPort = h, p
+		}
+		if patternPort != "" && patternPort != port {
+			continue
+		}
+		if patternHost == "*" || patternHost == host {
+			return true
+		}
+		if strings.HasPrefix(patternHost, "*.") && strings.HasSuffix(host, patternHost[1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle implements the `handle` function of `wrpc:http/outgoing-handler`.
+func (h *OutgoingHandler) Handle(ctx context.Context, wreq *wrpctypes.Request, opts *wrpctypes.RequestOptions) (*wrpc.Result[outgoing_handler.Response, outgoing_handler.ErrorCode], error) {
+	// NOTE: The response body outlives the invocation handler, so the
+	// request is detached from the invocation context and cancelled once the
+	// response body is closed.
+	reqCtx, cancel := context.WithCancelCause(context.WithoutCancel(ctx))
+
+	req, err := wrpcToHTTPRequest(reqCtx, wreq)
+	if err != nil {
+		cancel(err)
+		return wrpc.Err[outgoing_handler.Response](*wasitypes.NewErrorCodeHttpRequestUriInvalid()), nil
+	}
+
+	if !h.allowed(ctx, req.URL) {
+		cancel(nil)
+		return wrpc.Err[outgoing_handler.Response](*wasitypes.NewErrorCodeHttpRequestDenied()), nil
+	}
+
+	var betweenBytes time.Duration
+	if opts != nil {
+		req = withRequestTimeouts(req, opts, cancel)
+		if opts.BetweenBytesTimeout != nil {
+			betweenBytes = time.Duration(*opts.BetweenBytesTimeout)
+		}
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		if cause := context.Cause(reqCtx); cause != nil {
+			err = cause
+		}
+		cancel(err)
+		return wrpc.Err[outgoing_handler.Response](*ToErrorCode(err)), nil
+	}
+
+	removeHopByHopHeaders(resp.Header)
+
+	body := HTTPBodyToWrpc(&outgoingResponseBody{
+		body:         resp.Body,
+		betweenBytes: betweenBytes,
+		cancel:       cancel,
+	}, nil)
+	body.trailerFunc = func() http.Header {
+		return resp.Trailer
+	}
+
+	return wrpc.Ok[outgoing_handler.ErrorCode](outgoing_handler.Response{
+		Status:   uint16(resp.StatusCode),
+		Headers:  HTTPHeaderToWrpc(resp.Header),
+		Body:     body,
+		Trailers: body,
+	}), nil
+}
+
+func wrpcToHTTPRequest(ctx context.Context, wreq *wrpctypes.Request) (*http.Request, error) {
+	if wreq.Authority == nil || *wreq.Authority == "" {
+		return nil, errors.New("missing authority")
+	}
+	pathWithQuery := "/"
+	if wreq.PathWithQuery != nil && *wreq.PathWithQuery != "" {
+		pathWithQuery = *wreq.PathWithQuery
+	}
+
+	u, err := url.Parse(WrpcSchemeToHTTP(wreq.Scheme) + "://" + *wreq.Authority + pathWithQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, WrpcMethodToHTTP(wreq.Method), u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = WrpcHeaderToHTTP(wreq.Headers)
+	req.Host = *wreq.Authority
+
+	if cl := req.Header.Get("Content-Length"); cl != "" {
+		n, err := strconv.ParseInt(cl, 10, 64)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid content-length %q", cl)
+		}
+		req.ContentLength = n
+	}
+
+	// Trailers are only known once the body has been sent, declare the ones
+	// the component announced upfront.
+	req.Trailer = make(http.Header)
+	for _, declared := range req.Header.Values("Trailer") {
+		for _, key := range strings.Split(declared, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				req.Trailer[http.CanonicalHeaderKey(key)] = nil
+			}
+		}
+	}
+	removeHopByHopHeaders(req.Header)
+
+	if wreq.Body != nil {
+		req.Body = &outgoingRequestBody{
+			body:      wreq.Body,
+			trailerRx: wreq.Trailers,
+			trailer:   req.Trailer,
+		}
+	}
+
+	return req, nil
+}
+
+func withRequestTimeouts(req *http.Request, opts *wrpctypes.RequestOptions, cancel context.CancelCauseFunc) *http.Request {
+	var connectTimer *time.Timer
+	if opts.ConnectTimeout != nil {
+		connectTimer = time.AfterFunc(time.Duration(*opts.ConnectTimeout), func() {
+			cancel(errConnectTimeout)
+		})
+	}
+
+	// Trace callbacks may run on different goroutines
+	var firstByte struct {
+		sync.Mutex
+		timer    *time.Timer
+		received bool
+	}
+	trace := &httptrace.ClientTrace{
+		GotConn: func(httptrace.GotConnInfo) {
+			if connectTimer != nil {
+				connectTimer.Stop()
+			}
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			if opts.FirstByteTimeout == nil {
+				return
+			}
+			firstByte.Lock()
+			defer firstByte.Unlock()
+			// The response may start before the request is fully written
+			if !firstByte.received {
+				firstByte.timer = time.AfterFunc(time.Duration(*opts.FirstByteTimeout), func() {
+					cancel(errFirstByteTimeout)
+				})
+			}
+		},
+		GotFirstResponseByte: func() {
+			firstByte.Lock()
+			defer firstByte.Unlock()
+			firstByte.received = true
+			if firstByte.timer != nil {
+				firstByte.timer.Stop()
+			}
+		},
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+}
+
+// outgoingRequestBody streams the component's request body and collects its
+// trailers once the body is exhausted.
+type outgoingRequestBody struct {
+	body      io.ReadCloser
+	trailerRx wrpc.Receiver[[]*wrpc.Tuple2[string, [][]uint8]]
+	trailer   http.Header
+	once      sync.Once
+}
+
+func (b *outgoingRequestBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	if err == io.EOF {
+		b.once.Do(b.receiveTrailers)
+	}
+	return n, err
+}
+
+func (b *outgoingRequestBody) receiveTrailers() {
+	if b.trailerRx == nil {
+		return
+	}
+	trailers, err := b.trailerRx.Receive()
+	if err != nil {
+		return
+	}
+	for key, vals := range WrpcHeaderToHTTP(trailers) {
+		b.trailer[key] = vals
+	}
+}
+
+func (b *outgoingRequestBody) Close() error {
+	return b.body.Close()
+}
+
+// outgoingResponseBody enforces the between-bytes timeout and releases the
+// request once the response has been fully forwarded.
+type outgoingResponseBody struct {
+	body         io.ReadCloser
+	betweenBytes time.Duration
+	cancel       context.CancelCauseFunc
+}
+
+func (b *outgoingResponseBody) Read(p []byte) (int, error) {
+	if b.betweenBytes > 0 {
+		timer := time.AfterFunc(b.betweenBytes, func() {
+			b.cancel(errBetweenBytesTimeout)
+		})
+		defer timer.Stop()
+	}
+	return b.body.Read(p)
+}
+
+func (b *outgoingResponseBody) Close() error {
+	err := b.body.Close()
+	b.cancel(nil)
+	return err
+}
+
+func removeHopByHopHeaders(header http.Header) {
+	for _, connHeader := range header.Values("Connection") {
+		for _, key := range strings.Split(connHeader, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				header.Del(key)
+			}
+		}
+	}
+	for _, key := range hopHeaders {
+		header.Del(key)
+	}
+}
+
+// errorCodeFromTransport maps a [http.Client] error to the closest `wasi:http` error code.
+func errorCodeFromTransport(err error) *wasitypes.ErrorCode {
+	var (
+		dnsErr      *net.DNSError
+		alertErr    tls.AlertError
+		recordErr   tls.RecordHeaderError
+		verifyErr   *tls.CertificateVerificationError
+		unknownAuth x509.UnknownAuthorityError
+		hostnameErr x509.HostnameError
+		invalidErr  x509.CertificateInvalidError
+		netErr      net.Error
+	)
+
+	switch {
+	case errors.Is(err, errConnectTimeout):
+		return wasitypes.NewErrorCodeConnectionTimeout()
+	case errors.Is(err, errFirstByteTimeout):
+		return wasitypes.NewErrorCodeHttpResponseTimeout()
+	case errors.Is(err, errBetweenBytesTimeout):
+		return wasitypes.NewErrorCodeConnectionReadTimeout()
+	case errors.As(err, &dnsErr):
+		if dnsErr.IsTimeout {
+			return wasitypes.NewErrorCodeDnsTimeout()
+		}
+		payload := &wasitypes.DnsErrorPayload{}
+		if dnsErr.IsNotFound {
+			rcode := "NXDOMAIN"
+			payload.Rcode = &rcode
+		}
+		return wasitypes.NewErrorCodeDnsError(payload)
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return wasitypes.NewErrorCodeConnectionRefused()
+	case errors.Is(err, syscall.ENETUNREACH), errors.Is(err, syscall.EHOSTUNREACH):
+		return wasitypes.NewErrorCodeDestinationUnavailable()
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return wasitypes.NewErrorCodeConnectionTerminated()
+	case errors.As(err, &alertErr):
+		id := uint8(alertErr)
+		msg := alertErr.Error()
+		return wasitypes.NewErrorCodeTlsAlertReceived(&wasitypes.TlsAlertReceivedPayload{AlertId: &id, AlertMessage: &msg})
+	case errors.As(err, &verifyErr), errors.As(err, &unknownAuth), errors.As(err, &hostnameErr), errors.As(err, &invalidErr):
+		return wasitypes.NewErrorCodeTlsCertificateError()
+	case errors.As(err, &recordErr):
+		return wasitypes.NewErrorCodeTlsProtocolError()
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return wasitypes.NewErrorCodeConnectionTimeout()
+	default:
+		msg := err.Error()
+		return wasitypes.NewErrorCodeInternalError(&msg)
+	}
+}
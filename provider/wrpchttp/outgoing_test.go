@@ -0,0 +1,273 @@
+package wrpchttp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"go.wasmcloud.dev/provider"
+	wasitypes "go.wasmcloud.dev/provider/internal/wasi/http/types"
+	wrpctypes "go.wasmcloud.dev/provider/internal/wrpc/http/types"
+	wrpcnats "wrpc.io/go/nats"
+)
+
+func sourceContext(source string) context.Context {
+	return wrpcnats.ContextWithHeader(context.Background(), nats.Header{provider.SourceIDHeader: []string{source}})
+}
+
+func newOutgoingRequest(t *testing.T, method string, rawURL string, body string, header http.Header, trailer http.Header) *wrpctypes.Request {
+	t.Helper()
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	pathWithQuery := u.RequestURI()
+	return &wrpctypes.Request{
+		Method:        HTTPMethodToWrpc(method),
+		Scheme:        HTTPSchemeToWrpc(u.Scheme),
+		Authority:     &u.Host,
+		PathWithQuery: &pathWithQuery,
+		Headers:       HTTPHeaderToWrpc(header),
+		Body:          io.NopCloser(strings.NewReader(body)),
+		Trailers:      fakeReceiver{headers: trailer},
+	}
+}
+
+func TestOutgoingHandle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if want, got := "/path?q=val", r.URL.RequestURI(); want != got {
+			t.Errorf("expected request uri %s, got %s", want, got)
+		}
+		if want, got := "x-client-value", r.Header.Get("X-Client-Custom"); want != got {
+			t.Errorf("expected header %s, got %s", want, got)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if want, got := "hello request", string(body); want != got {
+			t.Errorf("expected body %s, got %s", want, got)
+		}
+		if want, got := "request-trailer", r.Trailer.Get("X-Request-Trailer"); want != got {
+			t.Errorf("expected request trailer %s, got %s", want, got)
+		}
+
+		w.Header().Set("Trailer", "X-Response-Trailer")
+		w.Header().Set("X-Custom", "x-value")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("hello response"))
+		w.Header().Set("X-Response-Trailer", "response-trailer")
+	}))
+	defer server.Close()
+
+	h := NewOutgoingHandler()
+	if err := h.PutLink(provider.InterfaceLinkDefinition{
+		SourceID:     "component",
+		TargetConfig: map[string]string{AllowedHostsConfigKey: "*"},
+	}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	req := newOutgoingRequest(t, http.MethodPost, server.URL+"/path?q=val", "hello request",
+		http.Header{"X-Client-Custom": {"x-client-value"}, "Trailer": {"X-Request-Trailer"}},
+		http.Header{"X-Request-Trailer": {"request-trailer"}})
+
+	res, err := h.Handle(sourceContext("component"), req, nil)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if res.Err != nil {
+		t.Fatalf("unexpected error code %v", res.Err.Discriminant())
+	}
+
+	resp := res.Ok
+	if want, got := uint16(http.StatusCreated), resp.Status; want != got {
+		t.Errorf("expected status %d, got %d", want, got)
+	}
+	header := WrpcHeaderToHTTP(resp.Headers)
+	if want, got := "x-value", header.Get("X-Custom"); want != got {
+		t.Errorf("expected header %s, got %s", want, got)
+	}
+	if got := header.Get("Trailer"); got != "" {
+		t.Errorf("expected hop-by-hop header to be removed, got %s", got)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if want, got := "hello response", string(body); want != got {
+		t.Errorf("expected body %s, got %s", want, got)
+	}
+
+	trailers, err := resp.Trailers.Receive()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if want, got := "response-trailer", WrpcHeaderToHTTP(trailers).Get("X-Response-Trailer"); want != got {
+		t.Errorf("expected trailer %s, got %s", want, got)
+	}
+}
+
+func TestOutgoingAllowlist(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	h := NewOutgoingHandler()
+	_ = h.PutLink(provider.InterfaceLinkDefinition{
+		SourceID:     "restricted",
+		TargetConfig: map[string]string{AllowedHostsConfigKey: "api.example.com, *.internal"},
+	})
+	_ = h.PutLink(provider.InterfaceLinkDefinition{
+		SourceID:     "local",
+		TargetConfig: map[string]string{AllowedHostsConfigKey: "127.0.0.1"},
+	})
+
+	tt := map[string]struct {
+		ctx    context.Context
+		denied bool
+	}{
+		"allowed host": {ctx: sourceContext("local")},
+		"other host":   {ctx: sourceContext("restricted"), denied: true},
+		"unlinked":     {ctx: sourceContext("stranger"), denied: true},
+		"no source":    {ctx: context.Background(), denied: true},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			req := newOutgoingRequest(t, http.MethodGet, server.URL, "", http.Header{}, http.Header{})
+			res, err := h.Handle(tc.ctx, req, nil)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if !tc.denied {
+				if res.Err != nil {
+					t.Fatalf("unexpected error code %v", res.Err.Discriminant())
+				}
+				_ = res.Ok.Body.Close()
+				return
+			}
+			if res.Err == nil {
+				t.Fatal("expected request to be denied")
+			}
+			if want, got := wasitypes.ErrorCodeHttpRequestDenied, res.Err.Discriminant(); want != got {
+				t.Errorf("expected error code %v, got %v", want, got)
+			}
+		})
+	}
+
+	_ = h.DelLink(provider.InterfaceLinkDefinition{SourceID: "local"})
+	req := newOutgoingRequest(t, http.MethodGet, server.URL, "", http.Header{}, http.Header{})
+	if res, _ := h.Handle(sourceContext("local"), req, nil); res.Err == nil {
+		t.Error("expected request to be denied after link deletion")
+	}
+}
+
+func TestHostAllowed(t *testing.T) {
+	hosts := []string{"api.example.com", "*.internal", "db.local:5432"}
+
+	for rawURL, want := range map[string]bool{
+		"https://api.example.com/x":     true,
+		"https://API.example.com/x":     true,
+		"https://example.com/x":         false,
+		"http://svc.internal/":          true,
+		"http://internal/":              false,
+		"http://db.local:5432/":         true,
+		"http://db.local/":              false,
+		"https://api.example.com:8443/": true,
+	} {
+		u, _ := url.Parse(rawURL)
+		if got := hostAllowed(hosts, u); want != got {
+			t.Errorf("%s: expected %v, got %v", rawURL, want, got)
+		}
+	}
+
+	u, _ := url.Parse("https://api.example.com/")
+	if hostAllowed(nil, u) {
+		t.Error("expected an empty list to deny every host")
+	}
+	if !hostAllowed([]string{"*"}, u) {
+		t.Error("expected * to allow every host")
+	}
+}
+
+func TestOutgoingErrorCodes(t *testing.T) {
+	h := NewOutgoingHandler()
+	_ = h.PutLink(provider.InterfaceLinkDefinition{
+		SourceID:     "component",
+		TargetConfig: map[string]string{AllowedHostsConfigKey: "*"},
+	})
+
+	t.Run("connection refused", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		addr := server.URL
+		server.Close()
+
+		req := newOutgoingRequest(t, http.MethodGet, addr, "", http.Header{}, http.Header{})
+		res, err := h.Handle(sourceContext("component"), req, nil)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if res.Err == nil {
+			t.Fatal("expected error code")
+		}
+		if want, got := wasitypes.ErrorCodeConnectionRefused, res.Err.Discriminant(); want != got {
+			t.Errorf("expected error code %v, got %v", want, got)
+		}
+	})
+
+	t.Run("first byte timeout", func(t *testing.T) {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			<-release
+		}))
+		defer server.Close()
+		defer close(release)
+
+		timeout := uint64(50 * time.Millisecond)
+		req := newOutgoingRequest(t, http.MethodGet, server.URL, "", http.Header{}, http.Header{})
+		res, err := h.Handle(sourceContext("component"), req, &wrpctypes.RequestOptions{FirstByteTimeout: &timeout})
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if res.Err == nil {
+			t.Fatal("expected error code")
+		}
+		if want, got := wasitypes.ErrorCodeHttpResponseTimeout, res.Err.Discriminant(); want != got {
+			t.Errorf("expected error code %v, got %v", want, got)
+		}
+	})
+
+	t.Run("invalid authority", func(t *testing.T) {
+		req := newOutgoingRequest(t, http.MethodGet, "http:///path", "", http.Header{}, http.Header{})
+		res, _ := h.Handle(sourceContext("component"), req, nil)
+		if res.Err == nil {
+			t.Fatal("expected error code")
+		}
+		if want, got := wasitypes.ErrorCodeHttpRequestUriInvalid, res.Err.Discriminant(); want != got {
+			t.Errorf("expected error code %v, got %v", want, got)
+		}
+	})
+}
+
+func TestWrpcToHTTPConversions(t *testing.T) {
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPatch, "PURGE"} {
+		if got := WrpcMethodToHTTP(HTTPMethodToWrpc(method)); got != method {
+			t.Errorf("expected method %s, got %s", method, got)
+		}
+	}
+	for _, scheme := range []string{"http", "https"} {
+		if got := WrpcSchemeToHTTP(HTTPSchemeToWrpc(scheme)); got != scheme {
+			t.Errorf("expected scheme %s, got %s", scheme, got)
+		}
+	}
+	header := http.Header{"X-Multi": {"a", "b"}}
+	if got := WrpcHeaderToHTTP(HTTPHeaderToWrpc(header)); strings.Join(got.Values("X-Multi"), ",") != "a,b" {
+		t.Errorf("expected header values a,b, got %v", got)
+	}
+}
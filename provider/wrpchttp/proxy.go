@@ -0,0 +1,231 @@
+package wrpchttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultProxyTimeout   = 60 * time.Second
+	proxyCopyBufferSize   = 32 * 1024
+	headerFieldOverhead   = len(": \r\n")
+	defaultMaxHeaderBytes = http.DefaultMaxHeaderBytes
+)
+
+var errProxyTimeout = fmt.Errorf("proxy: no response within timeout: %w", context.DeadlineExceeded)
+
+// Proxy is an [http.Handler] forwarding requests to components through a
+// [http.RoundTripper], usually an [IncomingRoundTripper].
+//
+// Bodies are streamed in both directions, response trailers are sent as HTTP
+// trailers and hop-by-hop headers are removed. Failures are answered with the
+// status returned by [StatusForError].
+type Proxy struct {
+	transport      http.RoundTripper
+	timeout        time.Duration
+	maxBodyBytes   int64
+	maxHeaderBytes int
+	logger         *slog.Logger
+}
+
+var _ http.Handler = (*Proxy)(nil)
+
+type ProxyOption func(*Proxy)
+
+// WithTimeout bounds the time until the component starts responding. Once
+// headers are received the body streams for as long as it takes. Zero
+// disables the timeout. Defaults to 60 seconds.
+func WithTimeout(timeout time.Duration) ProxyOption {
+	return func(p *Proxy) {
+		p.timeout = timeout
+	}
+}
+
+// WithMaxBodyBytes limits the size of request bodies. Zero, the default,
+// means no limit.
+func WithMaxBodyBytes(n int64) ProxyOption {
+	return func(p *Proxy) {
+		p.maxBodyBytes = n
+	}
+}
+
+// WithMaxHeaderBytes limits the size of request and response header
+// sections. Defaults to [http.DefaultMaxHeaderBytes].
+func WithMaxHeaderBytes(n int) ProxyOption {
+	return func(p *Proxy) {
+		p.maxHeaderBytes = n
+	}
+}
+
+// WithProxyLogger sets the logger used to report failed requests.
+func WithProxyLogger(logger *slog.Logger) ProxyOption {
+	return func(p *Proxy) {
+		p.logger = logger
+	}
+}
+
+func NewProxy(transport http.RoundTripper, opts ...ProxyOption) *Proxy {
+	p := &Proxy{
+		transport:      transport,
+		timeout:        defaultProxyTimeout,
+		maxHeaderBytes: defaultMaxHeaderBytes,
+		logger:         slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if headerSize(r.Header) > p.maxHeaderBytes {
+		p.fail(w, r, http.StatusRequestHeaderFieldsTooLarge, nil)
+		return
+	}
+	if p.maxBodyBytes > 0 {
+		if r.ContentLength > p.maxBodyBytes {
+			p.fail(w, r, http.StatusRequestEntityTooLarge, nil)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, p.maxBodyBytes)
+	}
+
+	// NOTE: The context stays alive while the response body streams and is
+	// only cancelled by the timer until the response headers arrive.
+	ctx, cancel := context.WithCancelCause(r.Context())
+	defer cancel(nil)
+	var timer *time.Timer
+	if p.timeout > 0 {
+		timer = time.AfterFunc(p.timeout, func() {
+			cancel(errProxyTimeout)
+		})
+	}
+
+	outreq := p.outgoingRequest(ctx, r)
+	resp, err := p.transport.RoundTrip(outreq)
+	if timer != nil && !timer.Stop() {
+		if resp != nil {
+			resp.Body.Close()
+		}
+		p.fail(w, r, http.StatusGatewayTimeout, errProxyTimeout)
+		return
+	}
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			p.fail(w, r, http.StatusRequestEntityTooLarge, err)
+			return
+		}
+		p.fail(w, r, StatusForError(err), err)
+		return
+	}
+	defer resp.Body.Close()
+
+	removeHopByHopHeaders(resp.Header)
+	if headerSize(resp.Header) > p.maxHeaderBytes {
+		p.fail(w, r, http.StatusBadGateway, fmt.Errorf("%w: %d bytes", ErrHTTPResponseHeaderSectionSize, headerSize(resp.Header)))
+		return
+	}
+
+	for k, vals := range resp.Header {
+		w.Header()[k] = vals
+	}
+	w.WriteHeader(resp.StatusCode)
+
+	if err := copyFlush(w, resp.Body); err != nil {
+		// The status is already sent, abort the connection so the client can
+		// tell the response is incomplete.
+		p.logger.Error("proxy: streaming response body", "url", r.URL.String(), "err", err)
+		panic(http.ErrAbortHandler)
+	}
+
+	// Trailers are only known once the body is consumed, send them undeclared.
+	for k, vals := range resp.Trailer {
+		w.Header()[http.TrailerPrefix+k] = vals
+	}
+}
+
+func (p *Proxy) outgoingRequest(ctx context.Context, r *http.Request) *http.Request {
+	outreq := r.Clone(ctx)
+	// net/http doesn't allow 'RequestURI' to be present in outbound requests
+	outreq.RequestURI = ""
+	if r.ContentLength == 0 {
+		outreq.Body = http.NoBody
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	outreq.URL.Scheme = scheme
+	outreq.URL.Host = r.Host
+
+	removeHopByHopHeaders(outreq.Header)
+
+	if clientIP, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		if prior := outreq.Header.Values("X-Forwarded-For"); len(prior) > 0 {
+			clientIP = strings.Join(prior, ", ") + ", " + clientIP
+		}
+		outreq.Header.Set("X-Forwarded-For", clientIP)
+	}
+	if outreq.Header.Get("X-Forwarded-Host") == "" {
+		outreq.Header.Set("X-Forwarded-Host", r.Host)
+	}
+	if outreq.Header.Get("X-Forwarded-Proto") == "" {
+		outreq.Header.Set("X-Forwarded-Proto", scheme)
+	}
+
+	return outreq
+}
+
+func (p *Proxy) fail(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if err != nil {
+		p.logger.Error("proxy: forwarding request", "url", r.URL.String(), "status", status, "err", err)
+	}
+	http.Error(w, http.StatusText(status), status)
+}
+
+// copyFlush copies src to w, flushing after every write so the body is never
+// buffered by the proxy.
+func copyFlush(w http.ResponseWriter, src io.Reader) error {
+	rc := http.NewResponseController(w)
+	// Send the headers right away, the first chunk may take a while
+	if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	buf := make([]byte, proxyCopyBufferSize)
+	for {
+		n, rerr := src.Read(buf)
+		if n > 0 {
+			if _, err := w.Write(buf[:n]); err != nil {
+				return err
+			}
+			if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+				return err
+			}
+		}
+		if rerr == io.EOF {
+			return nil
+		}
+		if rerr != nil {
+			return rerr
+		}
+	}
+}
+
+func headerSize(header http.Header) int {
+	size := 0
+	for k, vals := range header {
+		for _, v := range vals {
+			size += len(k) + len(v) + headerFieldOverhead
+		}
+	}
+	return size
+}
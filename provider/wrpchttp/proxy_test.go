@@ -0,0 +1,226 @@
+package wrpchttp
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func TestProxyForwards(t *testing.T) {
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if got := r.Header.Get("Connection"); got != "" {
+			t.Errorf("expected hop-by-hop header to be removed, got %s", got)
+		}
+		if got := r.Header.Get("X-Forwarded-For"); !strings.HasPrefix(got, "10.0.0.1, ") {
+			t.Errorf("expected X-Forwarded-For to be appended to, got %s", got)
+		}
+		if want, got := "example.com", r.Header.Get("X-Forwarded-Host"); want != got {
+			t.Errorf("expected X-Forwarded-Host %s, got %s", want, got)
+		}
+		if want, got := "http", r.Header.Get("X-Forwarded-Proto"); want != got {
+			t.Errorf("expected X-Forwarded-Proto %s, got %s", want, got)
+		}
+		if want, got := "example.com", r.URL.Host; want != got {
+			t.Errorf("expected host %s, got %s", want, got)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if want, got := "hello request", string(body); want != got {
+			t.Errorf("expected body %s, got %s", want, got)
+		}
+
+		trailer := http.Header{}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"X-Custom": {"x-value"}, "Keep-Alive": {"timeout=5"}},
+			Body: &trailerBody{
+				Reader:  strings.NewReader("hello response"),
+				trailer: trailer,
+				values:  http.Header{"X-Checksum": {"abc"}},
+			},
+			Trailer: trailer,
+		}, nil
+	})
+
+	server := httptest.NewServer(NewProxy(transport, WithProxyLogger(discardLogger)))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("hello request"))
+	req.Host = "example.com"
+	req.Header.Set("X-Forwarded-For", "10.0.0.1")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer resp.Body.Close()
+
+	if want, got := http.StatusOK, resp.StatusCode; want != got {
+		t.Errorf("expected status %d, got %d", want, got)
+	}
+	if want, got := "x-value", resp.Header.Get("X-Custom"); want != got {
+		t.Errorf("expected header %s, got %s", want, got)
+	}
+	if got := resp.Header.Get("Keep-Alive"); got != "" {
+		t.Errorf("expected hop-by-hop header to be removed, got %s", got)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if want, got := "hello response", string(body); want != got {
+		t.Errorf("expected body %s, got %s", want, got)
+	}
+	if want, got := "abc", resp.Trailer.Get("X-Checksum"); want != got {
+		t.Errorf("expected trailer %s, got %s", want, got)
+	}
+	if got := resp.Header.Get("X-Checksum"); got != "" {
+		t.Errorf("expected trailer not to be sent as a header, got %s", got)
+	}
+}
+
+// trailerBody fills its trailer once fully read, like [WrpcBodyToHTTP].
+type trailerBody struct {
+	io.Reader
+	trailer http.Header
+	values  http.Header
+}
+
+func (b *trailerBody) Read(p []byte) (int, error) {
+	n, err := b.Reader.Read(p)
+	if err == io.EOF {
+		for k, v := range b.values {
+			b.trailer[k] = v
+		}
+	}
+	return n, err
+}
+
+func (*trailerBody) Close() error { return nil }
+
+func TestProxyStreams(t *testing.T) {
+	pr, pw := io.Pipe()
+	transport := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: pr}, nil
+	})
+
+	server := httptest.NewServer(NewProxy(transport, WithProxyLogger(discardLogger)))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer resp.Body.Close()
+
+	// Every chunk must reach the client before the next one is produced
+	reader := bufio.NewReader(resp.Body)
+	for i := range 3 {
+		line := fmt.Sprintf("chunk %d\n", i)
+		if _, err := pw.Write([]byte(line)); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		got, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if got != line {
+			t.Errorf("expected %q, got %q", line, got)
+		}
+	}
+	pw.Close()
+}
+
+func TestProxyErrors(t *testing.T) {
+	tt := map[string]struct {
+		transport roundTripFunc
+		opts      []ProxyOption
+		header    http.Header
+		body      string
+		status    int
+	}{
+		"no route": {
+			transport: func(*http.Request) (*http.Response, error) { return nil, ErrNoRoute },
+			status:    http.StatusNotFound,
+		},
+		"no links": {
+			transport: func(*http.Request) (*http.Response, error) { return nil, ErrNoLinks },
+			status:    http.StatusServiceUnavailable,
+		},
+		"component error": {
+			transport: func(*http.Request) (*http.Response, error) {
+				return nil, fmt.Errorf("%w: %w", ErrRPC, &Error{Code: ErrConnectionRefused})
+			},
+			status: http.StatusServiceUnavailable,
+		},
+		"rpc error": {
+			transport: func(*http.Request) (*http.Response, error) { return nil, ErrRPC },
+			status:    http.StatusBadGateway,
+		},
+		"timeout": {
+			transport: func(r *http.Request) (*http.Response, error) {
+				<-r.Context().Done()
+				return nil, r.Context().Err()
+			},
+			opts:   []ProxyOption{WithTimeout(20 * time.Millisecond)},
+			status: http.StatusGatewayTimeout,
+		},
+		"body too large": {
+			transport: func(r *http.Request) (*http.Response, error) {
+				_, err := io.ReadAll(r.Body)
+				return nil, err
+			},
+			opts:   []ProxyOption{WithMaxBodyBytes(4)},
+			body:   "too large",
+			status: http.StatusRequestEntityTooLarge,
+		},
+		"headers too large": {
+			transport: func(*http.Request) (*http.Response, error) {
+				t.Error("unexpected round trip")
+				return nil, ErrRPC
+			},
+			opts:   []ProxyOption{WithMaxHeaderBytes(64)},
+			header: http.Header{"X-Large": {strings.Repeat("a", 128)}},
+			status: http.StatusRequestHeaderFieldsTooLarge,
+		},
+		"response headers too large": {
+			transport: func(*http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"X-Large": {strings.Repeat("a", 128)}},
+					Body:       http.NoBody,
+				}, nil
+			},
+			opts:   []ProxyOption{WithMaxHeaderBytes(64)},
+			status: http.StatusBadGateway,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			proxy := NewProxy(tc.transport, append(tc.opts, WithProxyLogger(discardLogger))...)
+			r := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "http://example.com/", strings.NewReader(tc.body))
+			if tc.body == "" {
+				r.ContentLength = -1
+			}
+			for k, v := range tc.header {
+				r.Header[k] = v
+			}
+			w := httptest.NewRecorder()
+			proxy.ServeHTTP(w, r)
+			if want, got := tc.status, w.Code; want != got {
+				t.Errorf("expected status %d, got %d", want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,359 @@
+package wrpchttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.wasmcloud.dev/provider"
+)
+
+const (
+	// AddressConfigKey is the link config key holding the address an
+	// [AddressRouter] listens on for the linked component.
+	AddressConfigKey = "address"
+	// HostConfigKey is the link config key holding the virtual host a
+	// [HostRouter] serves the linked component on.
+	HostConfigKey = "host"
+	// PathConfigKey is the link config key holding the path prefix a
+	// [PathRouter] serves the linked component on.
+	PathConfigKey = "path"
+
+	// DefaultAddress is used by [AddressRouter] for links without an address.
+	DefaultAddress = "0.0.0.0:8000"
+
+	serverShutdownTimeout = 10 * time.Second
+)
+
+var (
+	// ErrNoRoute is returned when components are linked but none matches the request.
+	ErrNoRoute = errors.New("no route")
+	// ErrNoLinks is returned when no component is linked yet.
+	ErrNoLinks = errors.New("no linked components")
+)
+
+// Router picks the component handling a request. Routes are derived from the
+// `TargetConfig` of the links passed to PutLink and DelLink, whose signatures
+// match [provider.SourceLinkPut] and [provider.SourceLinkDel].
+type Router interface {
+	// Route returns the target component for r, [ErrNoRoute] when no link
+	// matches it or [ErrNoLinks] when there are no links at all.
+	Route(r *http.Request) (string, error)
+	PutLink(link provider.InterfaceLinkDefinition) error
+	DelLink(link provider.InterfaceLinkDefinition) error
+}
+
+// WithRouter routes requests with router, keeping up with link changes.
+func WithRouter(router Router) IncomingHandlerOption {
+	return func(p *IncomingRoundTripper) {
+		p.route = router.Route
+	}
+}
+
+func linkID(link provider.InterfaceLinkDefinition) string {
+	return link.Target + "/" + link.Name
+}
+
+type route struct {
+	// link identifier, used to find the route again when the link is updated or deleted
+	id     string
+	target string
+}
+
+// HostRouter routes requests on their `Host` header. Each link declares
+// the host it serves under [HostConfigKey]; a leading wildcard
+// ("*.example.com") matches any subdomain.
+type HostRouter struct {
+	lock  sync.RWMutex
+	hosts map[string]route
+}
+
+var _ Router = (*HostRouter)(nil)
+
+func NewHostRouter() *HostRouter {
+	return &HostRouter{hosts: make(map[string]route)}
+}
+
+func (h *HostRouter) PutLink(link provider.InterfaceLinkDefinition) error {
+	id := linkID(link)
+	host := normalizeHost(link.TargetConfig[HostConfigKey])
+	if host == "" {
+		return fmt.Errorf("link %s: missing %q config", id, HostConfigKey)
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if existing, ok := h.hosts[host]; ok && existing.id != id {
+		return fmt.Errorf("link %s: host %q already routed to link %s", id, host, existing.id)
+	}
+	h.deleteLocked(id)
+	h.hosts[host] = route{id: id, target: link.Target}
+	return nil
+}
+
+func (h *HostRouter) DelLink(link provider.InterfaceLinkDefinition) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.deleteLocked(linkID(link))
+	return nil
+}
+
+func (h *HostRouter) deleteLocked(id string) {
+	for host, r := range h.hosts {
+		if r.id == id {
+			delete(h.hosts, host)
+		}
+	}
+}
+
+func (h *HostRouter) Route(r *http.Request) (string, error) {
+	host := normalizeHost(r.Host)
+
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	if len(h.hosts) == 0 {
+		return "", ErrNoLinks
+	}
+	if rt, ok := h.hosts[host]; ok {
+		return rt.target, nil
+	}
+	// Try wildcards from the most to the least specific
+	for i := strings.IndexByte(host, '.'); i >= 0; {
+		if rt, ok := h.hosts["*"+host[i:]]; ok {
+			return rt.target, nil
+		}
+		next := strings.IndexByte(host[i+1:], '.')
+		if next < 0 {
+			break
+		}
+		i += next + 1
+	}
+	return "", ErrNoRoute
+}
+
+func normalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.TrimSuffix(host, ".")
+}
+
+// PathRouter routes requests on their path. Each link declares the prefix it
+// serves under [PathConfigKey]; the longest matching prefix wins. Prefixes
+// match whole path segments, so "/api" matches "/api/users" but not "/apis".
+type PathRouter struct {
+	lock sync.RWMutex
+	// sorted by decreasing prefix length
+	prefixes []pathRoute
+}
+
+type pathRoute struct {
+	route
+	prefix string
+}
+
+var _ Router = (*PathRouter)(nil)
+
+func NewPathRouter() *PathRouter {
+	return &PathRouter{}
+}
+
+func (p *PathRouter) PutLink(link provider.InterfaceLinkDefinition) error {
+	id := linkID(link)
+	prefix, ok := link.TargetConfig[PathConfigKey]
+	if !ok {
+		return fmt.Errorf("link %s: missing %q config", id, PathConfigKey)
+	}
+	prefix = normalizePrefix(prefix)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for _, pr := range p.prefixes {
+		if pr.prefix == prefix && pr.id != id {
+			return fmt.Errorf("link %s: path %q already routed to link %s", id, prefix, pr.id)
+		}
+	}
+	p.deleteLocked(id)
+	p.prefixes = append(p.prefixes, pathRoute{route: route{id: id, target: link.Target}, prefix: prefix})
+	sort.SliceStable(p.prefixes, func(i, j int) bool {
+		return len(p.prefixes[i].prefix) > len(p.prefixes[j].prefix)
+	})
+	return nil
+}
+
+func (p *PathRouter) DelLink(link provider.InterfaceLinkDefinition) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.deleteLocked(linkID(link))
+	return nil
+}
+
+func (p *PathRouter) deleteLocked(id string) {
+	p.prefixes = slices.DeleteFunc(p.prefixes, func(pr pathRoute) bool {
+		return pr.id == id
+	})
+}
+
+func (p *PathRouter) Route(r *http.Request) (string, error) {
+	path := r.URL.Path
+	if path == "" {
+		path = "/"
+	}
+
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if len(p.prefixes) == 0 {
+		return "", ErrNoLinks
+	}
+	for _, pr := range p.prefixes {
+		if pr.prefix == "/" || path == pr.prefix || strings.HasPrefix(path, pr.prefix+"/") {
+			return pr.target, nil
+		}
+	}
+	return "", ErrNoRoute
+}
+
+func normalizePrefix(prefix string) string {
+	return "/" + strings.Trim(strings.TrimSpace(prefix), "/")
+}
+
+type addressTargetKey struct{}
+
+// AddressRouter runs one HTTP server per link, listening on the address found
+// under [AddressConfigKey] (or [DefaultAddress]). Every server uses the same
+// handler; requests are routed to the component whose link opened the listener.
+type AddressRouter struct {
+	handler http.Handler
+
+	lock    sync.Mutex
+	servers map[string]*addressServer
+}
+
+type addressServer struct {
+	route
+	address string
+	server  *http.Server
+}
+
+var _ Router = (*AddressRouter)(nil)
+
+// NewAddressRouter returns a router serving handler on the address of every
+// link. handler usually proxies requests through an [IncomingRoundTripper]
+// configured [WithRouter] this router.
+func NewAddressRouter(handler http.Handler) *AddressRouter {
+	return &AddressRouter{
+		handler: handler,
+		servers: make(map[string]*addressServer),
+	}
+}
+
+func (a *AddressRouter) PutLink(link provider.InterfaceLinkDefinition) error {
+	id := linkID(link)
+	address := link.TargetConfig[AddressConfigKey]
+	if address == "" {
+		address = DefaultAddress
+	}
+
+	a.lock.Lock()
+	for _, srv := range a.servers {
+		if srv.address == address && srv.id != id {
+			a.lock.Unlock()
+			return fmt.Errorf("link %s: address %q already used by link %s", id, address, srv.id)
+		}
+	}
+
+	existing, ok := a.servers[id]
+	if ok && existing.address == address {
+		a.lock.Unlock()
+		return nil
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		a.lock.Unlock()
+		return fmt.Errorf("link %s: %w", id, err)
+	}
+
+	target := link.Target
+	srv := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			a.handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), addressTargetKey{}, target)))
+		}),
+	}
+	go func() {
+		_ = srv.Serve(listener)
+	}()
+
+	a.servers[id] = &addressServer{
+		route:   route{id: id, target: target},
+		address: address,
+		server:  srv,
+	}
+	a.lock.Unlock()
+
+	// The previous server may take a while to drain, so it is stopped once
+	// the new one is in place and the router unlocked
+	if ok {
+		if err := shutdownServer(existing.server); err != nil {
+			return fmt.Errorf("link %s: stopping previous server: %w", id, err)
+		}
+	}
+	return nil
+}
+
+func (a *AddressRouter) DelLink(link provider.InterfaceLinkDefinition) error {
+	id := linkID(link)
+
+	a.lock.Lock()
+	srv, ok := a.servers[id]
+	delete(a.servers, id)
+	a.lock.Unlock()
+
+	if !ok {
+		return nil
+	}
+	return shutdownServer(srv.server)
+}
+
+func (a *AddressRouter) Route(r *http.Request) (string, error) {
+	if target, ok := r.Context().Value(addressTargetKey{}).(string); ok {
+		return target, nil
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if len(a.servers) == 0 {
+		return "", ErrNoLinks
+	}
+	return "", ErrNoRoute
+}
+
+// Shutdown stops every server.
+func (a *AddressRouter) Shutdown() error {
+	a.lock.Lock()
+	servers := a.servers
+	a.servers = make(map[string]*addressServer)
+	a.lock.Unlock()
+
+	var errs []error
+	for _, srv := range servers {
+		if err := shutdownServer(srv.server); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func shutdownServer(srv *http.Server) error {
+	ctx, cancel := context.WithTimeout(context.Background(), serverShutdownTimeout)
+	defer cancel()
+	return srv.Shutdown(ctx)
+}
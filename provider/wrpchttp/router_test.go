@@ -0,0 +1,206 @@
+package wrpchttp
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.wasmcloud.dev/provider"
+)
+
+func link(target string, config map[string]string) provider.InterfaceLinkDefinition {
+	return provider.InterfaceLinkDefinition{Target: target, Name: "default", TargetConfig: config}
+}
+
+func TestHostRouter(t *testing.T) {
+	router := NewHostRouter()
+
+	if _, err := router.Route(httptest.NewRequest(http.MethodGet, "http://a.example.com/", nil)); !errors.Is(err, ErrNoLinks) {
+		t.Fatalf("expected ErrNoLinks, got %v", err)
+	}
+
+	for _, l := range []provider.InterfaceLinkDefinition{
+		link("component-a", map[string]string{HostConfigKey: "a.example.com"}),
+		link("component-wild", map[string]string{HostConfigKey: "*.example.com"}),
+	} {
+		if err := router.PutLink(l); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	if err := router.PutLink(link("component-b", map[string]string{HostConfigKey: "A.example.com"})); err == nil {
+		t.Error("expected conflicting host to be rejected")
+	}
+	if err := router.PutLink(link("component-b", nil)); err == nil {
+		t.Error("expected missing host to be rejected")
+	}
+
+	tt := map[string]struct {
+		url    string
+		target string
+		err    error
+	}{
+		"exact":     {url: "http://a.example.com/", target: "component-a"},
+		"with port": {url: "http://A.example.com:8080/", target: "component-a"},
+		"wildcard":  {url: "http://b.c.example.com/", target: "component-wild"},
+		"unknown":   {url: "http://example.org/", err: ErrNoRoute},
+	}
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			target, err := router.Route(httptest.NewRequest(http.MethodGet, tc.url, nil))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+			if want, got := tc.target, target; want != got {
+				t.Errorf("expected target %s, got %s", want, got)
+			}
+		})
+	}
+
+	// Updating a link moves its route
+	if err := router.PutLink(link("component-a", map[string]string{HostConfigKey: "new.example.org"})); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if target, _ := router.Route(httptest.NewRequest(http.MethodGet, "http://new.example.org/", nil)); target != "component-a" {
+		t.Errorf("expected updated route, got %q", target)
+	}
+	if target, _ := router.Route(httptest.NewRequest(http.MethodGet, "http://a.example.com/", nil)); target != "component-wild" {
+		t.Errorf("expected old host to fall back to the wildcard, got %q", target)
+	}
+
+	_ = router.DelLink(link("component-a", nil))
+	_ = router.DelLink(link("component-wild", nil))
+	if _, err := router.Route(httptest.NewRequest(http.MethodGet, "http://new.example.org/", nil)); !errors.Is(err, ErrNoLinks) {
+		t.Errorf("expected ErrNoLinks, got %v", err)
+	}
+}
+
+func TestPathRouter(t *testing.T) {
+	router := NewPathRouter()
+
+	for _, l := range []provider.InterfaceLinkDefinition{
+		link("component-api", map[string]string{PathConfigKey: "/api"}),
+		link("component-users", map[string]string{PathConfigKey: "/api/users/"}),
+	} {
+		if err := router.PutLink(l); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+	if err := router.PutLink(link("component-other", map[string]string{PathConfigKey: "api"})); err == nil {
+		t.Error("expected conflicting path to be rejected")
+	}
+
+	tt := map[string]struct {
+		path   string
+		target string
+		err    error
+	}{
+		"prefix":           {path: "/api", target: "component-api"},
+		"nested":           {path: "/api/things", target: "component-api"},
+		"longest prefix":   {path: "/api/users/42", target: "component-users"},
+		"segment boundary": {path: "/apis", err: ErrNoRoute},
+		"root":             {path: "/", err: ErrNoRoute},
+	}
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			target, err := router.Route(httptest.NewRequest(http.MethodGet, tc.path, nil))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+			if want, got := tc.target, target; want != got {
+				t.Errorf("expected target %s, got %s", want, got)
+			}
+		})
+	}
+
+	if err := router.PutLink(link("component-root", map[string]string{PathConfigKey: "/"})); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if target, _ := router.Route(httptest.NewRequest(http.MethodGet, "/apis", nil)); target != "component-root" {
+		t.Errorf("expected root fallback, got %q", target)
+	}
+
+	_ = router.DelLink(link("component-users", nil))
+	if target, _ := router.Route(httptest.NewRequest(http.MethodGet, "/api/users/42", nil)); target != "component-api" {
+		t.Errorf("expected deleted route to fall back, got %q", target)
+	}
+}
+
+func TestAddressRouter(t *testing.T) {
+	var router *AddressRouter
+	router = NewAddressRouter(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		target, err := router.Route(r)
+		if err != nil {
+			http.Error(w, err.Error(), StatusForError(err))
+			return
+		}
+		_, _ = w.Write([]byte(target))
+	}))
+	defer router.Shutdown()
+
+	if _, err := router.Route(httptest.NewRequest(http.MethodGet, "/", nil)); !errors.Is(err, ErrNoLinks) {
+		t.Fatalf("expected ErrNoLinks, got %v", err)
+	}
+
+	// Reserve a free port
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	address := ln.Addr().String()
+	ln.Close()
+
+	if err := router.PutLink(link("component-a", map[string]string{AddressConfigKey: address})); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := router.PutLink(link("component-b", map[string]string{AddressConfigKey: address})); err == nil {
+		t.Error("expected conflicting address to be rejected")
+	}
+
+	resp, err := http.Get("http://" + address + "/")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if want, got := "component-a", string(body); want != got {
+		t.Errorf("expected target %s, got %s", want, got)
+	}
+
+	ln, err = net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	moved := ln.Addr().String()
+	ln.Close()
+
+	if err := router.PutLink(link("component-a", map[string]string{AddressConfigKey: moved})); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, err := http.Get("http://" + address + "/"); err == nil {
+		t.Error("expected previous listener to be closed after the address changed")
+	}
+	address = moved
+	resp, err = http.Get("http://" + address + "/")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	resp.Body.Close()
+
+	if err := router.DelLink(link("component-a", nil)); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, err := http.Get("http://" + address + "/"); err == nil {
+		t.Error("expected listener to be closed after link deletion")
+	}
+}
+
+func TestRoundTripRouteError(t *testing.T) {
+	roundTripper := NewIncomingRoundTripper(fakeNatsCreator{}, WithRouter(NewPathRouter()))
+	if _, err := roundTripper.RoundTrip(httptest.NewRequest(http.MethodGet, "/", nil)); !errors.Is(err, ErrNoLinks) {
+		t.Errorf("expected ErrNoLinks, got %v", err)
+	}
+}
@@ -0,0 +1,187 @@
+package wrpchttp
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const meterName = "go.wasmcloud.dev/provider/wrpchttp"
+
+var ErrInvalidSplit = errors.New("invalid split")
+
+// WeightedTarget is a component receiving a share of the traffic proportional
+// to its weight. Targets with a zero weight only receive traffic on failover.
+type WeightedTarget struct {
+	Target string
+	Weight uint
+}
+
+// Split spreads requests across weighted targets, for example to canary a new
+// version of a component. When the split is built [WithIdempotentRetry],
+// idempotent requests that fail to be invoked fail over to the other targets,
+// in order. Other requests are never sent to a second target, since a
+// transport error such as a timeout may arrive after the component ran.
+type Split struct {
+	targets         []WeightedTarget
+	totalWeight     uint
+	stickyHeader    string
+	stickyCookie    string
+	retryIdempotent bool
+}
+
+type SplitOption func(*Split)
+
+// WithStickyHeader pins requests carrying the same value for header to the
+// same target, as long as the targets don't change.
+func WithStickyHeader(header string) SplitOption {
+	return func(s *Split) {
+		s.stickyHeader = header
+	}
+}
+
+// WithStickyCookie pins requests carrying the same value for cookie to the
+// same target, as long as the targets don't change.
+func WithStickyCookie(cookie string) SplitOption {
+	return func(s *Split) {
+		s.stickyCookie = cookie
+	}
+}
+
+// WithIdempotentRetry fails idempotent requests over to the next target when
+// a target can't be invoked. Requests whose body was consumed by the failed
+// target are replayed with GetBody, as implemented by requests built by
+// [http.NewRequest] for in-memory bodies, and not retried otherwise.
+func WithIdempotentRetry() SplitOption {
+	return func(s *Split) {
+		s.retryIdempotent = true
+	}
+}
+
+func NewSplit(targets []WeightedTarget, opts ...SplitOption) (*Split, error) {
+	s := &Split{targets: targets}
+	for _, t := range targets {
+		if t.Target == "" {
+			return nil, ErrInvalidSplit
+		}
+		s.totalWeight += t.Weight
+	}
+	if s.totalWeight == 0 {
+		return nil, ErrInvalidSplit
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
+
+// WithSplit sends requests to the targets of split instead of a single target.
+func WithSplit(split *Split) IncomingHandlerOption {
+	return func(p *IncomingRoundTripper) {
+		p.split = split
+	}
+}
+
+// order returns the targets to try for r: the weighted pick first, followed
+// by the remaining targets in declaration order.
+func (s *Split) order(r *http.Request) []string {
+	var point uint
+	if key, ok := s.stickyKey(r); ok {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(key))
+		point = uint(h.Sum64() % uint64(s.totalWeight))
+	} else {
+		point = rand.UintN(s.totalWeight)
+	}
+
+	primary := 0
+	for i, t := range s.targets {
+		if point < t.Weight {
+			primary = i
+			break
+		}
+		point -= t.Weight
+	}
+
+	order := make([]string, 0, len(s.targets))
+	order = append(order, s.targets[primary].Target)
+	for i, t := range s.targets {
+		if i != primary {
+			order = append(order, t.Target)
+		}
+	}
+	return order
+}
+
+func (s *Split) stickyKey(r *http.Request) (string, bool) {
+	if s.stickyHeader != "" {
+		if v := r.Header.Get(s.stickyHeader); v != "" {
+			return v, true
+		}
+	}
+	if s.stickyCookie != "" {
+		if c, err := r.Cookie(s.stickyCookie); err == nil && c.Value != "" {
+			return c.Value, true
+		}
+	}
+	return "", false
+}
+
+func isIdempotent(r *http.Request) bool {
+	switch r.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	// Same convention as net/http
+	return r.Header["Idempotency-Key"] != nil || r.Header["X-Idempotency-Key"] != nil
+}
+
+// readTracker records whether a body was read from.
+type readTracker struct {
+	io.ReadCloser
+	read atomic.Bool
+}
+
+func (r *readTracker) Read(p []byte) (int, error) {
+	r.read.Store(true)
+	return r.ReadCloser.Read(p)
+}
+
+type targetMetrics struct {
+	requests metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+func newTargetMetrics(provider metric.MeterProvider) *targetMetrics {
+	meter := provider.Meter(meterName)
+	// Instrument creation only fails on invalid names, in which case the
+	// returned instruments are no-ops.
+	requests, _ := meter.Int64Counter("wrpchttp.target.requests",
+		metric.WithDescription("Requests sent to a component target"),
+		metric.WithUnit("{request}"))
+	duration, _ := meter.Float64Histogram("wrpchttp.target.duration",
+		metric.WithDescription("Time until a component target returned response headers"),
+		metric.WithUnit("s"))
+	return &targetMetrics{requests: requests, duration: duration}
+}
+
+func (m *targetMetrics) record(ctx context.Context, target string, elapsed time.Duration, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	attrs := metric.WithAttributes(
+		attribute.String("target", target),
+		attribute.String("outcome", outcome),
+	)
+	m.requests.Add(ctx, 1, attrs)
+	m.duration.Record(ctx, elapsed.Seconds(), attrs)
+}
@@ -0,0 +1,197 @@
+package wrpchttp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.wasmcloud.dev/provider/internal/wrpc/http/incoming_handler"
+	wrpctypes "go.wasmcloud.dev/provider/internal/wrpc/http/types"
+	wrpc "wrpc.io/go"
+	wrpcnats "wrpc.io/go/nats"
+)
+
+var errNoResponders = errors.New("nats: no responders available for request")
+
+// splitHarness fakes invocations, failing for the targets in down.
+type splitHarness struct {
+	target string
+	down   map[string]bool
+	calls  []string
+	bodies []string
+}
+
+func (h *splitHarness) roundTripper(t *testing.T, split *Split, opts ...IncomingHandlerOption) *IncomingRoundTripper {
+	t.Helper()
+	nc := fakeNatsCreator{
+		OutgoingRpcClientFunc: func(target string) *wrpcnats.Client {
+			h.target = target
+			return nil
+		},
+	}
+	p := NewIncomingRoundTripper(nc, append(opts, WithSplit(split))...)
+	p.invoker = func(_ context.Context, _ wrpc.Invoker, req *wrpctypes.Request) (*wrpc.Result[incoming_handler.Response, incoming_handler.ErrorCode], <-chan error, error) {
+		target := h.target
+		h.calls = append(h.calls, target)
+		body, _ := io.ReadAll(req.Body)
+		h.bodies = append(h.bodies, string(body))
+		if h.down[target] {
+			return nil, nil, errNoResponders
+		}
+		errCh := make(chan error)
+		close(errCh)
+		return wrpc.Ok[incoming_handler.ErrorCode](wrpctypes.Response{
+			Status:   http.StatusOK,
+			Body:     io.NopCloser(strings.NewReader(target)),
+			Trailers: fakeReceiver{headers: http.Header{}},
+		}), errCh, nil
+	}
+	return p
+}
+
+func TestNewSplitInvalid(t *testing.T) {
+	for name, targets := range map[string][]WeightedTarget{
+		"empty":       nil,
+		"zero weight": {{Target: "a"}},
+		"no target":   {{Weight: 1}},
+	} {
+		if _, err := NewSplit(targets); !errors.Is(err, ErrInvalidSplit) {
+			t.Errorf("%s: expected ErrInvalidSplit, got %v", name, err)
+		}
+	}
+}
+
+func TestSplitWeights(t *testing.T) {
+	split, err := NewSplit([]WeightedTarget{{Target: "stable", Weight: 90}, {Target: "canary", Weight: 10}, {Target: "standby"}})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	counts := map[string]int{}
+	for range 10000 {
+		order := split.order(&http.Request{Header: http.Header{}})
+		if len(order) != 3 {
+			t.Fatalf("expected every target in the order, got %v", order)
+		}
+		counts[order[0]]++
+	}
+	if counts["standby"] != 0 {
+		t.Errorf("expected zero weight target to never be picked first, got %d", counts["standby"])
+	}
+	if got := counts["canary"]; got < 700 || got > 1300 {
+		t.Errorf("expected about 10%% of requests on canary, got %d", got)
+	}
+}
+
+func TestSplitSticky(t *testing.T) {
+	split, _ := NewSplit([]WeightedTarget{{Target: "a", Weight: 1}, {Target: "b", Weight: 1}},
+		WithStickyHeader("X-Session"), WithStickyCookie("session"))
+
+	pick := func(r *http.Request) string {
+		return split.order(r)[0]
+	}
+
+	header := &http.Request{Header: http.Header{"X-Session": {"user-42"}}}
+	first := pick(header)
+	cookie := &http.Request{Header: http.Header{"Cookie": {"session=user-42"}}}
+	for range 100 {
+		if got := pick(header); got != first {
+			t.Fatalf("expected sticky header to pin %s, got %s", first, got)
+		}
+		if got := pick(cookie); got != first {
+			t.Fatalf("expected sticky cookie to pin %s, got %s", first, got)
+		}
+	}
+}
+
+func TestSplitFailover(t *testing.T) {
+	split, _ := NewSplit([]WeightedTarget{{Target: "a", Weight: 1}, {Target: "b"}}, WithIdempotentRetry())
+	reader := sdkmetric.NewManualReader()
+	h := &splitHarness{down: map[string]bool{"a": true}}
+	p := h.roundTripper(t, split, WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))))
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+	resp, err := p.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if want, got := "b", string(body); want != got {
+		t.Errorf("expected response from %s, got %s", want, got)
+	}
+	if want, got := "a,b", strings.Join(h.calls, ","); want != got {
+		t.Errorf("expected calls %s, got %s", want, got)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	outcomes := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "wrpchttp.target.requests" {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				target, _ := dp.Attributes.Value(attribute.Key("target"))
+				outcome, _ := dp.Attributes.Value(attribute.Key("outcome"))
+				outcomes[target.AsString()+"/"+outcome.AsString()] += dp.Value
+			}
+		}
+	}
+	if outcomes["a/error"] != 1 || outcomes["b/success"] != 1 {
+		t.Errorf("expected one error on a and one success on b, got %v", outcomes)
+	}
+
+	h.down["b"] = true
+	if _, err := p.RoundTrip(req); !errors.Is(err, errNoResponders) {
+		t.Errorf("expected every target to fail, got %v", err)
+	}
+}
+
+func TestSplitRetryBody(t *testing.T) {
+	tt := map[string]struct {
+		method string
+		body   string
+		opts   []SplitOption
+		calls  string
+	}{
+		"post is not replayed":         {method: http.MethodPost, body: "payload", opts: []SplitOption{WithIdempotentRetry()}, calls: "a"},
+		"put needs retry enabled":      {method: http.MethodPut, body: "payload", calls: "a"},
+		"put is replayed":              {method: http.MethodPut, body: "payload", opts: []SplitOption{WithIdempotentRetry()}, calls: "a,b"},
+		"bodyless post is not retried": {method: http.MethodPost, opts: []SplitOption{WithIdempotentRetry()}, calls: "a"},
+		"bodyless delete needs retry":  {method: http.MethodDelete, calls: "a"},
+		"get needs retry enabled":      {method: http.MethodGet, calls: "a"},
+		"bodyless delete is retried":   {method: http.MethodDelete, opts: []SplitOption{WithIdempotentRetry()}, calls: "a,b"},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			split, _ := NewSplit([]WeightedTarget{{Target: "a", Weight: 1}, {Target: "b"}}, tc.opts...)
+			h := &splitHarness{down: map[string]bool{"a": true}}
+			p := h.roundTripper(t, split)
+
+			var body io.Reader
+			if tc.body != "" {
+				body = strings.NewReader(tc.body)
+			}
+			req, _ := http.NewRequest(tc.method, "http://example.com/", body)
+			_, _ = p.RoundTrip(req)
+			if want, got := tc.calls, strings.Join(h.calls, ","); want != got {
+				t.Errorf("expected calls %s, got %s", want, got)
+			}
+			for _, body := range h.bodies {
+				if body != tc.body {
+					t.Errorf("expected every attempt to carry the full body, got %q", body)
+				}
+			}
+		})
+	}
+}
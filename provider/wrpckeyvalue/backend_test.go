@@ -0,0 +1,190 @@
+package wrpckeyvalue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func testBackends(t *testing.T) map[string]Backend {
+	t.Helper()
+	file, err := NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	t.Cleanup(func() { file.Close() })
+	return map[string]Backend{
+		"memory": NewMemoryBackend(),
+		"file":   file,
+	}
+}
+
+func TestBackends(t *testing.T) {
+	ctx := context.Background()
+	for name, b := range testBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			if _, ok, err := b.Get(ctx, "bucket", "missing"); ok || err != nil {
+				t.Errorf("expected missing key, got ok %v err %v", ok, err)
+			}
+			if err := b.Set(ctx, "bucket", "key", []byte("value")); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if err := b.Set(ctx, "bucket", "empty", nil); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			value, ok, err := b.Get(ctx, "bucket", "key")
+			if err != nil || !ok || string(value) != "value" {
+				t.Errorf("expected value, got %q ok %v err %v", value, ok, err)
+			}
+			if _, ok, _ := b.Get(ctx, "bucket", "empty"); !ok {
+				t.Errorf("expected empty value to exist")
+			}
+			if _, ok, _ := b.Get(ctx, "other", "key"); ok {
+				t.Errorf("expected buckets to be isolated")
+			}
+
+			if err := b.Delete(ctx, "bucket", "key"); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if err := b.Delete(ctx, "bucket", "key"); err != nil {
+				t.Errorf("expected deleting a missing key to succeed, got %v", err)
+			}
+			if _, ok, _ := b.Get(ctx, "bucket", "key"); ok {
+				t.Errorf("expected key to be deleted")
+			}
+
+			for i, want := range []uint64{5, 8} {
+				n, err := b.Increment(ctx, "bucket", "counter", []uint64{5, 3}[i])
+				if err != nil || n != want {
+					t.Errorf("expected counter %d, got %d err %v", want, n, err)
+				}
+			}
+			if value, _, _ := b.Get(ctx, "bucket", "counter"); string(value) != "8" {
+				t.Errorf("expected counter to be stored as decimal, got %q", value)
+			}
+			if _, err := b.Increment(ctx, "bucket", "empty", 1); !errors.Is(err, ErrInvalidCounter) {
+				t.Errorf("expected ErrInvalidCounter, got %v", err)
+			}
+		})
+	}
+}
+
+func TestBackendListKeys(t *testing.T) {
+	ctx := context.Background()
+	for name, b := range testBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			var want []string
+			for i := range 25 {
+				key := fmt.Sprintf("key-%02d", i)
+				want = append(want, key)
+				if err := b.Set(ctx, "bucket", key, nil); err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+			}
+
+			var got []string
+			var cursor uint64
+			pages := 0
+			for {
+				keys, next, err := b.ListKeys(ctx, "bucket", cursor, 10)
+				if err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+				got = append(got, keys...)
+				pages++
+				if next == nil {
+					break
+				}
+				cursor = *next
+			}
+			if pages != 3 {
+				t.Errorf("expected 3 pages, got %d", pages)
+			}
+			if !slices.Equal(want, got) {
+				t.Errorf("expected keys %v, got %v", want, got)
+			}
+
+			keys, next, err := b.ListKeys(ctx, "missing", 0, 10)
+			if err != nil || len(keys) != 0 || next != nil {
+				t.Errorf("expected an empty page, got %v %v %v", keys, next, err)
+			}
+		})
+	}
+}
+
+func TestFileBackendReopen(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	b, err := NewFileBackend(dir)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	_ = b.SetMany(ctx, "a/b", []Entry{{Key: "kept", Value: []byte("1")}, {Key: "deleted", Value: []byte("2")}})
+	_ = b.Delete(ctx, "a/b", "deleted")
+	_ = b.Set(ctx, "a/b", "torn", []byte("3"))
+	b.Close()
+
+	// Simulate a crash in the middle of the last write
+	path := filepath.Join(dir, "a%2Fb"+fileExt)
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := os.Truncate(path, info.Size()-2); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	b, err = NewFileBackend(dir)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer b.Close()
+	keys, _, _ := b.ListKeys(ctx, "a/b", 0, 0)
+	if want, got := "kept", strings.Join(keys, ","); want != got {
+		t.Errorf("expected keys %s, got %s", want, got)
+	}
+
+	// Appends after the discarded tail must survive another reopen
+	_ = b.Set(ctx, "a/b", "after", []byte("4"))
+	b.Close()
+	b, _ = NewFileBackend(dir)
+	defer b.Close()
+	if value, ok, _ := b.Get(ctx, "a/b", "after"); !ok || string(value) != "4" {
+		t.Errorf("expected value written after recovery, got %q ok %v", value, ok)
+	}
+}
+
+func TestFileBackendCompaction(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	b, err := NewFileBackend(dir)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	value := make([]byte, 64*1024)
+	for range 64 {
+		if err := b.Set(ctx, "bucket", "key", value); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+	b.Close()
+
+	info, err := os.Stat(filepath.Join(dir, "bucket"+fileExt))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if info.Size() > fileCompactMinSize+int64(len(value))*2 {
+		t.Errorf("expected log to be compacted, got %d bytes", info.Size())
+	}
+
+	b, _ = NewFileBackend(dir)
+	defer b.Close()
+	if got, ok, _ := b.Get(ctx, "bucket", "key"); !ok || len(got) != len(value) {
+		t.Errorf("expected value to survive compaction, got %d bytes ok %v", len(got), ok)
+	}
+}
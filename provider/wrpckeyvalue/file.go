@@ -0,0 +1,357 @@
+package wrpckeyvalue
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	fileExt = ".kv"
+	// Logs smaller than this are never compacted.
+	fileCompactMinSize = 1 << 20
+
+	recordSet    byte = 1
+	recordDelete byte = 2
+)
+
+var errCorruptRecord = errors.New("corrupt record")
+
+// FileBackend is a [Backend] persisting every bucket to an append-only log
+// file in a directory. Writes are synced to disk before returning. Buckets are
+// loaded in memory when the backend is opened, and logs are rewritten once
+// they hold mostly overwritten or deleted entries.
+//
+// A torn write at the end of a log, for example after a crash, is discarded
+// when the backend is opened. Only one FileBackend may use a directory at a
+// time.
+type FileBackend struct {
+	dir string
+
+	lock    sync.RWMutex
+	buckets map[string]*fileBucket
+}
+
+var _ BatchBackend = (*FileBackend)(nil)
+
+type fileBucket struct {
+	path string
+	file *os.File
+	data map[string][]byte
+	// size of the log and of the records needed to rebuild data
+	size, live int64
+}
+
+// NewFileBackend opens the buckets stored in dir, creating it if needed.
+func NewFileBackend(dir string) (*FileBackend, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &FileBackend{dir: dir, buckets: make(map[string]*fileBucket)}
+	for _, entry := range entries {
+		escaped, ok := strings.CutSuffix(entry.Name(), fileExt)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		name, err := url.PathUnescape(escaped)
+		if err != nil {
+			continue
+		}
+		b, err := openFileBucket(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("failed to open bucket %q: %w", name, err)
+		}
+		f.buckets[name] = b
+	}
+	return f, nil
+}
+
+// Close closes the bucket files.
+func (f *FileBackend) Close() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	var errs []error
+	for _, b := range f.buckets {
+		errs = append(errs, b.file.Close())
+	}
+	return errors.Join(errs...)
+}
+
+func (f *FileBackend) Get(_ context.Context, bucket, key string) ([]byte, bool, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	b, ok := f.buckets[bucket]
+	if !ok {
+		return nil, false, nil
+	}
+	value, ok := b.data[key]
+	return slices.Clone(value), ok, nil
+}
+
+func (f *FileBackend) Set(_ context.Context, bucket, key string, value []byte) error {
+	return f.write(bucket, []Entry{{Key: key, Value: value}}, nil)
+}
+
+func (f *FileBackend) SetMany(_ context.Context, bucket string, entries []Entry) error {
+	return f.write(bucket, entries, nil)
+}
+
+func (f *FileBackend) Delete(_ context.Context, bucket, key string) error {
+	return f.write(bucket, nil, []string{key})
+}
+
+func (f *FileBackend) DeleteMany(_ context.Context, bucket string, keys []string) error {
+	return f.write(bucket, nil, keys)
+}
+
+func (f *FileBackend) ListKeys(_ context.Context, bucket string, cursor uint64, limit int) ([]string, *uint64, error) {
+	f.lock.RLock()
+	var keys []string
+	if b, ok := f.buckets[bucket]; ok {
+		keys = slices.Sorted(maps.Keys(b.data))
+	}
+	f.lock.RUnlock()
+	keys, next := page(keys, cursor, limit)
+	return keys, next, nil
+}
+
+func (f *FileBackend) Increment(_ context.Context, bucket, key string, delta uint64) (uint64, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	var current []byte
+	var exists bool
+	if b, ok := f.buckets[bucket]; ok {
+		current, exists = b.data[key]
+	}
+	n, err := addCounter(current, exists, delta)
+	if err != nil {
+		return 0, err
+	}
+	if err := f.writeLocked(bucket, []Entry{{Key: key, Value: formatCounter(n)}}, nil); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func (f *FileBackend) write(bucket string, entries []Entry, deletes []string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.writeLocked(bucket, entries, deletes)
+}
+
+// writeLocked appends entries and deletes to the log of bucket, then applies
+// them in memory. The lock must be held.
+func (f *FileBackend) writeLocked(bucket string, entries []Entry, deletes []string) error {
+	b, ok := f.buckets[bucket]
+	if !ok {
+		if len(entries) == 0 {
+			return nil
+		}
+		var err error
+		b, err = openFileBucket(filepath.Join(f.dir, url.PathEscape(bucket)+fileExt))
+		if err != nil {
+			return err
+		}
+		f.buckets[bucket] = b
+	}
+
+	var buf []byte
+	for _, e := range entries {
+		buf = appendRecord(buf, recordSet, e.Key, e.Value)
+	}
+	for _, key := range deletes {
+		buf = appendRecord(buf, recordDelete, key, nil)
+	}
+	if err := b.append(buf); err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		b.put(e.Key, slices.Clone(e.Value))
+	}
+	for _, key := range deletes {
+		b.remove(key)
+	}
+
+	if b.size > fileCompactMinSize && b.size > 2*b.live {
+		// The write is already durable, a failed compaction is retried on
+		// the next write.
+		_ = b.compact()
+	}
+	return nil
+}
+
+func openFileBucket(path string) (*fileBucket, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	b := &fileBucket{path: path, file: file, data: make(map[string][]byte)}
+
+	r := bufio.NewReader(file)
+	for {
+		op, key, value, n, err := readRecord(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// Discard the torn tail so later appends start on a record boundary
+			if err := file.Truncate(b.size); err != nil {
+				file.Close()
+				return nil, err
+			}
+			break
+		}
+		b.size += int64(n)
+		switch op {
+		case recordSet:
+			b.put(key, value)
+		case recordDelete:
+			b.remove(key)
+		}
+	}
+	if _, err := file.Seek(b.size, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return b, nil
+}
+
+// append writes records to the log, rolling back partial writes so the log
+// never holds a torn record followed by valid ones.
+func (b *fileBucket) append(buf []byte) error {
+	_, err := b.file.Write(buf)
+	if err == nil {
+		err = b.file.Sync()
+	}
+	if err != nil {
+		if terr := b.file.Truncate(b.size); terr == nil {
+			_, _ = b.file.Seek(b.size, io.SeekStart)
+		}
+		return err
+	}
+	b.size += int64(len(buf))
+	return nil
+}
+
+func (b *fileBucket) put(key string, value []byte) {
+	b.remove(key)
+	b.data[key] = value
+	b.live += int64(recordSize(key, value))
+}
+
+func (b *fileBucket) remove(key string) {
+	if old, ok := b.data[key]; ok {
+		b.live -= int64(recordSize(key, old))
+		delete(b.data, key)
+	}
+}
+
+// compact rewrites the log with only the live entries.
+func (b *fileBucket) compact() error {
+	var buf []byte
+	for key, value := range b.data {
+		buf = appendRecord(buf, recordSet, key, value)
+	}
+
+	tmp := b.path + ".tmp"
+	if err := os.WriteFile(tmp, buf, 0o600); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(tmp, os.O_RDWR, 0o600)
+	if err != nil {
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := os.Rename(tmp, b.path); err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := file.Seek(0, io.SeekEnd); err != nil {
+		file.Close()
+		return err
+	}
+
+	b.file.Close()
+	b.file = file
+	b.size = int64(len(buf))
+	b.live = b.size
+	return nil
+}
+
+// Records are laid out as op, key length, value length, key, value and a
+// CRC-32 of all of the above.
+func appendRecord(buf []byte, op byte, key string, value []byte) []byte {
+	start := len(buf)
+	buf = append(buf, op)
+	buf = binary.AppendUvarint(buf, uint64(len(key)))
+	buf = binary.AppendUvarint(buf, uint64(len(value)))
+	buf = append(buf, key...)
+	buf = append(buf, value...)
+	return binary.BigEndian.AppendUint32(buf, crc32.ChecksumIEEE(buf[start:]))
+}
+
+func recordSize(key string, value []byte) int {
+	return len(appendRecord(nil, recordSet, key, value))
+}
+
+func readRecord(r *bufio.Reader) (op byte, key string, value []byte, n int, err error) {
+	op, err = r.ReadByte()
+	if err != nil {
+		return 0, "", nil, 0, err
+	}
+	if op != recordSet && op != recordDelete {
+		return 0, "", nil, 0, errCorruptRecord
+	}
+	keyLen, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, "", nil, 0, errCorruptRecord
+	}
+	valueLen, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, "", nil, 0, errCorruptRecord
+	}
+	// Guard allocations against corrupt lengths
+	if keyLen > 1<<32 || valueLen > 1<<32 {
+		return 0, "", nil, 0, errCorruptRecord
+	}
+
+	buf := []byte{op}
+	buf = binary.AppendUvarint(buf, keyLen)
+	buf = binary.AppendUvarint(buf, valueLen)
+	header := len(buf)
+	buf = append(buf, make([]byte, keyLen+valueLen+4)...)
+	if _, err := io.ReadFull(r, buf[header:]); err != nil {
+		return 0, "", nil, 0, errCorruptRecord
+	}
+	body := buf[:len(buf)-4]
+	if crc32.ChecksumIEEE(body) != binary.BigEndian.Uint32(buf[len(buf)-4:]) {
+		return 0, "", nil, 0, errCorruptRecord
+	}
+
+	key = string(body[header : header+int(keyLen)])
+	if op == recordSet {
+		value = slices.Clone(body[header+int(keyLen):])
+	}
+	return op, key, value, len(buf), nil
+}
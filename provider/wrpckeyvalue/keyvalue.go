@@ -0,0 +1,374 @@
+// Package wrpckeyvalue serves `wrpc:keyvalue` store, atomics and batch from a
+// pluggable [Backend], so key-value providers only implement storage.
+//
+//	h := wrpckeyvalue.NewHandler(wrpckeyvalue.NewMemoryBackend())
+//	p, _ := provider.New(
+//		provider.TargetLinkPut(h.PutLink),
+//		provider.TargetLinkDel(h.DelLink),
+//	)
+//	stop, _ := h.Serve(p.RPCClient)
+package wrpckeyvalue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.wasmcloud.dev/provider"
+	"go.wasmcloud.dev/provider/internal/exports/wrpc/keyvalue/atomics"
+	"go.wasmcloud.dev/provider/internal/exports/wrpc/keyvalue/batch"
+	"go.wasmcloud.dev/provider/internal/exports/wrpc/keyvalue/store"
+
+	wrpc "wrpc.io/go"
+)
+
+const (
+	// BucketsConfigKey is the link config key mapping the buckets used by a
+	// component to backend buckets, as a comma separated list of
+	// `name=bucket` pairs. A bare `name` maps to itself and `*=bucket` maps
+	// every other name. Once set, unlisted names are denied. An absent key
+	// passes bucket names through unchanged.
+	BucketsConfigKey = "buckets"
+
+	// DefaultListLimit is the default number of keys returned per ListKeys page.
+	DefaultListLimit = 1000
+
+	wildcardBucket = "*"
+)
+
+var (
+	// ErrNoSuchStore is returned by backends for buckets they don't know about.
+	ErrNoSuchStore = errors.New("no such store")
+	// ErrAccessDenied is returned when a component may not use a bucket.
+	ErrAccessDenied = errors.New("access denied")
+	// ErrInvalidCounter is returned when incrementing a value that isn't a counter.
+	ErrInvalidCounter = errors.New("value is not a counter")
+	// ErrInvalidMapping is returned for malformed [BucketsConfigKey] values.
+	ErrInvalidMapping = errors.New("invalid bucket mapping")
+)
+
+// Backend stores the key-value pairs of buckets. Buckets are created on first
+// write. Implementations must be safe for concurrent use.
+type Backend interface {
+	// Get returns the value stored under key, ok is false when bucket holds no such key.
+	Get(ctx context.Context, bucket, key string) (value []byte, ok bool, err error)
+	// Set stores value under key, overwriting any previous value.
+	Set(ctx context.Context, bucket, key string, value []byte) error
+	// Delete removes key from bucket. Deleting a missing key is not an error.
+	Delete(ctx context.Context, bucket, key string) error
+	// ListKeys returns up to limit keys of bucket. The cursor is zero for the
+	// first page and otherwise a value previously returned as next, which is
+	// nil once the last page was returned.
+	ListKeys(ctx context.Context, bucket string, cursor uint64, limit int) (keys []string, next *uint64, err error)
+	// Increment atomically adds delta to the counter stored under key,
+	// starting from zero when the key is missing, and returns the new value.
+	Increment(ctx context.Context, bucket, key string, delta uint64) (uint64, error)
+}
+
+// Entry is a key-value pair written by [BatchBackend.SetMany].
+type Entry struct {
+	Key   string
+	Value []byte
+}
+
+// BatchBackend is implemented by backends able to apply several writes at
+// once. The [Handler] falls back to single writes for other backends.
+type BatchBackend interface {
+	Backend
+	SetMany(ctx context.Context, bucket string, entries []Entry) error
+	DeleteMany(ctx context.Context, bucket string, keys []string) error
+}
+
+// Handler serves `wrpc:keyvalue` store, atomics and batch from a [Backend].
+//
+// Components only get access once linked, see [Handler.PutLink].
+type Handler struct {
+	backend   Backend
+	listLimit int
+
+	lock sync.RWMutex
+	// bucket mapping per linked component, indexed by the component ID. A nil
+	// mapping passes names through.
+	links map[string]map[string]string
+}
+
+var (
+	_ store.Handler   = (*Handler)(nil)
+	_ atomics.Handler = (*Handler)(nil)
+	_ batch.Handler   = (*Handler)(nil)
+)
+
+type HandlerOption func(*Handler)
+
+// WithListLimit sets the maximum number of keys returned per ListKeys page.
+// Defaults to [DefaultListLimit].
+func WithListLimit(limit int) HandlerOption {
+	return func(h *Handler) {
+		h.listLimit = limit
+	}
+}
+
+func NewHandler(backend Backend, opts ...HandlerOption) *Handler {
+	h := &Handler{
+		backend:   backend,
+		listLimit: DefaultListLimit,
+		links:     make(map[string]map[string]string),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Serve exports `wrpc:keyvalue` store, atomics and batch on s, usually the
+// provider's RPCClient.
+func (h *Handler) Serve(s wrpc.Server) (stop func() error, err error) {
+	var stops []func() error
+	stop = func() error {
+		var errs []error
+		for _, stop := range stops {
+			errs = append(errs, stop())
+		}
+		return errors.Join(errs...)
+	}
+
+	for _, serve := range []func(wrpc.Server) (func() error, error){
+		func(s wrpc.Server) (func() error, error) { return store.ServeInterface(s, h) },
+		func(s wrpc.Server) (func() error, error) { return atomics.ServeInterface(s, h) },
+		func(s wrpc.Server) (func() error, error) { return batch.ServeInterface(s, h) },
+	} {
+		stopInterface, err := serve(s)
+		if err != nil {
+			return nil, errors.Join(err, stop())
+		}
+		stops = append(stops, stopInterface)
+	}
+	return stop, nil
+}
+
+// PutLink grants the source component of link access to the buckets mapped by
+// [BucketsConfigKey].
+func (h *Handler) PutLink(link provider.InterfaceLinkDefinition) error {
+	mapping, err := parseMapping(link.TargetConfig[BucketsConfigKey])
+	if err != nil {
+		return err
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.links[link.SourceID] = mapping
+	return nil
+}
+
+// DelLink revokes access for the source component of link.
+func (h *Handler) DelLink(link provider.InterfaceLinkDefinition) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	delete(h.links, link.SourceID)
+	return nil
+}
+
+func parseMapping(config string) (map[string]string, error) {
+	if strings.TrimSpace(config) == "" {
+		return nil, nil
+	}
+
+	mapping := make(map[string]string)
+	for _, pair := range strings.Split(config, ",") {
+		name, bucket, found := strings.Cut(pair, "=")
+		name, bucket = strings.TrimSpace(name), strings.TrimSpace(bucket)
+		if !found {
+			bucket = name
+		}
+		if name == "" || bucket == "" || bucket == wildcardBucket {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidMapping, pair)
+		}
+		if _, ok := mapping[name]; ok {
+			return nil, fmt.Errorf("%w: %q mapped twice", ErrInvalidMapping, name)
+		}
+		mapping[name] = bucket
+	}
+	return mapping, nil
+}
+
+// bucket resolves the backend bucket for the name used by the calling component.
+func (h *Handler) bucket(ctx context.Context, name string) (string, error) {
+	sourceID, ok := provider.SourceIDFromContext(ctx)
+	if !ok {
+		return "", ErrAccessDenied
+	}
+
+	h.lock.RLock()
+	mapping, linked := h.links[sourceID]
+	h.lock.RUnlock()
+	if !linked {
+		return "", ErrAccessDenied
+	}
+	if mapping == nil {
+		return name, nil
+	}
+	if bucket, ok := mapping[name]; ok {
+		return bucket, nil
+	}
+	if bucket, ok := mapping[wildcardBucket]; ok {
+		return bucket, nil
+	}
+	return "", ErrAccessDenied
+}
+
+func (h *Handler) Get(ctx context.Context, bucket string, key string) (*wrpc.Result[[]uint8, store.Error], error) {
+	bucket, err := h.bucket(ctx, bucket)
+	if err != nil {
+		return fail[[]uint8](err), nil
+	}
+	value, ok, err := h.backend.Get(ctx, bucket, key)
+	if err != nil {
+		return fail[[]uint8](err), nil
+	}
+	if !ok {
+		return wrpc.Ok[store.Error]([]uint8(nil)), nil
+	}
+	if value == nil {
+		// An empty value must still be sent as `option::some`
+		value = []uint8{}
+	}
+	return wrpc.Ok[store.Error](value), nil
+}
+
+func (h *Handler) Set(ctx context.Context, bucket string, key string, value []uint8) (*wrpc.Result[struct{}, store.Error], error) {
+	bucket, err := h.bucket(ctx, bucket)
+	if err != nil {
+		return fail[struct{}](err), nil
+	}
+	if err := h.backend.Set(ctx, bucket, key, value); err != nil {
+		return fail[struct{}](err), nil
+	}
+	return wrpc.Ok[store.Error](struct{}{}), nil
+}
+
+func (h *Handler) Delete(ctx context.Context, bucket string, key string) (*wrpc.Result[struct{}, store.Error], error) {
+	bucket, err := h.bucket(ctx, bucket)
+	if err != nil {
+		return fail[struct{}](err), nil
+	}
+	if err := h.backend.Delete(ctx, bucket, key); err != nil {
+		return fail[struct{}](err), nil
+	}
+	return wrpc.Ok[store.Error](struct{}{}), nil
+}
+
+func (h *Handler) Exists(ctx context.Context, bucket string, key string) (*wrpc.Result[bool, store.Error], error) {
+	bucket, err := h.bucket(ctx, bucket)
+	if err != nil {
+		return fail[bool](err), nil
+	}
+	_, ok, err := h.backend.Get(ctx, bucket, key)
+	if err != nil {
+		return fail[bool](err), nil
+	}
+	return wrpc.Ok[store.Error](ok), nil
+}
+
+func (h *Handler) ListKeys(ctx context.Context, bucket string, cursor *uint64) (*wrpc.Result[store.KeyResponse, store.Error], error) {
+	bucket, err := h.bucket(ctx, bucket)
+	if err != nil {
+		return fail[store.KeyResponse](err), nil
+	}
+	var from uint64
+	if cursor != nil {
+		from = *cursor
+	}
+	keys, next, err := h.backend.ListKeys(ctx, bucket, from, h.listLimit)
+	if err != nil {
+		return fail[store.KeyResponse](err), nil
+	}
+	return wrpc.Ok[store.Error](store.KeyResponse{Keys: keys, Cursor: next}), nil
+}
+
+func (h *Handler) Increment(ctx context.Context, bucket string, key string, delta uint64) (*wrpc.Result[uint64, store.Error], error) {
+	bucket, err := h.bucket(ctx, bucket)
+	if err != nil {
+		return fail[uint64](err), nil
+	}
+	n, err := h.backend.Increment(ctx, bucket, key, delta)
+	if err != nil {
+		return fail[uint64](err), nil
+	}
+	return wrpc.Ok[store.Error](n), nil
+}
+
+func (h *Handler) GetMany(ctx context.Context, bucket string, keys []string) (*wrpc.Result[[]*wrpc.Tuple2[string, []uint8], store.Error], error) {
+	bucket, err := h.bucket(ctx, bucket)
+	if err != nil {
+		return fail[[]*wrpc.Tuple2[string, []uint8]](err), nil
+	}
+	values := make([]*wrpc.Tuple2[string, []uint8], len(keys))
+	for i, key := range keys {
+		value, ok, err := h.backend.Get(ctx, bucket, key)
+		if err != nil {
+			return fail[[]*wrpc.Tuple2[string, []uint8]](err), nil
+		}
+		if ok {
+			values[i] = &wrpc.Tuple2[string, []uint8]{V0: key, V1: value}
+		}
+	}
+	return wrpc.Ok[store.Error](values), nil
+}
+
+func (h *Handler) SetMany(ctx context.Context, bucket string, keyValues []*wrpc.Tuple2[string, []uint8]) (*wrpc.Result[struct{}, store.Error], error) {
+	bucket, err := h.bucket(ctx, bucket)
+	if err != nil {
+		return fail[struct{}](err), nil
+	}
+	if b, ok := h.backend.(BatchBackend); ok {
+		entries := make([]Entry, len(keyValues))
+		for i, kv := range keyValues {
+			entries[i] = Entry{Key: kv.V0, Value: kv.V1}
+		}
+		err = b.SetMany(ctx, bucket, entries)
+	} else {
+		for _, kv := range keyValues {
+			if err = h.backend.Set(ctx, bucket, kv.V0, kv.V1); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return fail[struct{}](err), nil
+	}
+	return wrpc.Ok[store.Error](struct{}{}), nil
+}
+
+func (h *Handler) DeleteMany(ctx context.Context, bucket string, keys []string) (*wrpc.Result[struct{}, store.Error], error) {
+	bucket, err := h.bucket(ctx, bucket)
+	if err != nil {
+		return fail[struct{}](err), nil
+	}
+	if b, ok := h.backend.(BatchBackend); ok {
+		err = b.DeleteMany(ctx, bucket, keys)
+	} else {
+		for _, key := range keys {
+			if err = h.backend.Delete(ctx, bucket, key); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return fail[struct{}](err), nil
+	}
+	return wrpc.Ok[store.Error](struct{}{}), nil
+}
+
+// fail converts backend errors to `wrpc:keyvalue/store.error`.
+func fail[T any](err error) *wrpc.Result[T, store.Error] {
+	switch {
+	case errors.Is(err, ErrNoSuchStore):
+		return wrpc.Err[T](*store.NewErrorNoSuchStore())
+	case errors.Is(err, ErrAccessDenied):
+		return wrpc.Err[T](*store.NewErrorAccessDenied())
+	default:
+		return wrpc.Err[T](*store.NewErrorOther(err.Error()))
+	}
+}
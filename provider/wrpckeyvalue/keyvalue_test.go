@@ -0,0 +1,226 @@
+package wrpckeyvalue
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"go.wasmcloud.dev/provider"
+	"go.wasmcloud.dev/provider/internal/exports/wrpc/keyvalue/store"
+	wrpc "wrpc.io/go"
+	wrpcnats "wrpc.io/go/nats"
+)
+
+func sourceContext(source string) context.Context {
+	return wrpcnats.ContextWithHeader(context.Background(), nats.Header{provider.SourceIDHeader: {source}})
+}
+
+func linkedHandler(t *testing.T, buckets string, opts ...HandlerOption) *Handler {
+	t.Helper()
+	h := NewHandler(NewMemoryBackend(), opts...)
+	if err := h.PutLink(provider.InterfaceLinkDefinition{
+		SourceID:     "component",
+		TargetConfig: map[string]string{BucketsConfigKey: buckets},
+	}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	return h
+}
+
+func TestHandlerBucketMapping(t *testing.T) {
+	h := linkedHandler(t, "cache=shared, own, *=fallback")
+	ctx := sourceContext("component")
+
+	tt := map[string]string{
+		"cache":   "shared",
+		"own":     "own",
+		"unknown": "fallback",
+	}
+	for name, bucket := range tt {
+		if _, err := h.Set(ctx, name, "key", []byte(name)); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		value, ok, _ := h.backend.Get(ctx, bucket, "key")
+		if !ok || string(value) != name {
+			t.Errorf("expected %s to be stored in %s, got %q", name, bucket, value)
+		}
+	}
+
+	h = linkedHandler(t, "cache=shared")
+	res, _ := h.Get(ctx, "other", "key")
+	if res.Err == nil || !res.Err.GetAccessDenied() {
+		t.Errorf("expected unmapped bucket to be denied, got %v", res)
+	}
+}
+
+func TestHandlerAccess(t *testing.T) {
+	h := linkedHandler(t, "")
+
+	for name, ctx := range map[string]context.Context{
+		"no header":        context.Background(),
+		"unlinked":         sourceContext("stranger"),
+		"deleted link":     sourceContext("removed"),
+		"linked component": sourceContext("component"),
+	} {
+		res, _ := h.Exists(ctx, "bucket", "key")
+		denied := res.Err != nil && res.Err.GetAccessDenied()
+		if want := name != "linked component"; want != denied {
+			t.Errorf("%s: expected denied %v, got %v", name, want, res)
+		}
+	}
+
+	link := provider.InterfaceLinkDefinition{SourceID: "component"}
+	_ = h.DelLink(link)
+	if res, _ := h.Exists(sourceContext("component"), "bucket", "key"); res.Err == nil {
+		t.Errorf("expected access to be revoked")
+	}
+
+	link.TargetConfig = map[string]string{BucketsConfigKey: "a=b,a=c"}
+	if err := h.PutLink(link); !errors.Is(err, ErrInvalidMapping) {
+		t.Errorf("expected ErrInvalidMapping, got %v", err)
+	}
+}
+
+func TestHandlerStore(t *testing.T) {
+	h := linkedHandler(t, "", WithListLimit(2))
+	ctx := sourceContext("component")
+
+	if res, _ := h.Get(ctx, "bucket", "key"); res.Ok == nil || *res.Ok != nil {
+		t.Errorf("expected none for a missing key, got %v", res)
+	}
+	_, _ = h.Set(ctx, "bucket", "empty", nil)
+	if res, _ := h.Get(ctx, "bucket", "empty"); res.Ok == nil || *res.Ok == nil {
+		t.Errorf("expected some for an empty value, got %v", res)
+	}
+
+	_, _ = h.SetMany(ctx, "bucket", []*wrpc.Tuple2[string, []uint8]{{V0: "a", V1: []byte("1")}, {V0: "b", V1: []byte("2")}})
+	var keys []string
+	var cursor *uint64
+	for {
+		res, _ := h.ListKeys(ctx, "bucket", cursor)
+		if res.Err != nil {
+			t.Fatalf("unexpected error %v", res.Err)
+		}
+		keys = append(keys, res.Ok.Keys...)
+		if cursor = res.Ok.Cursor; cursor == nil {
+			break
+		}
+	}
+	if want := []string{"a", "b", "empty"}; !slices.Equal(want, keys) {
+		t.Errorf("expected keys %v, got %v", want, keys)
+	}
+
+	_, _ = h.DeleteMany(ctx, "bucket", []string{"a", "empty"})
+	res, _ := h.GetMany(ctx, "bucket", []string{"a", "b"})
+	if values := *res.Ok; values[0] != nil || values[1] == nil || string(values[1].V1) != "2" {
+		t.Errorf("expected only b to remain, got %v", values)
+	}
+
+	incr, _ := h.Increment(ctx, "bucket", "b", 40)
+	if incr.Ok == nil || *incr.Ok != 42 {
+		t.Errorf("expected counter 42, got %v", incr)
+	}
+	incr, _ = h.Increment(ctx, "bucket", "missing-counter", 1)
+	if incr.Ok == nil || *incr.Ok != 1 {
+		t.Errorf("expected counter 1, got %v", incr)
+	}
+}
+
+// fakeServer records the functions served, to invoke them without NATS.
+type fakeServer map[string]wrpc.HandleFunc
+
+func (s fakeServer) Serve(instance string, name string, f wrpc.HandleFunc, _ ...wrpc.SubscribePath) (func() error, error) {
+	s[instance+"."+name] = f
+	return func() error {
+		delete(s, instance+"."+name)
+		return nil
+	}, nil
+}
+
+type fakeReader struct{ *bytes.Reader }
+
+func (fakeReader) Index(...uint32) (wrpc.IndexReadCloser, error) {
+	return nil, errors.New("unexpected nested read")
+}
+func (fakeReader) Close() error { return nil }
+
+type fakeWriter struct{ *bytes.Buffer }
+
+func (fakeWriter) Index(...uint32) (wrpc.IndexWriteCloser, error) {
+	return nil, errors.New("unexpected nested write")
+}
+func (fakeWriter) Close() error { return nil }
+
+func appendString(buf []byte, s string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
+
+func (s fakeServer) invoke(t *testing.T, ctx context.Context, fn string, params []byte) *bytes.Buffer {
+	t.Helper()
+	f, ok := s[fn]
+	if !ok {
+		t.Fatalf("%s is not served", fn)
+	}
+	out := fakeWriter{&bytes.Buffer{}}
+	f(ctx, out, fakeReader{bytes.NewReader(params)})
+	return out.Buffer
+}
+
+func TestHandlerServe(t *testing.T) {
+	h := linkedHandler(t, "")
+	ctx := sourceContext("component")
+	s := fakeServer{}
+	stop, err := h.Serve(s)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	params := appendString(appendString(nil, "bucket"), "counter")
+	params = binary.AppendUvarint(params, 300)
+	out := s.invoke(t, ctx, "wrpc:keyvalue/atomics@0.2.0-draft.increment", params)
+	if status, _ := out.ReadByte(); status != 0 {
+		t.Fatalf("expected `result::ok`, got status %d", status)
+	}
+	if n, err := binary.ReadUvarint(out); err != nil || n != 300 {
+		t.Errorf("expected 300, got %d err %v", n, err)
+	}
+
+	// set-many takes a list of (key, value) tuples
+	params = appendString(nil, "bucket")
+	params = binary.AppendUvarint(params, 1)
+	params = appendString(params, "key")
+	params = appendString(params, "value")
+	out = s.invoke(t, ctx, "wrpc:keyvalue/batch@0.2.0-draft.set-many", params)
+	if want, got := []byte{0}, out.Bytes(); !bytes.Equal(want, got) {
+		t.Errorf("expected `result::ok`, got %v", got)
+	}
+
+	params = appendString(nil, "bucket")
+	params = binary.AppendUvarint(params, 2)
+	params = appendString(params, "missing")
+	params = appendString(params, "key")
+	out = s.invoke(t, ctx, "wrpc:keyvalue/batch@0.2.0-draft.get-many", params)
+	want := []byte{0, 2, 0, 1}
+	want = appendString(want, "key")
+	want = appendString(want, "value")
+	if got := out.Bytes(); !bytes.Equal(want, got) {
+		t.Errorf("expected get-many result %v, got %v", want, got)
+	}
+
+	out = s.invoke(t, sourceContext("stranger"), "wrpc:keyvalue/store@0.2.0-draft.get", appendString(appendString(nil, "bucket"), "key"))
+	if want, got := []byte{1, byte(store.ErrorAccessDenied)}, out.Bytes(); !bytes.Equal(want, got) {
+		t.Errorf("expected access-denied error, got %v", got)
+	}
+
+	if err := stop(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if len(s) != 0 {
+		t.Errorf("expected every function to be stopped, got %d", len(s))
+	}
+}
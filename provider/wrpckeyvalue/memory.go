@@ -0,0 +1,129 @@
+package wrpckeyvalue
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// MemoryBackend is a [Backend] keeping buckets in memory. Its content is lost
+// when the provider stops.
+type MemoryBackend struct {
+	lock    sync.RWMutex
+	buckets map[string]map[string][]byte
+}
+
+var _ BatchBackend = (*MemoryBackend)(nil)
+
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{buckets: make(map[string]map[string][]byte)}
+}
+
+func (m *MemoryBackend) Get(_ context.Context, bucket, key string) ([]byte, bool, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	value, ok := m.buckets[bucket][key]
+	return slices.Clone(value), ok, nil
+}
+
+func (m *MemoryBackend) Set(_ context.Context, bucket, key string, value []byte) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.bucket(bucket)[key] = slices.Clone(value)
+	return nil
+}
+
+func (m *MemoryBackend) SetMany(_ context.Context, bucket string, entries []Entry) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	b := m.bucket(bucket)
+	for _, e := range entries {
+		b[e.Key] = slices.Clone(e.Value)
+	}
+	return nil
+}
+
+func (m *MemoryBackend) Delete(_ context.Context, bucket, key string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.buckets[bucket], key)
+	return nil
+}
+
+func (m *MemoryBackend) DeleteMany(_ context.Context, bucket string, keys []string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, key := range keys {
+		delete(m.buckets[bucket], key)
+	}
+	return nil
+}
+
+func (m *MemoryBackend) ListKeys(_ context.Context, bucket string, cursor uint64, limit int) ([]string, *uint64, error) {
+	m.lock.RLock()
+	keys := slices.Sorted(maps.Keys(m.buckets[bucket]))
+	m.lock.RUnlock()
+	keys, next := page(keys, cursor, limit)
+	return keys, next, nil
+}
+
+func (m *MemoryBackend) Increment(_ context.Context, bucket, key string, delta uint64) (uint64, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	b := m.bucket(bucket)
+	value, ok := b[key]
+	n, err := addCounter(value, ok, delta)
+	if err != nil {
+		return 0, err
+	}
+	b[key] = formatCounter(n)
+	return n, nil
+}
+
+// bucket returns the named bucket, creating it if needed. The lock must be held.
+func (m *MemoryBackend) bucket(name string) map[string][]byte {
+	b, ok := m.buckets[name]
+	if !ok {
+		b = make(map[string][]byte)
+		m.buckets[name] = b
+	}
+	return b
+}
+
+// page returns the page of sorted keys starting at offset cursor. Cursors are
+// offsets, so concurrent writes may cause keys to be skipped or repeated
+// across pages, which `wrpc:keyvalue/store` allows.
+func page(keys []string, cursor uint64, limit int) ([]string, *uint64) {
+	if cursor >= uint64(len(keys)) {
+		return []string{}, nil
+	}
+	keys = keys[cursor:]
+	if limit <= 0 || len(keys) <= limit {
+		return keys, nil
+	}
+	next := cursor + uint64(limit)
+	return keys[:limit], &next
+}
+
+// Counters are stored as decimal strings so they stay readable with Get.
+func addCounter(value []byte, exists bool, delta uint64) (uint64, error) {
+	var n uint64
+	if exists {
+		var err error
+		if n, err = strconv.ParseUint(string(value), 10, 64); err != nil {
+			return 0, ErrInvalidCounter
+		}
+	}
+	if n > math.MaxUint64-delta {
+		return 0, fmt.Errorf("%w: increment overflows a 64-bit integer", ErrInvalidCounter)
+	}
+	return n + delta, nil
+}
+
+func formatCounter(n uint64) []byte {
+	return strconv.AppendUint(nil, n, 10)
+}
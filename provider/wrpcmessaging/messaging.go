@@ -0,0 +1,443 @@
+// Package wrpcmessaging bridges NATS subjects and components using
+// `wasmcloud:messaging`.
+//
+// Components linked to the provider publish and send requests through the
+// `consumer` interface, while messages received on the subjects listed in the
+// config of links from the provider are delivered to the `handler` export of
+// the linked component.
+//
+//	h := wrpcmessaging.NewHandler(nc, wasmcloudprovider)
+//	wasmcloudprovider, err := provider.New(
+//		provider.SourceLinkPut(h.PutSourceLink),
+//		provider.SourceLinkDel(h.DelSourceLink),
+//		provider.TargetLinkPut(h.PutTargetLink),
+//		provider.TargetLinkDel(h.DelTargetLink),
+//		provider.Shutdown(h.Shutdown),
+//	)
+//	stop, err := h.Serve(wasmcloudprovider.RPCClient)
+package wrpcmessaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"go.wasmcloud.dev/provider"
+	"go.wasmcloud.dev/provider/internal/exports/wasmcloud/messaging/consumer"
+	"go.wasmcloud.dev/provider/internal/wasmcloud/messaging/handler"
+	"go.wasmcloud.dev/provider/internal/wasmcloud/messaging/types"
+	wrpc "wrpc.io/go"
+	wrpcnats "wrpc.io/go/nats"
+)
+
+const (
+	// SubscriptionsConfigKey is the link source config key holding the comma
+	// separated subjects delivered to the linked component.
+	SubscriptionsConfigKey = "subscriptions"
+	// QueueGroupConfigKey is the optional link source config key holding the
+	// queue group of the link subscriptions, so that several provider
+	// instances share the deliveries instead of each receiving every message.
+	QueueGroupConfigKey = "queue_group"
+	// ClusterURIsConfigKey is the optional link config key holding the comma
+	// separated URLs of a NATS cluster used by the link instead of the
+	// handler connection, read from the source config of links from the
+	// provider and the target config of links to it.
+	ClusterURIsConfigKey = "cluster_uris"
+)
+
+var (
+	ErrNoSubscriptions = errors.New("no subscriptions in link config")
+	ErrNoConnection    = errors.New("no NATS connection for link")
+	// ErrAccessDenied is returned to components that aren't linked to the provider.
+	ErrAccessDenied = errors.New("access denied")
+)
+
+type NatsClientCreator interface {
+	OutgoingRpcClient(target string) *wrpcnats.Client
+}
+
+type HandlerOption func(*Handler)
+
+// WithMaxConcurrency bounds the number of messages of a link handled at the
+// same time. Defaults to 1, delivering the messages of a link in order.
+func WithMaxConcurrency(n int) HandlerOption {
+	return func(h *Handler) {
+		h.concurrency = max(n, 1)
+	}
+}
+
+// WithTimeout bounds the duration of each delivery, and of requests sent
+// without a timeout.
+func WithTimeout(d time.Duration) HandlerOption {
+	return func(h *Handler) {
+		h.timeout = d
+	}
+}
+
+// WithNatsOptions sets the options of the connections made for links
+// configured with [ClusterURIsConfigKey].
+func WithNatsOptions(opts ...nats.Option) HandlerOption {
+	return func(h *Handler) {
+		h.natsOpts = opts
+	}
+}
+
+func WithLogger(logger *slog.Logger) HandlerOption {
+	return func(h *Handler) {
+		h.logger = logger
+	}
+}
+
+// Handler serves `wasmcloud:messaging/consumer` and delivers subscribed
+// messages to `wasmcloud:messaging/handler`.
+//
+// Messages received through a JetStream consumer are acknowledged once the
+// component handled them, and negatively acknowledged when it fails so that
+// they are redelivered. Core NATS messages are delivered at most once.
+type Handler struct {
+	nc          *nats.Conn
+	natsCreator NatsClientCreator
+	natsOpts    []nats.Option
+	invoke      func(context.Context, wrpc.Invoker, *types.BrokerMessage) (*wrpc.Result[struct{}, string], error)
+	concurrency int
+	timeout     time.Duration
+	logger      *slog.Logger
+
+	lock sync.Mutex
+	// subscriptions of the links from the provider, indexed by link key
+	subscriptions map[string]*subscription
+	// connections of the links to the provider, indexed by the component ID
+	publishers map[string]*publisher
+}
+
+var _ consumer.Handler = (*Handler)(nil)
+
+// NewHandler returns a handler using nc, usually the provider's
+// NatsConnection, for links that don't configure their own cluster.
+func NewHandler(nc *nats.Conn, natsCreator NatsClientCreator, opts ...HandlerOption) *Handler {
+	h := &Handler{
+		nc:            nc,
+		natsCreator:   natsCreator,
+		invoke:        handler.HandleMessage,
+		concurrency:   1,
+		timeout:       30 * time.Second,
+		logger:        slog.Default(),
+		subscriptions: make(map[string]*subscription),
+		publishers:    make(map[string]*publisher),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Serve exports `wasmcloud:messaging/consumer` on s, usually the provider's RPCClient.
+func (h *Handler) Serve(s wrpc.Server) (stop func() error, err error) {
+	return consumer.ServeInterface(s, h)
+}
+
+// connect returns the connection of a link, and whether the link owns it.
+func (h *Handler) connect(config map[string]string) (*nats.Conn, bool, error) {
+	uris := splitList(config[ClusterURIsConfigKey])
+	if len(uris) == 0 {
+		if h.nc == nil {
+			return nil, false, ErrNoConnection
+		}
+		return h.nc, false, nil
+	}
+	nc, err := nats.Connect(strings.Join(uris, ","), h.natsOpts...)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to connect to %v: %w", uris, err)
+	}
+	return nc, true, nil
+}
+
+// PutSourceLink subscribes to the subjects of a link from the provider,
+// replacing the subscriptions of a previous definition of the link.
+func (h *Handler) PutSourceLink(link provider.InterfaceLinkDefinition) error {
+	subjects := splitList(link.SourceConfig[SubscriptionsConfigKey])
+	if len(subjects) == 0 {
+		return ErrNoSubscriptions
+	}
+	nc, owned, err := h.connect(link.SourceConfig)
+	if err != nil {
+		return err
+	}
+
+	s := &subscription{
+		handler: h,
+		link:    link,
+		client:  h.natsCreator.OutgoingRpcClient(link.Target),
+		sem:     make(chan struct{}, h.concurrency),
+	}
+	if owned {
+		s.nc = nc
+	}
+	queue := link.SourceConfig[QueueGroupConfigKey]
+	for _, subject := range subjects {
+		sub, err := nc.QueueSubscribe(subject, queue, s.deliver)
+		if err != nil {
+			s.stop()
+			return fmt.Errorf("failed to subscribe to %q: %w", subject, err)
+		}
+		s.subs = append(s.subs, sub)
+	}
+
+	key := linkKey(link)
+	h.lock.Lock()
+	old := h.subscriptions[key]
+	h.subscriptions[key] = s
+	h.lock.Unlock()
+
+	if old != nil {
+		old.stop()
+	}
+	h.logger.Info("subscribed link", "target", link.Target, "name", link.Name, "subjects", subjects)
+	return nil
+}
+
+// DelSourceLink unsubscribes the link and waits for in-flight deliveries to
+// return.
+func (h *Handler) DelSourceLink(link provider.InterfaceLinkDefinition) error {
+	key := linkKey(link)
+	h.lock.Lock()
+	s := h.subscriptions[key]
+	delete(h.subscriptions, key)
+	h.lock.Unlock()
+
+	if s != nil {
+		s.stop()
+		h.logger.Info("unsubscribed link", "target", link.Target, "name", link.Name)
+	}
+	return nil
+}
+
+// PutTargetLink allows the source component of link to publish and send
+// requests.
+func (h *Handler) PutTargetLink(link provider.InterfaceLinkDefinition) error {
+	nc, owned, err := h.connect(link.TargetConfig)
+	if err != nil {
+		return err
+	}
+	p := &publisher{nc: nc, owned: owned}
+
+	h.lock.Lock()
+	old := h.publishers[link.SourceID]
+	h.publishers[link.SourceID] = p
+	h.lock.Unlock()
+
+	if old != nil {
+		old.close()
+	}
+	return nil
+}
+
+// DelTargetLink revokes access for the source component of link.
+func (h *Handler) DelTargetLink(link provider.InterfaceLinkDefinition) error {
+	h.lock.Lock()
+	p := h.publishers[link.SourceID]
+	delete(h.publishers, link.SourceID)
+	h.lock.Unlock()
+
+	if p != nil {
+		p.close()
+	}
+	return nil
+}
+
+// Shutdown unsubscribes all links, waits for in-flight deliveries to return and
+// closes the connections of the links.
+func (h *Handler) Shutdown() error {
+	h.lock.Lock()
+	subscriptions, publishers := h.subscriptions, h.publishers
+	h.subscriptions = make(map[string]*subscription)
+	h.publishers = make(map[string]*publisher)
+	h.lock.Unlock()
+
+	for _, s := range subscriptions {
+		s.stop()
+	}
+	for _, p := range publishers {
+		p.close()
+	}
+	return nil
+}
+
+func (h *Handler) publisher(ctx context.Context) (*nats.Conn, error) {
+	sourceID, ok := provider.SourceIDFromContext(ctx)
+	if !ok {
+		return nil, ErrAccessDenied
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	p, ok := h.publishers[sourceID]
+	if !ok {
+		return nil, ErrAccessDenied
+	}
+	return p.nc, nil
+}
+
+func (h *Handler) Publish(ctx context.Context, msg *types.BrokerMessage) (*wrpc.Result[struct{}, string], error) {
+	nc, err := h.publisher(ctx)
+	if err != nil {
+		return wrpc.Err[struct{}](err.Error()), nil
+	}
+	m := &nats.Msg{Subject: msg.Subject, Data: msg.Body}
+	if msg.ReplyTo != nil {
+		m.Reply = *msg.ReplyTo
+	}
+	if err := nc.PublishMsg(m); err != nil {
+		return wrpc.Err[struct{}](err.Error()), nil
+	}
+	return wrpc.Ok[string](struct{}{}), nil
+}
+
+func (h *Handler) Request(ctx context.Context, subject string, body []uint8, timeoutMs uint32) (*wrpc.Result[types.BrokerMessage, string], error) {
+	nc, err := h.publisher(ctx)
+	if err != nil {
+		return wrpc.Err[types.BrokerMessage](err.Error()), nil
+	}
+	timeout := time.Duration(timeoutMs) * time.Millisecond
+	if timeout == 0 {
+		timeout = h.timeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	resp, err := nc.RequestWithContext(ctx, subject, body)
+	if err != nil {
+		return wrpc.Err[types.BrokerMessage](err.Error()), nil
+	}
+	return wrpc.Ok[string](*brokerMessage(resp)), nil
+}
+
+// subscription delivers the messages of a link from the provider.
+type subscription struct {
+	handler *Handler
+	link    provider.InterfaceLinkDefinition
+	client  wrpc.Invoker
+	subs    []*nats.Subscription
+	// nc is only set for connections owned by the link
+	nc *nats.Conn
+
+	sem    chan struct{}
+	lock   sync.Mutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// deliver is called by the NATS client for every message, one at a time per
+// subject. Blocking on the semaphore applies backpressure to the subscription.
+func (s *subscription) deliver(m *nats.Msg) {
+	s.sem <- struct{}{}
+	s.lock.Lock()
+	if s.closed {
+		s.lock.Unlock()
+		<-s.sem
+		return
+	}
+	s.wg.Add(1)
+	s.lock.Unlock()
+	go func() {
+		defer func() {
+			<-s.sem
+			s.wg.Done()
+		}()
+		s.handle(m)
+	}()
+}
+
+func (s *subscription) handle(m *nats.Msg) {
+	ctx := context.Background()
+	if s.handler.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.handler.timeout)
+		defer cancel()
+	}
+
+	err := func() error {
+		res, err := s.handler.invoke(ctx, s.client, brokerMessage(m))
+		if err != nil {
+			return err
+		}
+		if res.Err != nil {
+			return errors.New(*res.Err)
+		}
+		return nil
+	}()
+
+	logger := s.handler.logger.With("target", s.link.Target, "name", s.link.Name, "subject", m.Subject)
+	if err != nil {
+		logger.Error("failed to handle message", slog.Any("error", err))
+	}
+	if _, mErr := m.Metadata(); mErr != nil {
+		// Not a JetStream message, there is nothing to acknowledge
+		return
+	}
+	ack := m.Ack
+	if err != nil {
+		ack = m.Nak
+	}
+	if err := ack(); err != nil {
+		logger.Error("failed to acknowledge message", slog.Any("error", err))
+	}
+}
+
+// stop unsubscribes, waits for in-flight deliveries and closes the
+// connection owned by the link, if any.
+func (s *subscription) stop() {
+	s.lock.Lock()
+	s.closed = true
+	s.lock.Unlock()
+	for _, sub := range s.subs {
+		if err := sub.Unsubscribe(); err != nil && !errors.Is(err, nats.ErrConnectionClosed) {
+			s.handler.logger.Warn("failed to unsubscribe", "subject", sub.Subject, slog.Any("error", err))
+		}
+	}
+	s.wg.Wait()
+	if s.nc != nil {
+		s.nc.Close()
+	}
+}
+
+// publisher is the connection used by a component linked to the provider.
+type publisher struct {
+	nc    *nats.Conn
+	owned bool
+}
+
+func (p *publisher) close() {
+	if p.owned {
+		p.nc.Close()
+	}
+}
+
+func brokerMessage(m *nats.Msg) *types.BrokerMessage {
+	msg := &types.BrokerMessage{Subject: m.Subject, Body: m.Data}
+	if m.Reply != "" {
+		reply := m.Reply
+		msg.ReplyTo = &reply
+	}
+	return msg
+}
+
+func linkKey(link provider.InterfaceLinkDefinition) string {
+	return link.Target + "/" + link.Name
+}
+
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,343 @@
+package wrpcmessaging
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"go.wasmcloud.dev/provider"
+	"go.wasmcloud.dev/provider/internal/wasmcloud/messaging/types"
+	wrpc "wrpc.io/go"
+	wrpcnats "wrpc.io/go/nats"
+)
+
+func startNats(t *testing.T) *server.Server {
+	t.Helper()
+	s, err := server.NewServer(&server.Options{
+		Port:      server.RANDOM_PORT,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoSigs:    true,
+		NoLog:     true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create nats server: %v", err)
+	}
+	s.Start()
+	if !s.ReadyForConnections(5 * time.Second) {
+		s.Shutdown()
+		t.Fatalf("nats server did not start")
+	}
+	t.Cleanup(func() {
+		s.Shutdown()
+		s.WaitForShutdown()
+	})
+	return s
+}
+
+func connect(t *testing.T, s *server.Server) *nats.Conn {
+	t.Helper()
+	nc, err := nats.Connect(s.ClientURL())
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(nc.Close)
+	return nc
+}
+
+type fakeCreator struct{}
+
+func (fakeCreator) OutgoingRpcClient(string) *wrpcnats.Client { return nil }
+
+// testHandler returns a handler delivering messages to handle.
+func testHandler(nc *nats.Conn, handle func(*types.BrokerMessage) error, opts ...HandlerOption) *Handler {
+	h := NewHandler(nc, fakeCreator{}, opts...)
+	h.invoke = func(_ context.Context, _ wrpc.Invoker, msg *types.BrokerMessage) (*wrpc.Result[struct{}, string], error) {
+		if err := handle(msg); err != nil {
+			return wrpc.Err[struct{}](err.Error()), nil
+		}
+		return wrpc.Ok[string](struct{}{}), nil
+	}
+	return h
+}
+
+func sourceLink(config map[string]string) provider.InterfaceLinkDefinition {
+	return provider.InterfaceLinkDefinition{Target: "component", Name: "default", SourceConfig: config}
+}
+
+func receive(t *testing.T, ch <-chan *types.BrokerMessage) *types.BrokerMessage {
+	t.Helper()
+	select {
+	case msg := <-ch:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for message")
+		return nil
+	}
+}
+
+func TestSubscriptions(t *testing.T) {
+	nc := connect(t, startNats(t))
+	received := make(chan *types.BrokerMessage, 10)
+	h := testHandler(nc, func(msg *types.BrokerMessage) error {
+		received <- msg
+		return nil
+	})
+
+	if err := h.PutSourceLink(sourceLink(nil)); !errors.Is(err, ErrNoSubscriptions) {
+		t.Errorf("expected missing subscriptions to fail, got %v", err)
+	}
+	if err := h.PutSourceLink(sourceLink(map[string]string{SubscriptionsConfigKey: "orders.>, audit"})); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	_ = nc.Flush()
+
+	_ = nc.PublishMsg(&nats.Msg{Subject: "orders.new", Data: []byte("order"), Reply: "inbox"})
+	msg := receive(t, received)
+	if msg.Subject != "orders.new" || string(msg.Body) != "order" || msg.ReplyTo == nil || *msg.ReplyTo != "inbox" {
+		t.Errorf("unexpected message %+v", msg)
+	}
+	_ = nc.Publish("audit", nil)
+	if msg := receive(t, received); msg.Subject != "audit" || msg.ReplyTo != nil {
+		t.Errorf("unexpected message %+v", msg)
+	}
+
+	if err := h.DelSourceLink(sourceLink(nil)); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	_ = nc.Publish("audit", nil)
+	_ = nc.Flush()
+	select {
+	case msg := <-received:
+		t.Errorf("expected no delivery after the link was deleted, got %+v", msg)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestQueueGroup(t *testing.T) {
+	s := startNats(t)
+	nc := connect(t, s)
+
+	var delivered atomic.Int32
+	config := map[string]string{SubscriptionsConfigKey: "work", QueueGroupConfigKey: "workers"}
+	for range 2 {
+		h := testHandler(connect(t, s), func(*types.BrokerMessage) error {
+			delivered.Add(1)
+			return nil
+		})
+		if err := h.PutSourceLink(sourceLink(config)); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		t.Cleanup(func() { _ = h.Shutdown() })
+	}
+
+	for range 10 {
+		_ = nc.Publish("work", nil)
+	}
+	_ = nc.Flush()
+	time.Sleep(200 * time.Millisecond)
+	if n := delivered.Load(); n != 10 {
+		t.Errorf("expected each message to be delivered once, got %d deliveries", n)
+	}
+}
+
+func TestMaxConcurrency(t *testing.T) {
+	nc := connect(t, startNats(t))
+
+	var inFlight, peak atomic.Int32
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(6)
+	h := testHandler(nc, func(*types.BrokerMessage) error {
+		defer wg.Done()
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		<-release
+		return nil
+	}, WithMaxConcurrency(2))
+	if err := h.PutSourceLink(sourceLink(map[string]string{SubscriptionsConfigKey: "jobs"})); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	for range 6 {
+		_ = nc.Publish("jobs", nil)
+	}
+	_ = nc.Flush()
+	time.Sleep(100 * time.Millisecond)
+	if n := inFlight.Load(); n != 2 {
+		t.Errorf("expected 2 messages in flight, got %d", n)
+	}
+	close(release)
+	wg.Wait()
+	if p := peak.Load(); p != 2 {
+		t.Errorf("expected at most 2 messages in flight, got %d", p)
+	}
+
+	// DelSourceLink waits for in-flight deliveries
+	var done atomic.Bool
+	release = make(chan struct{})
+	wg.Add(1)
+	h.invoke = func(context.Context, wrpc.Invoker, *types.BrokerMessage) (*wrpc.Result[struct{}, string], error) {
+		defer wg.Done()
+		<-release
+		done.Store(true)
+		return wrpc.Ok[string](struct{}{}), nil
+	}
+	_ = nc.Publish("jobs", nil)
+	_ = nc.Flush()
+	time.Sleep(50 * time.Millisecond)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+	}()
+	_ = h.DelSourceLink(sourceLink(nil))
+	if !done.Load() {
+		t.Errorf("expected the link deletion to wait for in-flight deliveries")
+	}
+}
+
+func TestJetStreamAcks(t *testing.T) {
+	nc := connect(t, startNats(t))
+	js, err := nc.JetStream()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, err := js.AddStream(&nats.StreamConfig{Name: "EVENTS", Subjects: []string{"events.>"}}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, err := js.AddConsumer("EVENTS", &nats.ConsumerConfig{
+		Durable:        "component",
+		DeliverSubject: "deliver.component",
+		AckPolicy:      nats.AckExplicitPolicy,
+		AckWait:        time.Minute,
+	}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	var attempts atomic.Int32
+	handled := make(chan *types.BrokerMessage, 10)
+	h := testHandler(nc, func(msg *types.BrokerMessage) error {
+		if attempts.Add(1) == 1 {
+			return errors.New("try again")
+		}
+		handled <- msg
+		return nil
+	})
+	if err := h.PutSourceLink(sourceLink(map[string]string{SubscriptionsConfigKey: "deliver.component"})); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	t.Cleanup(func() { _ = h.Shutdown() })
+
+	if _, err := js.Publish("events.created", []byte("event")); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if msg := receive(t, handled); string(msg.Body) != "event" {
+		t.Errorf("unexpected message %+v", msg)
+	}
+	if n := attempts.Load(); n != 2 {
+		t.Errorf("expected the failed delivery to be redelivered once, got %d attempts", n)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		info, err := js.ConsumerInfo("EVENTS", "component")
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if info.NumAckPending == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected message to be acknowledged, %d pending", info.NumAckPending)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSeparateConnection(t *testing.T) {
+	s := startNats(t)
+	nc := connect(t, s)
+
+	received := make(chan *types.BrokerMessage, 1)
+	h := testHandler(nil, func(msg *types.BrokerMessage) error {
+		received <- msg
+		return nil
+	})
+	if err := h.PutSourceLink(sourceLink(map[string]string{SubscriptionsConfigKey: "events"})); !errors.Is(err, ErrNoConnection) {
+		t.Errorf("expected missing connection to fail, got %v", err)
+	}
+	link := sourceLink(map[string]string{SubscriptionsConfigKey: "events", ClusterURIsConfigKey: s.ClientURL()})
+	if err := h.PutSourceLink(link); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	conn := h.subscriptions[linkKey(link)].nc
+
+	time.Sleep(50 * time.Millisecond)
+	_ = nc.Publish("events", []byte("hello"))
+	if msg := receive(t, received); string(msg.Body) != "hello" {
+		t.Errorf("unexpected message %+v", msg)
+	}
+	_ = h.DelSourceLink(link)
+	if !conn.IsClosed() {
+		t.Errorf("expected the link connection to be closed")
+	}
+}
+
+func sourceContext(source string) context.Context {
+	return wrpcnats.ContextWithHeader(context.Background(), nats.Header{provider.SourceIDHeader: {source}})
+}
+
+func TestConsumer(t *testing.T) {
+	nc := connect(t, startNats(t))
+	h := NewHandler(nc, fakeCreator{})
+	ctx := sourceContext("component")
+
+	if res, _ := h.Publish(ctx, &types.BrokerMessage{Subject: "out"}); res.Err == nil || *res.Err != ErrAccessDenied.Error() {
+		t.Errorf("expected unlinked component to be denied, got %v", res)
+	}
+	if err := h.PutTargetLink(provider.InterfaceLinkDefinition{SourceID: "component"}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	sub, err := nc.SubscribeSync("out")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	reply := "reply.here"
+	if res, _ := h.Publish(ctx, &types.BrokerMessage{Subject: "out", Body: []byte("hi"), ReplyTo: &reply}); res.Err != nil {
+		t.Fatalf("unexpected error %s", *res.Err)
+	}
+	m, err := sub.NextMsg(5 * time.Second)
+	if err != nil || string(m.Data) != "hi" || m.Reply != reply {
+		t.Errorf("unexpected message %+v, %v", m, err)
+	}
+
+	if _, err := nc.Subscribe("echo", func(m *nats.Msg) {
+		_ = m.Respond(append([]byte("echo: "), m.Data...))
+	}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	res, _ := h.Request(ctx, "echo", []byte("ping"), 5000)
+	if res.Err != nil || string(res.Ok.Body) != "echo: ping" {
+		t.Errorf("unexpected response %v", res)
+	}
+	if res, _ := h.Request(ctx, "nobody", nil, 50); res.Err == nil {
+		t.Errorf("expected request without responders to fail")
+	}
+
+	_ = h.DelTargetLink(provider.InterfaceLinkDefinition{SourceID: "component"})
+	if res, _ := h.Request(ctx, "echo", nil, 50); res.Err == nil || *res.Err != ErrAccessDenied.Error() {
+		t.Errorf("expected access to be revoked, got %v", res)
+	}
+}
@@ -0,0 +1,105 @@
+// Package wasitelhttp traces requests served by wasi:http components.
+package wasitelhttp
+
+import (
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing returns middleware that starts a server span for each request
+// using the tracer named name from the global OpenTelemetry provider. The
+// parent span is extracted from the W3C traceparent and tracestate
+// headers, and the span is available to handlers through the request
+// context.
+//
+// The result can be passed to middleware.Chain from
+// go.wasmcloud.dev/component/net/wasihttp/middleware. Spans are exported by
+// whatever provider the component configures, for example with
+// [go.wasmcloud.dev/x/wasitel/wasiteltrace].
+func Tracing(name string) func(http.Handler) http.Handler {
+	propagator := propagation.TraceContext{}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx, span := otel.Tracer(name).Start(ctx, r.Method,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(r.Method),
+					semconv.URLPath(r.URL.Path),
+				),
+			)
+			defer span.End()
+
+			rec := &statusRecorder{ResponseWriter: w}
+			r = r.WithContext(ctx)
+			next.ServeHTTP(rec, r)
+
+			// ServeMux records the matched pattern on the request it was given
+			if route := patternRoute(r.Pattern); route != "" {
+				span.SetName(r.Method + " " + route)
+				span.SetAttributes(semconv.HTTPRoute(route))
+			}
+			status := rec.Status()
+			span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+		})
+	}
+}
+
+// patternRoute returns the path of a ServeMux pattern such as
+// "GET /items/{id}", dropping its method.
+func patternRoute(pattern string) string {
+	if _, route, ok := strings.Cut(pattern, " "); ok {
+		return strings.TrimLeft(route, " \t")
+	}
+	return pattern
+}
+
+// statusRecorder tracks the status of a response written through it.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(p []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.ResponseWriter.Write(p)
+}
+
+// Status returns the response status, or 200 if the handler didn't write
+// anything.
+func (r *statusRecorder) Status() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
+
+func (r *statusRecorder) Flush() {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	http.NewResponseController(r.ResponseWriter).Flush()
+}
+
+// Unwrap allows [http.ResponseController] to reach the underlying writer.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
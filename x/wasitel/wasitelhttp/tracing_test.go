@@ -0,0 +1,54 @@
+package wasitelhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if !trace.SpanContextFromContext(r.Context()).IsValid() {
+			t.Error("expected the span in the request context")
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/items/42", nil)
+	req.Header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	Tracing("test")(mux).ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected one span, got %d", len(spans))
+	}
+	span := spans[0]
+	if want, got := "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String(); want != got {
+		t.Errorf("expected trace ID %s, got %s", want, got)
+	}
+	if want, got := "00f067aa0ba902b7", span.Parent().SpanID().String(); want != got {
+		t.Errorf("expected parent span ID %s, got %s", want, got)
+	}
+	if !span.Parent().IsRemote() {
+		t.Error("expected a remote parent")
+	}
+	if want, got := trace.SpanKindServer, span.SpanKind(); want != got {
+		t.Errorf("expected span kind %v, got %v", want, got)
+	}
+	if want, got := "GET /items/{id}", span.Name(); want != got {
+		t.Errorf("expected span name %q, got %q", want, got)
+	}
+	if want, got := codes.Error, span.Status().Code; want != got {
+		t.Errorf("expected status %v, got %v", want, got)
+	}
+}